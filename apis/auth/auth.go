@@ -0,0 +1,48 @@
+package auth
+
+import (
+	"muti-kube/apis"
+	"muti-kube/middleware"
+	authModels "muti-kube/models/auth"
+	"muti-kube/pkg/authorization"
+	"muti-kube/pkg/consts"
+
+	"github.com/gin-gonic/gin"
+)
+
+type Auth struct {
+	apis.Base
+	authorizer authorization.Authorizer
+}
+
+func NewAuth(authorizer authorization.Authorizer) *Auth {
+	return &Auth{
+		authorizer: authorizer,
+	}
+}
+
+// CanI Check whether the current user may run the verb on the resource in the cluster and namespace
+func (ac *Auth) CanI(c *gin.Context) {
+	review := &authModels.AccessReview{}
+	if err := c.ShouldBindQuery(review); err != nil {
+		ac.Error(c, consts.ErrorAccessReview, err, "")
+		return
+	}
+	user, _ := middleware.GetUser(c)
+	if ac.authorizer == nil {
+		ac.OK(c, &authModels.AccessReviewResult{User: user, Allowed: true, Reason: "authorization disabled"}, "")
+		return
+	}
+	allowed, reason, err := ac.authorizer.Authorize(authorization.Attributes{
+		User:      user,
+		Verb:      review.Verb,
+		Resource:  review.Resource,
+		Cluster:   review.Cluster,
+		Namespace: review.Namespace,
+	})
+	if err != nil {
+		ac.Error(c, consts.ErrorAccessReview, err, "")
+		return
+	}
+	ac.OK(c, &authModels.AccessReviewResult{User: user, Allowed: allowed, Reason: reason}, "")
+}
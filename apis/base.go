@@ -1,7 +1,9 @@
 package apis
 
 import (
+	"muti-kube/middleware"
 	"muti-kube/models/common"
+	"muti-kube/pkg/service"
 	"muti-kube/pkg/util/logger"
 	"net/http"
 	"strconv"
@@ -23,6 +25,13 @@ func (b *Base) GetPagination(c *gin.Context) *common.Pagination {
 	}
 }
 
+// UserOption the service option carrying the authenticated user of the request,
+// requests to member clusters impersonate the user when impersonation is enabled
+func (b *Base) UserOption(c *gin.Context) service.OpOption {
+	user, _ := middleware.GetUser(c)
+	return service.WithUser(user)
+}
+
 func (b *Base) OK(c *gin.Context, data interface{}, msg string) {
 	var res common.Response
 	res.Data = data
@@ -53,3 +62,24 @@ func (b *Base) Error(c *gin.Context, code int, err error, msg string) {
 	logger.Error(res.Msg)
 	c.JSON(http.StatusOK, res.ReturnError(code))
 }
+
+// ErrorWithData Respond with an error together with the data describing it
+func (b *Base) ErrorWithData(c *gin.Context, code int, err error, data interface{}, msg string) {
+	var res common.Response
+	res.Msg = err.Error()
+	res.Data = data
+	if msg != "" {
+		res.Msg = msg
+	}
+	logger.Error(res.Msg)
+	c.JSON(http.StatusOK, res.ReturnError(code))
+}
+
+// BadRequest Respond with 400 for requests that can not be handled, data describes the accepted input
+func (b *Base) BadRequest(c *gin.Context, code int, err error, data interface{}) {
+	var res common.Response
+	res.Msg = err.Error()
+	res.Data = data
+	logger.Warn(res.Msg)
+	c.JSON(http.StatusBadRequest, res.ReturnError(code))
+}
@@ -1,7 +1,10 @@
 package cluster
 
 import (
+	"errors"
+	"fmt"
 	"muti-kube/apis"
+	"muti-kube/apis/core"
 	"muti-kube/models/cluster"
 	"muti-kube/pkg/consts"
 	"muti-kube/pkg/service"
@@ -15,7 +18,8 @@ import (
 
 type Cluster struct {
 	apis.Base
-	cs clusterService.Interface
+	cs          clusterService.Interface
+	nodeActions *core.ActionRegistry
 }
 
 func NewCluster() (*Cluster, error) {
@@ -23,15 +27,33 @@ func NewCluster() (*Cluster, error) {
 	if err != nil {
 		return nil, err
 	}
-	return &Cluster{
+	cc := &Cluster{
 		cs: tmp,
-	}, nil
+	}
+	cc.nodeActions = core.NewActionRegistry("node", "",
+		&core.ActionHandler{
+			Action:      apis.CordonAction,
+			Description: "mark the node unschedulable, the pods running on it are not affected",
+			Handle:      cc.cordonNode,
+		},
+		&core.ActionHandler{
+			Action:      apis.UncordonAction,
+			Description: "mark the node schedulable again",
+			Handle:      cc.uncordonNode,
+		},
+	)
+	return cc, nil
 }
 
-// GetClusters Obtain the cluster list
+// GetClusters Obtain the cluster list filtered by a label selector
 func (cc *Cluster) GetClusters(c *gin.Context) {
 	pagination := cc.GetPagination(c)
-	clusters, count, err := cc.cs.GetClusters(service.WithPagination(pagination))
+	clusterQuery := cluster.Query{}
+	if err := c.ShouldBindQuery(&clusterQuery); err != nil {
+		cc.BadRequest(c, consts.ERRGETCLUSTERS, err, "")
+		return
+	}
+	clusters, count, err := cc.cs.GetClusters(clusterQuery, service.WithPagination(pagination))
 	if err != nil {
 		cc.Error(c, consts.ERRGETCLUSTERS, err, "")
 		return
@@ -42,7 +64,7 @@ func (cc *Cluster) GetClusters(c *gin.Context) {
 // GetCluster Obtain cluster details based on the cluster ID
 func (cc *Cluster) GetCluster(c *gin.Context) {
 	clusterID := c.Param("clusterID")
-	clusterData, err := cc.cs.GetCluster(clusterID)
+	clusterData, err := cc.cs.GetCluster(clusterID, cc.UserOption(c))
 	if err != nil {
 		cc.Error(c, consts.ERRGETCLUSTER, err, "")
 		return
@@ -50,21 +72,80 @@ func (cc *Cluster) GetCluster(c *gin.Context) {
 	cc.OK(c, clusterData, "")
 }
 
-// CreateCluster import the required information to a cluster
+// CreateCluster import the required information to a cluster,
+// with action=dry-run only the preflight report is returned
 func (cc *Cluster) CreateCluster(c *gin.Context) {
 	clusterPost := &cluster.Post{}
 	if err := c.ShouldBindJSON(clusterPost); err != nil {
 		cc.Error(c, consts.ERRCREATECLUSTER, err, "")
 		return
 	}
+	if c.DefaultQuery("action", apis.CreateAction) == apis.DryRunAction {
+		cc.OK(c, cc.cs.PreflightCluster(clusterPost), "")
+		return
+	}
 	clusterData, err := cc.cs.CreateCluster(clusterPost)
 	if err != nil {
-		cc.Error(c, consts.ERRCREATECLUSTER, err, "")
+		cc.preflightError(c, consts.ERRCREATECLUSTER, err)
+		return
+	}
+	cc.OK(c, clusterData, "")
+}
+
+// UpdateCluster Replace the information of the cluster
+func (cc *Cluster) UpdateCluster(c *gin.Context) {
+	clusterID := c.Param("clusterID")
+	clusterPost := &cluster.Post{}
+	if err := c.ShouldBindJSON(clusterPost); err != nil {
+		cc.Error(c, consts.ERRUPDATECLUSTER, err, "")
+		return
+	}
+	clusterData, err := cc.cs.UpdateCluster(clusterID, clusterPost)
+	if err != nil {
+		cc.preflightError(c, consts.ERRUPDATECLUSTER, err)
+		return
+	}
+	cc.OK(c, clusterData, "")
+}
+
+// PatchCluster Update part of the information of the cluster
+func (cc *Cluster) PatchCluster(c *gin.Context) {
+	clusterID := c.Param("clusterID")
+	clusterPatch := &cluster.Patch{}
+	if err := c.ShouldBindJSON(clusterPatch); err != nil {
+		cc.Error(c, consts.ERRUPDATECLUSTER, err, "")
+		return
+	}
+	clusterData, err := cc.cs.PatchCluster(clusterID, clusterPatch)
+	if err != nil {
+		cc.preflightError(c, consts.ERRUPDATECLUSTER, err)
 		return
 	}
 	cc.OK(c, clusterData, "")
 }
 
+// DeleteCluster Remove the cluster from muti-kube
+func (cc *Cluster) DeleteCluster(c *gin.Context) {
+	clusterID := c.Param("clusterID")
+	if err := cc.cs.DeleteCluster(clusterID); err != nil {
+		cc.Error(c, consts.ERRDELETECLUSTER, err, "")
+		return
+	}
+	cc.OK(c, nil, fmt.Sprintf("delete cluster %s success", clusterID))
+}
+
+// GetNodes Obtain the capacity, allocatable, requests, limits and usage of the nodes of the cluster
+func (cc *Cluster) GetNodes(c *gin.Context) {
+	pagination := cc.GetPagination(c)
+	clusterID := c.Param("clusterID")
+	nodes, count, err := cc.cs.GetNodeResources(clusterID, service.WithPagination(pagination), cc.UserOption(c))
+	if err != nil {
+		cc.Error(c, consts.ERRGETNODES, err, "")
+		return
+	}
+	cc.PageOK(c, nodes, count, pagination, "")
+}
+
 // GetNodeMetrics You can obtain node monitoring indicators based on the cluster ID, node name, and monitoring indicators
 func (cc *Cluster) GetNodeMetrics(c *gin.Context) {
 	clusterID := c.Param("clusterID")
@@ -99,3 +180,13 @@ func (cc *Cluster) GetNodeMetrics(c *gin.Context) {
 	}
 	cc.OK(c, nodeMetric, "")
 }
+
+// preflightError respond with the preflight report when the error is caused by a failed preflight check
+func (cc *Cluster) preflightError(c *gin.Context, code int, err error) {
+	var preflightErr *clusterService.PreflightError
+	if errors.As(err, &preflightErr) {
+		cc.ErrorWithData(c, code, err, preflightErr.Report, "")
+		return
+	}
+	cc.Error(c, code, err, "")
+}
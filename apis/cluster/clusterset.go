@@ -0,0 +1,87 @@
+package cluster
+
+import (
+	"fmt"
+	"muti-kube/apis"
+	"muti-kube/models/cluster"
+	"muti-kube/pkg/consts"
+	"muti-kube/pkg/service"
+	clusterService "muti-kube/pkg/service/cluster"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ClusterSet struct {
+	apis.Base
+	css clusterService.ClusterSetInterface
+}
+
+func NewClusterSet() (*ClusterSet, error) {
+	tmp, err := clusterService.NewClusterSet()
+	if err != nil {
+		return nil, err
+	}
+	return &ClusterSet{
+		css: tmp,
+	}, nil
+}
+
+// GetClusterSets Obtain the cluster sets with the names of their clusters
+func (sc *ClusterSet) GetClusterSets(c *gin.Context) {
+	pagination := sc.GetPagination(c)
+	clusterSets, count, err := sc.css.GetClusterSets(service.WithPagination(pagination), sc.UserOption(c))
+	if err != nil {
+		sc.Error(c, consts.ErrorGetClusterSets, err, "")
+		return
+	}
+	sc.PageOK(c, clusterSets, count, pagination, "")
+}
+
+func (sc *ClusterSet) GetClusterSet(c *gin.Context) {
+	name := c.Param("name")
+	clusterSet, err := sc.css.GetClusterSet(name, sc.UserOption(c))
+	if err != nil {
+		sc.Error(c, consts.ErrorGetClusterSet, err, "")
+		return
+	}
+	sc.OK(c, clusterSet, fmt.Sprintf("get cluster set %s success", name))
+}
+
+func (sc *ClusterSet) CreateClusterSet(c *gin.Context) {
+	clusterSetPost := cluster.ClusterSetPost{}
+	if err := c.ShouldBindJSON(&clusterSetPost); err != nil {
+		sc.Error(c, consts.ErrorCreateClusterSet, err, "")
+		return
+	}
+	clusterSet, err := sc.css.CreateClusterSet(clusterSetPost, sc.UserOption(c))
+	if err != nil {
+		sc.Error(c, consts.ErrorCreateClusterSet, err, "")
+		return
+	}
+	sc.OK(c, clusterSet, "create cluster set success")
+}
+
+func (sc *ClusterSet) UpdateClusterSet(c *gin.Context) {
+	name := c.Param("name")
+	clusterSetUpdate := cluster.ClusterSetUpdate{}
+	if err := c.ShouldBindJSON(&clusterSetUpdate); err != nil {
+		sc.Error(c, consts.ErrorUpdateClusterSet, err, "")
+		return
+	}
+	clusterSet, err := sc.css.UpdateClusterSet(name, clusterSetUpdate, sc.UserOption(c))
+	if err != nil {
+		sc.Error(c, consts.ErrorUpdateClusterSet, err, "")
+		return
+	}
+	sc.OK(c, clusterSet, fmt.Sprintf("update cluster set %s success", name))
+}
+
+// DeleteClusterSet Remove the cluster set, the clusters must leave the set first
+func (sc *ClusterSet) DeleteClusterSet(c *gin.Context) {
+	name := c.Param("name")
+	if err := sc.css.DeleteClusterSet(name, sc.UserOption(c)); err != nil {
+		sc.Error(c, consts.ErrorDeleteClusterSet, err, "")
+		return
+	}
+	sc.OK(c, nil, fmt.Sprintf("delete cluster set %s success", name))
+}
@@ -0,0 +1,108 @@
+package cluster
+
+import (
+	"fmt"
+	"muti-kube/models/cluster"
+	"muti-kube/pkg/consts"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetNode Obtain the node of the cluster
+func (cc *Cluster) GetNode(c *gin.Context) {
+	clusterID := c.Param("clusterID")
+	nodeName := c.Param("nodeName")
+	node, err := cc.cs.GetNode(clusterID, nodeName, cc.UserOption(c))
+	if err != nil {
+		cc.Error(c, consts.ERRGETNODE, err, "")
+		return
+	}
+	cc.OK(c, node, "")
+}
+
+// NodeAction Run the cordon or uncordon action on the node
+func (cc *Cluster) NodeAction(c *gin.Context) {
+	cc.nodeActions.Dispatch(c)
+}
+
+func (cc *Cluster) cordonNode(c *gin.Context) {
+	cc.setNodeUnschedulable(c, true)
+}
+
+func (cc *Cluster) uncordonNode(c *gin.Context) {
+	cc.setNodeUnschedulable(c, false)
+}
+
+func (cc *Cluster) setNodeUnschedulable(c *gin.Context, unschedulable bool) {
+	clusterID := c.Param("clusterID")
+	nodeName := c.Param("nodeName")
+	node, err := cc.cs.CordonNode(clusterID, nodeName, unschedulable, cc.UserOption(c))
+	if err != nil {
+		cc.Error(c, consts.ERRCORDONNODE, err, "")
+		return
+	}
+	cc.OK(c, node, fmt.Sprintf("set node %s unschedulable %t success", nodeName, unschedulable))
+}
+
+// UpdateNodeLabels Add, change or remove labels of the node
+func (cc *Cluster) UpdateNodeLabels(c *gin.Context) {
+	clusterID := c.Param("clusterID")
+	nodeName := c.Param("nodeName")
+	nodeLabels := cluster.NodeLabels{}
+	if err := c.ShouldBindJSON(&nodeLabels); err != nil {
+		cc.Error(c, consts.ERRUPDATENODELABELS, err, "")
+		return
+	}
+	node, err := cc.cs.UpdateNodeLabels(clusterID, nodeName, nodeLabels, cc.UserOption(c))
+	if err != nil {
+		cc.Error(c, consts.ERRUPDATENODELABELS, err, "")
+		return
+	}
+	cc.OK(c, node, "")
+}
+
+// UpdateNodeTaints Replace the taints of the node
+func (cc *Cluster) UpdateNodeTaints(c *gin.Context) {
+	clusterID := c.Param("clusterID")
+	nodeName := c.Param("nodeName")
+	nodeTaints := cluster.NodeTaints{}
+	if err := c.ShouldBindJSON(&nodeTaints); err != nil {
+		cc.Error(c, consts.ERRUPDATENODETAINTS, err, "")
+		return
+	}
+	node, err := cc.cs.UpdateNodeTaints(clusterID, nodeName, nodeTaints, cc.UserOption(c))
+	if err != nil {
+		cc.Error(c, consts.ERRUPDATENODETAINTS, err, "")
+		return
+	}
+	cc.OK(c, node, "")
+}
+
+// DrainNode Cordon the node and start evicting its pods, the progress is polled with GetNodeDrain
+func (cc *Cluster) DrainNode(c *gin.Context) {
+	clusterID := c.Param("clusterID")
+	nodeName := c.Param("nodeName")
+	nodeDrain := cluster.NodeDrain{}
+	if err := c.ShouldBindJSON(&nodeDrain); err != nil {
+		cc.Error(c, consts.ERRDRAINNODE, err, "")
+		return
+	}
+	status, err := cc.cs.DrainNode(clusterID, nodeName, nodeDrain, cc.UserOption(c))
+	if err != nil {
+		cc.Error(c, consts.ERRDRAINNODE, err, "")
+		return
+	}
+	cc.OK(c, status, fmt.Sprintf("drain node %s started", nodeName))
+}
+
+// GetNodeDrain Obtain the progress of the last drain of the node
+func (cc *Cluster) GetNodeDrain(c *gin.Context) {
+	clusterID := c.Param("clusterID")
+	nodeName := c.Param("nodeName")
+	status, err := cc.cs.GetNodeDrain(clusterID, nodeName)
+	if err != nil {
+		cc.Error(c, consts.ERRGETNODEDRAIN, err, "")
+		return
+	}
+	cc.OK(c, status, "")
+}
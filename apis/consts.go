@@ -1,7 +1,17 @@
 package apis
 
 const (
-	DryRunAction = "dry-run"
-	CreateAction = "create"
-	ScaleReplicasAction = "scale-replicas"
+	DryRunAction               = "dry-run"
+	CreateAction               = "create"
+	ScaleReplicasAction        = "scale-replicas"
+	RestartAction              = "restart"
+	PauseAction                = "pause"
+	ResumeAction               = "resume"
+	RollbackAction             = "rollback"
+	SuspendAction              = "suspend"
+	TriggerAction              = "trigger"
+	CreateDockerRegistryAction = "create-docker-registry"
+	CreateTLSAction            = "create-tls"
+	CordonAction               = "cordon"
+	UncordonAction             = "uncordon"
 )
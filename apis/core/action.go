@@ -0,0 +1,81 @@
+package core
+
+import (
+	"fmt"
+	"muti-kube/apis"
+	"muti-kube/pkg/consts"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ActionQuery the query parameter selecting the action of a request
+const ActionQuery = "action"
+
+// ActionHandler handles one action of a resource
+type ActionHandler struct {
+	// Action the value of the action query parameter
+	Action string `json:"action"`
+	// Description what the action does
+	Description string `json:"description"`
+	// Body an example of the request body of the action, nil when the action takes no body
+	Body interface{} `json:"body,omitempty"`
+	// Handle runs the action
+	Handle gin.HandlerFunc `json:"-"`
+}
+
+// ActionRegistry dispatches the requests of a resource to the handler of the action query parameter,
+// unknown actions are rejected with 400 and the supported actions
+type ActionRegistry struct {
+	apis.Base
+	resource      string
+	defaultAction string
+	handlers      map[string]*ActionHandler
+	actions       []*ActionHandler
+}
+
+// NewActionRegistry defaultAction is used when the request has no action, empty means the action is required
+func NewActionRegistry(resource string, defaultAction string, handlers ...*ActionHandler) *ActionRegistry {
+	r := &ActionRegistry{
+		resource:      resource,
+		defaultAction: defaultAction,
+		handlers:      make(map[string]*ActionHandler, len(handlers)),
+	}
+	for _, handler := range handlers {
+		if _, ok := r.handlers[handler.Action]; ok {
+			panic(fmt.Sprintf("action %s of %s registered twice", handler.Action, resource))
+		}
+		r.handlers[handler.Action] = handler
+		r.actions = append(r.actions, handler)
+	}
+	if defaultAction != "" && r.handlers[defaultAction] == nil {
+		panic(fmt.Sprintf("default action %s of %s is not registered", defaultAction, resource))
+	}
+	return r
+}
+
+// Actions the supported actions in the order they were registered
+func (r *ActionRegistry) Actions() []*ActionHandler {
+	return r.actions
+}
+
+// IsAction matches the requests whose action query parameter is the action
+func IsAction(action string) func(c *gin.Context) bool {
+	return func(c *gin.Context) bool {
+		return c.Query(ActionQuery) == action
+	}
+}
+
+// Dispatch run the handler of the action of the request
+func (r *ActionRegistry) Dispatch(c *gin.Context) {
+	action := c.DefaultQuery(ActionQuery, r.defaultAction)
+	handler, ok := r.handlers[action]
+	if !ok {
+		err := fmt.Errorf("unsupported action %q of %s", action, r.resource)
+		if action == "" {
+			err = fmt.Errorf("action of %s is required", r.resource)
+		}
+		r.BadRequest(c, consts.ErrorUnsupportedAction, err, r.actions)
+		return
+	}
+	handler.Handle(c)
+}
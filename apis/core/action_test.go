@@ -0,0 +1,91 @@
+package core
+
+import (
+	"encoding/json"
+	"muti-kube/models/common"
+	"muti-kube/pkg/consts"
+	"muti-kube/pkg/util/logger"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func newTestActionRouter(defaultAction string) *gin.Engine {
+	registry := NewActionRegistry("tests", defaultAction,
+		&ActionHandler{Action: "create", Handle: func(c *gin.Context) { c.String(http.StatusOK, "create") }},
+		&ActionHandler{Action: "restart", Handle: func(c *gin.Context) { c.String(http.StatusOK, "restart") }},
+	)
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.POST("/tests", registry.Dispatch)
+	return r
+}
+
+func TestActionRegistryDispatch(t *testing.T) {
+	logger.Init()
+	tests := []struct {
+		defaultAction string
+		url           string
+		code          int
+		body          string
+	}{
+		{defaultAction: "create", url: "/tests", code: http.StatusOK, body: "create"},
+		{defaultAction: "create", url: "/tests?action=restart", code: http.StatusOK, body: "restart"},
+		{defaultAction: "create", url: "/tests?action=foo", code: http.StatusBadRequest},
+		{defaultAction: "", url: "/tests", code: http.StatusBadRequest},
+	}
+	for _, test := range tests {
+		w := httptest.NewRecorder()
+		newTestActionRouter(test.defaultAction).ServeHTTP(w, httptest.NewRequest(http.MethodPost, test.url, nil))
+		if w.Code != test.code {
+			t.Errorf("%s: status = %d, want %d", test.url, w.Code, test.code)
+			continue
+		}
+		if test.code == http.StatusOK {
+			if w.Body.String() != test.body {
+				t.Errorf("%s: body = %s, want %s", test.url, w.Body.String(), test.body)
+			}
+			continue
+		}
+		var res struct {
+			common.Response
+			Data []ActionHandler `json:"data"`
+		}
+		if err := json.Unmarshal(w.Body.Bytes(), &res); err != nil {
+			t.Fatal(err)
+		}
+		if res.Code != consts.ErrorUnsupportedAction || len(res.Data) != 2 {
+			t.Errorf("%s: code = %d actions = %d, want %d and 2", test.url, res.Code, len(res.Data), consts.ErrorUnsupportedAction)
+		}
+	}
+}
+
+func TestDeploymentActionAttributes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.POST("/namespaces/:namespace/deployments", func(c *gin.Context) {
+		verb, resource, namespace, err := DeploymentActionAttributes(c)
+		if err != nil {
+			c.String(http.StatusBadRequest, err.Error())
+			return
+		}
+		c.String(http.StatusOK, verb+" "+resource+" "+namespace)
+	})
+	tests := []struct {
+		url  string
+		want string
+	}{
+		{"/namespaces/default/deployments", "create deployments default"},
+		{"/namespaces/default/deployments?action=dry-run", "create deployments default"},
+		{"/namespaces/default/deployments?action=scale-replicas", "update deployments default"},
+	}
+	for _, test := range tests {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, test.url, nil))
+		if w.Code != http.StatusOK || w.Body.String() != test.want {
+			t.Errorf("%s: got %d %q, want %q", test.url, w.Code, w.Body.String(), test.want)
+		}
+	}
+}
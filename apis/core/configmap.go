@@ -0,0 +1,134 @@
+package core
+
+import (
+	"fmt"
+	"muti-kube/apis"
+	"muti-kube/models/core"
+	"muti-kube/pkg/consts"
+	"muti-kube/pkg/service"
+	coreService "muti-kube/pkg/service/core"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ConfigMap struct {
+	apis.Base
+	cs coreService.ConfigMapInterface
+	// actions on the configmaps of a namespace
+	actions *ActionRegistry
+}
+
+func NewConfigMap() (*ConfigMap, error) {
+	tmp, err := coreService.NewConfigMap()
+	if err != nil {
+		return nil, err
+	}
+	cc := &ConfigMap{
+		cs: tmp,
+	}
+	cc.actions = NewActionRegistry("configmaps", apis.CreateAction,
+		&ActionHandler{
+			Action:      apis.CreateAction,
+			Description: "create the configmap",
+			Body:        core.ConfigMapPost{},
+			Handle:      cc.createConfigMap,
+		},
+		&ActionHandler{
+			Action:      apis.DryRunAction,
+			Description: "validate the configmap with a server side dry-run without creating it",
+			Body:        core.ConfigMapPost{},
+			Handle:      cc.dryRunConfigMap,
+		},
+	)
+	return cc, nil
+}
+
+func (cc *ConfigMap) createConfigMap(c *gin.Context) {
+	clusterID := c.Param("clusterID")
+	namespace := c.Param("namespace")
+	configMapPost := core.ConfigMapPost{}
+	if err := c.ShouldBindJSON(&configMapPost); err != nil {
+		cc.Error(c, consts.ErrorCreateConfigMap, err, "")
+		return
+	}
+	configMap, err := cc.cs.CreateConfigMap(clusterID, namespace, configMapPost, cc.UserOption(c))
+	if err != nil {
+		cc.Error(c, consts.ErrorCreateConfigMap, err, "")
+		return
+	}
+	cc.OK(c, configMap, "create configmap success")
+}
+
+func (cc *ConfigMap) dryRunConfigMap(c *gin.Context) {
+	clusterID := c.Param("clusterID")
+	namespace := c.Param("namespace")
+	configMapPost := core.ConfigMapPost{}
+	if err := c.ShouldBindJSON(&configMapPost); err != nil {
+		cc.Error(c, consts.ErrorCreateConfigMap, err, "")
+		return
+	}
+	configMap, err := cc.cs.DryRunConfigMap(clusterID, namespace, configMapPost, cc.UserOption(c))
+	if err != nil {
+		cc.Error(c, consts.ErrorCreateConfigMap, err, "")
+		return
+	}
+	cc.OK(c, configMap, "dry-run configmap success")
+}
+
+func (cc *ConfigMap) GetConfigMaps(c *gin.Context) {
+	pagination := cc.GetPagination(c)
+	clusterID := c.Param("clusterID")
+	namespace := c.Param("namespace")
+	configMaps, count, err := cc.cs.GetConfigMaps(clusterID, namespace, service.WithPagination(pagination), cc.UserOption(c))
+	if err != nil {
+		cc.Error(c, consts.ErrorGetConfigMaps, err, "")
+		return
+	}
+	cc.PageOK(c, configMaps, count, pagination, "")
+}
+
+// ConfigMapAction Run the create or dry-run action on the configmaps of the namespace
+func (cc *ConfigMap) ConfigMapAction(c *gin.Context) {
+	cc.actions.Dispatch(c)
+}
+
+func (cc *ConfigMap) GetConfigMap(c *gin.Context) {
+	clusterID := c.Param("clusterID")
+	namespace := c.Param("namespace")
+	configMapID := c.Param("configMapID")
+	configMap, err := cc.cs.GetConfigMap(clusterID, namespace, configMapID, cc.UserOption(c))
+	if err != nil {
+		cc.Error(c, consts.ErrorGetConfigMap, err, "")
+		return
+	}
+	cc.OK(c, configMap, fmt.Sprintf("get configmap %s success", configMapID))
+}
+
+// UpdateConfigMap Replace the data of the configmap, the changed keys are returned with their values
+func (cc *ConfigMap) UpdateConfigMap(c *gin.Context) {
+	clusterID := c.Param("clusterID")
+	namespace := c.Param("namespace")
+	configMapID := c.Param("configMapID")
+	configMapPost := core.ConfigMapPost{}
+	if err := c.ShouldBindJSON(&configMapPost); err != nil {
+		cc.Error(c, consts.ErrorUpdateConfigMap, err, "")
+		return
+	}
+	update, err := cc.cs.UpdateConfigMap(clusterID, namespace, configMapID, configMapPost, cc.UserOption(c))
+	if err != nil {
+		cc.Error(c, consts.ErrorUpdateConfigMap, err, "")
+		return
+	}
+	cc.OK(c, update, fmt.Sprintf("update configmap %s success", configMapID))
+}
+
+func (cc *ConfigMap) DeleteConfigMap(c *gin.Context) {
+	clusterID := c.Param("clusterID")
+	namespace := c.Param("namespace")
+	configMapID := c.Param("configMapID")
+	if err := cc.cs.DeleteConfigMap(clusterID, namespace, configMapID, cc.UserOption(c)); err != nil {
+		cc.Error(c, consts.ErrorDeleteConfigMap, err, "")
+		return
+	}
+	cc.OK(c, nil, fmt.Sprintf("delete configmap %s success", configMapID))
+}
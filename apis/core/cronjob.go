@@ -0,0 +1,190 @@
+package core
+
+import (
+	"fmt"
+	"muti-kube/apis"
+	"muti-kube/models/core"
+	"muti-kube/pkg/consts"
+	"muti-kube/pkg/service"
+	coreService "muti-kube/pkg/service/core"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CronJob struct {
+	apis.Base
+	cs coreService.CronJobInterface
+	// actions on the cronjobs of a namespace
+	actions *ActionRegistry
+	// actions on one cronjob
+	itemActions *ActionRegistry
+}
+
+func NewCronJob() (*CronJob, error) {
+	tmp, err := coreService.NewCronJob()
+	if err != nil {
+		return nil, err
+	}
+	cc := &CronJob{
+		cs: tmp,
+	}
+	cc.actions = NewActionRegistry("cronjobs", apis.CreateAction,
+		&ActionHandler{
+			Action:      apis.CreateAction,
+			Description: "create the cronjob",
+			Body:        core.CronJobPost{},
+			Handle:      cc.createCronJob,
+		},
+		&ActionHandler{
+			Action:      apis.DryRunAction,
+			Description: "validate the cronjob with a server side dry-run without creating it",
+			Body:        core.CronJobPost{},
+			Handle:      cc.dryRunCronJob,
+		},
+	)
+	cc.itemActions = NewActionRegistry("cronjob", "",
+		&ActionHandler{
+			Action:      apis.SuspendAction,
+			Description: "stop scheduling new jobs of the cronjob, running jobs are not affected",
+			Handle:      cc.suspendCronJob,
+		},
+		&ActionHandler{
+			Action:      apis.ResumeAction,
+			Description: "resume scheduling jobs of the cronjob",
+			Handle:      cc.resumeCronJob,
+		},
+		&ActionHandler{
+			Action:      apis.TriggerAction,
+			Description: "run the cronjob now by creating a job from its job template",
+			Handle:      cc.triggerCronJob,
+		},
+	)
+	return cc, nil
+}
+
+func (cc *CronJob) createCronJob(c *gin.Context) {
+	clusterID := c.Param("clusterID")
+	namespace := c.Param("namespace")
+	cronJobPost := core.CronJobPost{}
+	if err := c.ShouldBindJSON(&cronJobPost); err != nil {
+		cc.Error(c, consts.ErrorCreateCronJob, err, "")
+		return
+	}
+	cronJob, err := cc.cs.CreateCronJob(clusterID, namespace, cronJobPost, cc.UserOption(c))
+	if err != nil {
+		cc.Error(c, consts.ErrorCreateCronJob, err, "")
+		return
+	}
+	cc.OK(c, cronJob, "create cronjob success")
+}
+
+func (cc *CronJob) dryRunCronJob(c *gin.Context) {
+	clusterID := c.Param("clusterID")
+	namespace := c.Param("namespace")
+	cronJobPost := core.CronJobPost{}
+	if err := c.ShouldBindJSON(&cronJobPost); err != nil {
+		cc.Error(c, consts.ErrorCreateCronJob, err, "")
+		return
+	}
+	cronJob, err := cc.cs.DryRunCronJob(clusterID, namespace, cronJobPost, cc.UserOption(c))
+	if err != nil {
+		cc.Error(c, consts.ErrorCreateCronJob, err, "")
+		return
+	}
+	cc.OK(c, cronJob, "dry-run cronjob success")
+}
+
+func (cc *CronJob) suspendCronJob(c *gin.Context) {
+	cc.setCronJobSuspend(c, true)
+}
+
+func (cc *CronJob) resumeCronJob(c *gin.Context) {
+	cc.setCronJobSuspend(c, false)
+}
+
+func (cc *CronJob) setCronJobSuspend(c *gin.Context, suspend bool) {
+	clusterID := c.Param("clusterID")
+	namespace := c.Param("namespace")
+	cronJobID := c.Param("cronJobID")
+	cronJob, err := cc.cs.SuspendCronJob(clusterID, namespace, cronJobID, suspend, cc.UserOption(c))
+	if err != nil {
+		cc.Error(c, consts.ErrorSuspendCronJob, err, "")
+		return
+	}
+	cc.OK(c, cronJob, fmt.Sprintf("set cronjob %s suspend %t success", cronJobID, suspend))
+}
+
+func (cc *CronJob) triggerCronJob(c *gin.Context) {
+	clusterID := c.Param("clusterID")
+	namespace := c.Param("namespace")
+	cronJobID := c.Param("cronJobID")
+	job, err := cc.cs.TriggerCronJob(clusterID, namespace, cronJobID, cc.UserOption(c))
+	if err != nil {
+		cc.Error(c, consts.ErrorTriggerCronJob, err, "")
+		return
+	}
+	cc.OK(c, job, fmt.Sprintf("trigger cronjob %s success", cronJobID))
+}
+
+func (cc *CronJob) GetCronJobs(c *gin.Context) {
+	pagination := cc.GetPagination(c)
+	clusterID := c.Param("clusterID")
+	namespace := c.Param("namespace")
+	cronJobs, count, err := cc.cs.GetCronJobs(clusterID, namespace, service.WithPagination(pagination), cc.UserOption(c))
+	if err != nil {
+		cc.Error(c, consts.ErrorGetCronJobs, err, "")
+		return
+	}
+	cc.PageOK(c, cronJobs, count, pagination, "")
+}
+
+// CronJobAction Run the create or dry-run action on the cronjobs of the namespace
+func (cc *CronJob) CronJobAction(c *gin.Context) {
+	cc.actions.Dispatch(c)
+}
+
+// CronJobItemAction Run the suspend, resume or trigger action on one cronjob
+func (cc *CronJob) CronJobItemAction(c *gin.Context) {
+	cc.itemActions.Dispatch(c)
+}
+
+func (cc *CronJob) GetCronJob(c *gin.Context) {
+	clusterID := c.Param("clusterID")
+	namespace := c.Param("namespace")
+	cronJobID := c.Param("cronJobID")
+	cronJob, err := cc.cs.GetCronJob(clusterID, namespace, cronJobID, cc.UserOption(c))
+	if err != nil {
+		cc.Error(c, consts.ErrorGetCronJob, err, "")
+		return
+	}
+	cc.OK(c, cronJob, fmt.Sprintf("get cronjob %s success", cronJobID))
+}
+
+// UpdateCronJob Replace the spec of the cronjob
+func (cc *CronJob) UpdateCronJob(c *gin.Context) {
+	clusterID := c.Param("clusterID")
+	namespace := c.Param("namespace")
+	cronJobID := c.Param("cronJobID")
+	cronJobPost := core.CronJobPost{}
+	if err := c.ShouldBindJSON(&cronJobPost); err != nil {
+		cc.Error(c, consts.ErrorUpdateCronJob, err, "")
+		return
+	}
+	cronJob, err := cc.cs.UpdateCronJob(clusterID, namespace, cronJobID, cronJobPost, cc.UserOption(c))
+	if err != nil {
+		cc.Error(c, consts.ErrorUpdateCronJob, err, "")
+		return
+	}
+	cc.OK(c, cronJob, fmt.Sprintf("update cronjob %s success", cronJobID))
+}
+
+func (cc *CronJob) DeleteCronJob(c *gin.Context) {
+	clusterID := c.Param("clusterID")
+	namespace := c.Param("namespace")
+	cronJobID := c.Param("cronJobID")
+	if err := cc.cs.DeleteCronJob(clusterID, namespace, cronJobID, cc.UserOption(c)); err != nil {
+		cc.Error(c, consts.ErrorDeleteCronJob, err, "")
+		return
+	}
+	cc.OK(c, nil, fmt.Sprintf("delete cronjob %s success", cronJobID))
+}
@@ -0,0 +1,134 @@
+package core
+
+import (
+	"fmt"
+	"muti-kube/apis"
+	"muti-kube/models/core"
+	"muti-kube/pkg/consts"
+	"muti-kube/pkg/service"
+	coreService "muti-kube/pkg/service/core"
+
+	"github.com/gin-gonic/gin"
+)
+
+type DaemonSet struct {
+	apis.Base
+	ds coreService.DaemonSetInterface
+	// actions on the daemonsets of a namespace
+	actions *ActionRegistry
+}
+
+func NewDaemonSet() (*DaemonSet, error) {
+	tmp, err := coreService.NewDaemonSet()
+	if err != nil {
+		return nil, err
+	}
+	dc := &DaemonSet{
+		ds: tmp,
+	}
+	dc.actions = NewActionRegistry("daemonsets", apis.CreateAction,
+		&ActionHandler{
+			Action:      apis.CreateAction,
+			Description: "create the daemonset",
+			Body:        core.DaemonSetPost{},
+			Handle:      dc.createDaemonSet,
+		},
+		&ActionHandler{
+			Action:      apis.DryRunAction,
+			Description: "validate the daemonset with a server side dry-run without creating it",
+			Body:        core.DaemonSetPost{},
+			Handle:      dc.dryRunDaemonSet,
+		},
+	)
+	return dc, nil
+}
+
+func (dc *DaemonSet) createDaemonSet(c *gin.Context) {
+	clusterID := c.Param("clusterID")
+	namespace := c.Param("namespace")
+	daemonSetPost := core.DaemonSetPost{}
+	if err := c.ShouldBindJSON(&daemonSetPost); err != nil {
+		dc.Error(c, consts.ErrorCreateDaemonSet, err, "")
+		return
+	}
+	daemonSet, err := dc.ds.CreateDaemonSet(clusterID, namespace, daemonSetPost, dc.UserOption(c))
+	if err != nil {
+		dc.Error(c, consts.ErrorCreateDaemonSet, err, "")
+		return
+	}
+	dc.OK(c, daemonSet, "create daemonset success")
+}
+
+func (dc *DaemonSet) dryRunDaemonSet(c *gin.Context) {
+	clusterID := c.Param("clusterID")
+	namespace := c.Param("namespace")
+	daemonSetPost := core.DaemonSetPost{}
+	if err := c.ShouldBindJSON(&daemonSetPost); err != nil {
+		dc.Error(c, consts.ErrorCreateDaemonSet, err, "")
+		return
+	}
+	daemonSet, err := dc.ds.DryRunDaemonSet(clusterID, namespace, daemonSetPost, dc.UserOption(c))
+	if err != nil {
+		dc.Error(c, consts.ErrorCreateDaemonSet, err, "")
+		return
+	}
+	dc.OK(c, daemonSet, "dry-run daemonset success")
+}
+
+func (dc *DaemonSet) GetDaemonSets(c *gin.Context) {
+	pagination := dc.GetPagination(c)
+	clusterID := c.Param("clusterID")
+	namespace := c.Param("namespace")
+	daemonSets, count, err := dc.ds.GetDaemonSets(clusterID, namespace, service.WithPagination(pagination), dc.UserOption(c))
+	if err != nil {
+		dc.Error(c, consts.ErrorGetDaemonSets, err, "")
+		return
+	}
+	dc.PageOK(c, daemonSets, count, pagination, "")
+}
+
+// DaemonSetAction Run the create or dry-run action on the daemonsets of the namespace
+func (dc *DaemonSet) DaemonSetAction(c *gin.Context) {
+	dc.actions.Dispatch(c)
+}
+
+func (dc *DaemonSet) GetDaemonSet(c *gin.Context) {
+	clusterID := c.Param("clusterID")
+	namespace := c.Param("namespace")
+	daemonSetID := c.Param("daemonSetID")
+	daemonSet, err := dc.ds.GetDaemonSet(clusterID, namespace, daemonSetID, dc.UserOption(c))
+	if err != nil {
+		dc.Error(c, consts.ErrorGetDaemonSet, err, "")
+		return
+	}
+	dc.OK(c, daemonSet, fmt.Sprintf("get daemonset %s success", daemonSetID))
+}
+
+// UpdateDaemonSet Replace the spec of the daemonset
+func (dc *DaemonSet) UpdateDaemonSet(c *gin.Context) {
+	clusterID := c.Param("clusterID")
+	namespace := c.Param("namespace")
+	daemonSetID := c.Param("daemonSetID")
+	daemonSetPost := core.DaemonSetPost{}
+	if err := c.ShouldBindJSON(&daemonSetPost); err != nil {
+		dc.Error(c, consts.ErrorUpdateDaemonSet, err, "")
+		return
+	}
+	daemonSet, err := dc.ds.UpdateDaemonSet(clusterID, namespace, daemonSetID, daemonSetPost, dc.UserOption(c))
+	if err != nil {
+		dc.Error(c, consts.ErrorUpdateDaemonSet, err, "")
+		return
+	}
+	dc.OK(c, daemonSet, fmt.Sprintf("update daemonset %s success", daemonSetID))
+}
+
+func (dc *DaemonSet) DeleteDaemonSet(c *gin.Context) {
+	clusterID := c.Param("clusterID")
+	namespace := c.Param("namespace")
+	daemonSetID := c.Param("daemonSetID")
+	if err := dc.ds.DeleteDaemonSet(clusterID, namespace, daemonSetID, dc.UserOption(c)); err != nil {
+		dc.Error(c, consts.ErrorDeleteDaemonSet, err, "")
+		return
+	}
+	dc.OK(c, nil, fmt.Sprintf("delete daemonset %s success", daemonSetID))
+}
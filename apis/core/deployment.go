@@ -2,54 +2,61 @@ package core
 
 import (
 	"fmt"
+	"io"
 	"muti-kube/apis"
 	"muti-kube/models/core"
+	"muti-kube/pkg/authorization"
 	"muti-kube/pkg/consts"
 	"muti-kube/pkg/service"
 	deploymentService "muti-kube/pkg/service/core"
 
 	"github.com/gin-gonic/gin"
+	"k8s.io/apimachinery/pkg/types"
 )
 
 type Deployment struct {
 	apis.Base
 	ds deploymentService.DeploymentInterface
-	deploymentActionFunc map[string]func(dc *Deployment,c *gin.Context)
+	// actions on the deployments of a namespace
+	actions *ActionRegistry
+	// actions on one deployment
+	itemActions *ActionRegistry
 }
 
-func dryRunDeployment(dc *Deployment,c *gin.Context)  {
+func (dc *Deployment) dryRunDeployment(c *gin.Context) {
 	clusterID := c.Param("clusterID")
 	namespace := c.Param("namespace")
 	deploymentPost := core.DeploymentPost{}
 	if err := c.ShouldBindJSON(&deploymentPost); err != nil {
-		dc.Error(c, consts.ERRCREATECLUSTER, err, "")
+		dc.Error(c, consts.ErrorCreateDeployment, err, "")
 		return
 	}
-	deployment, err := dc.ds.DryRunDeployment(clusterID, namespace, deploymentPost)
+	deployment, err := dc.ds.DryRunDeployment(clusterID, namespace, deploymentPost, dc.UserOption(c))
 	if err != nil {
-		dc.Error(c, consts.ErrorGetDeployments, err, "")
+		dc.Error(c, consts.ErrorCreateDeployment, err, "")
 		return
 	}
 	dc.OK(c, deployment, "dry-run deployment success")
 }
 
-func createDeployment(dc *Deployment,c *gin.Context)  {
+func (dc *Deployment) createDeployment(c *gin.Context) {
 	clusterID := c.Param("clusterID")
 	namespace := c.Param("namespace")
 	deploymentPost := core.DeploymentPost{}
 	if err := c.ShouldBindJSON(&deploymentPost); err != nil {
-		dc.Error(c, consts.ERRCREATECLUSTER, err, "")
+		dc.Error(c, consts.ErrorCreateDeployment, err, "")
 		return
 	}
-	deployment, err := dc.ds.CreateDeployment(clusterID,namespace,deploymentPost)
+	deployment, err := dc.ds.CreateDeployment(clusterID, namespace, deploymentPost, dc.UserOption(c))
 	if err != nil {
 		dc.Error(c, consts.ErrorCreateDeployment, err, "")
 		return
 	}
-	dc.OK(c, deployment, "dry-run deployment success")
+	dc.OK(c, deployment, "create deployment success")
 }
 
-func scaleReplicasDeployment(dc *Deployment,c *gin.Context)  {
+// scaleReplicasDeployment scale the deployment named in the body, kept for the collection action
+func (dc *Deployment) scaleReplicasDeployment(c *gin.Context) {
 	clusterID := c.Param("clusterID")
 	namespace := c.Param("namespace")
 	deploymentPost := core.DeploymentPost{}
@@ -57,20 +64,81 @@ func scaleReplicasDeployment(dc *Deployment,c *gin.Context)  {
 		dc.Error(c, consts.ErrorScaleReplicasDeployment, err, "")
 		return
 	}
-	deployment, err := dc.ds.CreateDeployment(clusterID,namespace,deploymentPost)
+	err := dc.ds.ScaleDeployment(clusterID, namespace, deploymentPost.Name, deploymentPost.Replicas, dc.UserOption(c))
 	if err != nil {
 		dc.Error(c, consts.ErrorScaleReplicasDeployment, err, "")
 		return
 	}
-	dc.OK(c, deployment, fmt.Sprintf("relicas %d deployment success",deploymentPost.Replicas))
+	dc.OK(c, nil, fmt.Sprintf("relicas %d deployment success", deploymentPost.Replicas))
+}
+
+func (dc *Deployment) scaleDeployment(c *gin.Context) {
+	clusterID := c.Param("clusterID")
+	namespace := c.Param("namespace")
+	deploymentID := c.Param("deploymentID")
+	deploymentScale := core.DeploymentScale{}
+	if err := c.ShouldBindJSON(&deploymentScale); err != nil {
+		dc.Error(c, consts.ErrorScaleReplicasDeployment, err, "")
+		return
+	}
+	err := dc.ds.ScaleDeployment(clusterID, namespace, deploymentID, *deploymentScale.Replicas, dc.UserOption(c))
+	if err != nil {
+		dc.Error(c, consts.ErrorScaleReplicasDeployment, err, "")
+		return
+	}
+	dc.OK(c, nil, fmt.Sprintf("relicas %d deployment success", *deploymentScale.Replicas))
+}
+
+func (dc *Deployment) restartDeployment(c *gin.Context) {
+	clusterID := c.Param("clusterID")
+	namespace := c.Param("namespace")
+	deploymentID := c.Param("deploymentID")
+	deployment, err := dc.ds.RestartDeployment(clusterID, namespace, deploymentID, dc.UserOption(c))
+	if err != nil {
+		dc.Error(c, consts.ErrorRestartDeployment, err, "")
+		return
+	}
+	dc.OK(c, deployment, fmt.Sprintf("restart deployment %s success", deploymentID))
+}
+
+func (dc *Deployment) pauseDeployment(c *gin.Context) {
+	dc.setDeploymentPaused(c, true)
+}
+
+func (dc *Deployment) resumeDeployment(c *gin.Context) {
+	dc.setDeploymentPaused(c, false)
 }
 
-func newDeploymentActionFunc()  map[string]func(dc *Deployment,c *gin.Context) {
-	return map[string]func(dc *Deployment,c *gin.Context){
-		apis.DryRunAction: dryRunDeployment,
-		apis.CreateAction: createDeployment,
-		apis.ScaleReplicasAction: scaleReplicasDeployment,
+func (dc *Deployment) setDeploymentPaused(c *gin.Context, paused bool) {
+	clusterID := c.Param("clusterID")
+	namespace := c.Param("namespace")
+	deploymentID := c.Param("deploymentID")
+	deployment, err := dc.ds.PauseDeployment(clusterID, namespace, deploymentID, paused, dc.UserOption(c))
+	if err != nil {
+		dc.Error(c, consts.ErrorPauseDeployment, err, "")
+		return
 	}
+	dc.OK(c, deployment, fmt.Sprintf("set deployment %s paused %t success", deploymentID, paused))
+}
+
+func (dc *Deployment) rollbackDeployment(c *gin.Context) {
+	clusterID := c.Param("clusterID")
+	namespace := c.Param("namespace")
+	deploymentID := c.Param("deploymentID")
+	deploymentRollback := core.DeploymentRollback{}
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&deploymentRollback); err != nil {
+			dc.Error(c, consts.ErrorRollbackDeployment, err, "")
+			return
+		}
+	}
+	deployment, err := dc.ds.RollbackDeployment(clusterID, namespace, deploymentID,
+		deploymentRollback.Revision, dc.UserOption(c))
+	if err != nil {
+		dc.Error(c, consts.ErrorRollbackDeployment, err, "")
+		return
+	}
+	dc.OK(c, deployment, fmt.Sprintf("rollback deployment %s success", deploymentID))
 }
 
 func NewDeployment() (*Deployment, error) {
@@ -78,17 +146,66 @@ func NewDeployment() (*Deployment, error) {
 	if err != nil {
 		return nil, err
 	}
-	return &Deployment{
+	dc := &Deployment{
 		ds: tmp,
-		deploymentActionFunc: newDeploymentActionFunc(),
-	}, nil
+	}
+	dc.actions = NewActionRegistry("deployments", apis.CreateAction,
+		&ActionHandler{
+			Action:      apis.CreateAction,
+			Description: "create the deployment",
+			Body:        core.DeploymentPost{},
+			Handle:      dc.createDeployment,
+		},
+		&ActionHandler{
+			Action:      apis.DryRunAction,
+			Description: "validate the deployment with a server side dry-run without creating it",
+			Body:        core.DeploymentPost{},
+			Handle:      dc.dryRunDeployment,
+		},
+		&ActionHandler{
+			Action:      apis.ScaleReplicasAction,
+			Description: "scale the deployment named in metadata.name to replicas",
+			Body:        core.DeploymentPost{},
+			Handle:      dc.scaleReplicasDeployment,
+		},
+	)
+	dc.itemActions = NewActionRegistry("deployment", "",
+		&ActionHandler{
+			Action:      apis.ScaleReplicasAction,
+			Description: "scale the deployment to replicas",
+			Body:        core.DeploymentScale{},
+			Handle:      dc.scaleDeployment,
+		},
+		&ActionHandler{
+			Action:      apis.RestartAction,
+			Description: "roll out new pods of the deployment, as kubectl rollout restart",
+			Handle:      dc.restartDeployment,
+		},
+		&ActionHandler{
+			Action:      apis.PauseAction,
+			Description: "pause the rollout of the deployment",
+			Handle:      dc.pauseDeployment,
+		},
+		&ActionHandler{
+			Action:      apis.ResumeAction,
+			Description: "resume the rollout of the deployment",
+			Handle:      dc.resumeDeployment,
+		},
+		&ActionHandler{
+			Action:      apis.RollbackAction,
+			Description: "roll the deployment back to the revision, 0 or no body means the previous revision",
+			Body:        core.DeploymentRollback{},
+			Handle:      dc.rollbackDeployment,
+		},
+	)
+	return dc, nil
 }
 
 func (dc *Deployment) GetDeployments(c *gin.Context) {
 	pagination := dc.GetPagination(c)
 	clusterID := c.Param("clusterID")
 	namespace := c.Param("namespace")
-	clusters, count, err := dc.ds.GetDeployments(clusterID, namespace, service.WithPagination(pagination))
+	clusters, count, err := dc.ds.GetDeployments(clusterID, namespace, service.WithPagination(pagination), dc.UserOption(c))
 	if err != nil {
 		dc.Error(c, consts.ErrorGetDeployments, err, "")
 		return
@@ -96,32 +213,122 @@ func (dc *Deployment) GetDeployments(c *gin.Context) {
 	dc.PageOK(c, clusters, count, pagination, "")
 }
 
+// DeploymentActionAttributes the verb authorizing the action on the deployments of the namespace,
+// scale-replicas updates the deployment named in the body and the other actions create one
+func DeploymentActionAttributes(c *gin.Context) (string, string, string, error) {
+	verb := authorization.VerbCreate
+	if c.Query(ActionQuery) == apis.ScaleReplicasAction {
+		verb = authorization.VerbUpdate
+	}
+	return verb, authorization.ResourceDeployments, c.Param("namespace"), nil
+}
+
+// DeploymentAction Run the create, dry-run or scale-replicas action on the deployments of the namespace
 func (dc *Deployment) DeploymentAction(c *gin.Context) {
-	action := c.DefaultQuery("action", "create")
-	actionFunc := dc.deploymentActionFunc[action]
-	actionFunc(dc,c)
+	dc.actions.Dispatch(c)
+}
+
+// DeploymentItemAction Run the scale, restart, pause, resume or rollback action on one deployment
+func (dc *Deployment) DeploymentItemAction(c *gin.Context) {
+	dc.itemActions.Dispatch(c)
 }
 
 func (dc *Deployment) DeleteDeployment(c *gin.Context) {
 	clusterID := c.Param("clusterID")
 	namespace := c.Param("namespace")
 	deploymentID := c.Param("deploymentID")
-	err := dc.ds.DeleteDeployment(clusterID, namespace, deploymentID)
+	err := dc.ds.DeleteDeployment(clusterID, namespace, deploymentID, dc.UserOption(c))
 	if err != nil {
 		dc.Error(c, consts.ErrorDeleteDeployment, err, "")
 		return
 	}
-	dc.OK(c,nil,fmt.Sprintf("delete deployment %s success",deploymentID))
+	dc.OK(c, nil, fmt.Sprintf("delete deployment %s success", deploymentID))
 }
 
-func (dc *Deployment) GetDeployment(c *gin.Context)  {
+func (dc *Deployment) GetDeployment(c *gin.Context) {
 	clusterID := c.Param("clusterID")
 	namespace := c.Param("namespace")
 	deploymentID := c.Param("deploymentID")
-	deployment, err := dc.ds.GetDeployment(clusterID, namespace, deploymentID)
+	deployment, err := dc.ds.GetDeployment(clusterID, namespace, deploymentID, dc.UserOption(c))
 	if err != nil {
 		dc.Error(c, consts.ErrorGetDeployment, err, "")
 		return
 	}
-	dc.OK(c,deployment,fmt.Sprintf("get deployment %s success",deploymentID))
+	dc.OK(c, deployment, fmt.Sprintf("get deployment %s success", deploymentID))
+}
+
+// UpdateDeployment Replace the spec of the deployment
+func (dc *Deployment) UpdateDeployment(c *gin.Context) {
+	clusterID := c.Param("clusterID")
+	namespace := c.Param("namespace")
+	deploymentID := c.Param("deploymentID")
+	deploymentPost := core.DeploymentPost{}
+	if err := c.ShouldBindJSON(&deploymentPost); err != nil {
+		dc.Error(c, consts.ErrorUpdateDeployment, err, "")
+		return
+	}
+	deployment, err := dc.ds.UpdateDeployment(clusterID, namespace, deploymentID, deploymentPost, dc.UserOption(c))
+	if err != nil {
+		dc.Error(c, consts.ErrorUpdateDeployment, err, "")
+		return
+	}
+	dc.OK(c, deployment, fmt.Sprintf("update deployment %s success", deploymentID))
+}
+
+// PatchDeployment Patch the deployment, the patch type follows the Content-Type of the request
+// and defaults to a strategic merge patch
+func (dc *Deployment) PatchDeployment(c *gin.Context) {
+	clusterID := c.Param("clusterID")
+	namespace := c.Param("namespace")
+	deploymentID := c.Param("deploymentID")
+	data, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		dc.Error(c, consts.ErrorPatchDeployment, err, "")
+		return
+	}
+	deployment, err := dc.ds.PatchDeployment(clusterID, namespace, deploymentID,
+		patchTypeOf(c), data, dc.UserOption(c))
+	if err != nil {
+		dc.Error(c, consts.ErrorPatchDeployment, err, "")
+		return
+	}
+	dc.OK(c, deployment, fmt.Sprintf("patch deployment %s success", deploymentID))
+}
+
+// GetDeploymentRolloutStatus Obtain the progress of the latest rollout of the deployment
+func (dc *Deployment) GetDeploymentRolloutStatus(c *gin.Context) {
+	clusterID := c.Param("clusterID")
+	namespace := c.Param("namespace")
+	deploymentID := c.Param("deploymentID")
+	status, err := dc.ds.GetDeploymentRolloutStatus(clusterID, namespace, deploymentID, dc.UserOption(c))
+	if err != nil {
+		dc.Error(c, consts.ErrorGetDeploymentStatus, err, "")
+		return
+	}
+	dc.OK(c, status, "")
+}
+
+// GetDeploymentRevisions Obtain the revision history of the deployment
+func (dc *Deployment) GetDeploymentRevisions(c *gin.Context) {
+	clusterID := c.Param("clusterID")
+	namespace := c.Param("namespace")
+	deploymentID := c.Param("deploymentID")
+	revisions, err := dc.ds.GetDeploymentRevisions(clusterID, namespace, deploymentID, dc.UserOption(c))
+	if err != nil {
+		dc.Error(c, consts.ErrorGetDeploymentRevisions, err, "")
+		return
+	}
+	dc.OK(c, revisions, "")
+}
+
+// patchTypeOf the patch type of the Content-Type of the request
+func patchTypeOf(c *gin.Context) types.PatchType {
+	switch c.ContentType() {
+	case string(types.JSONPatchType):
+		return types.JSONPatchType
+	case string(types.MergePatchType):
+		return types.MergePatchType
+	default:
+		return types.StrategicMergePatchType
+	}
 }
@@ -0,0 +1,58 @@
+package core
+
+import (
+	"fmt"
+	"muti-kube/apis"
+	"muti-kube/models/core"
+	"muti-kube/pkg/consts"
+	"muti-kube/pkg/service"
+	coreService "muti-kube/pkg/service/core"
+
+	"github.com/gin-gonic/gin"
+)
+
+type EndpointSlice struct {
+	apis.Base
+	es coreService.EndpointSliceInterface
+}
+
+func NewEndpointSlice() (*EndpointSlice, error) {
+	tmp, err := coreService.NewEndpointSlice()
+	if err != nil {
+		return nil, err
+	}
+	return &EndpointSlice{
+		es: tmp,
+	}, nil
+}
+
+// GetEndpointSlices List the endpointslices of the namespace, only those of the service when given
+func (ec *EndpointSlice) GetEndpointSlices(c *gin.Context) {
+	pagination := ec.GetPagination(c)
+	clusterID := c.Param("clusterID")
+	namespace := c.Param("namespace")
+	endpointSliceQuery := core.EndpointSliceQuery{}
+	if err := c.ShouldBindQuery(&endpointSliceQuery); err != nil {
+		ec.BadRequest(c, consts.ErrorGetEndpointSlices, err, "")
+		return
+	}
+	endpointSlices, count, err := ec.es.GetEndpointSlices(clusterID, namespace, endpointSliceQuery,
+		service.WithPagination(pagination), ec.UserOption(c))
+	if err != nil {
+		ec.Error(c, consts.ErrorGetEndpointSlices, err, "")
+		return
+	}
+	ec.PageOK(c, endpointSlices, count, pagination, "")
+}
+
+func (ec *EndpointSlice) GetEndpointSlice(c *gin.Context) {
+	clusterID := c.Param("clusterID")
+	namespace := c.Param("namespace")
+	endpointSliceID := c.Param("endpointSliceID")
+	endpointSlice, err := ec.es.GetEndpointSlice(clusterID, namespace, endpointSliceID, ec.UserOption(c))
+	if err != nil {
+		ec.Error(c, consts.ErrorGetEndpointSlice, err, "")
+		return
+	}
+	ec.OK(c, endpointSlice, fmt.Sprintf("get endpointslice %s success", endpointSliceID))
+}
@@ -0,0 +1,134 @@
+package core
+
+import (
+	"fmt"
+	"muti-kube/apis"
+	"muti-kube/models/core"
+	"muti-kube/pkg/consts"
+	"muti-kube/pkg/service"
+	coreService "muti-kube/pkg/service/core"
+
+	"github.com/gin-gonic/gin"
+)
+
+type Ingress struct {
+	apis.Base
+	is coreService.IngressInterface
+	// actions on the ingresses of a namespace
+	actions *ActionRegistry
+}
+
+func NewIngress() (*Ingress, error) {
+	tmp, err := coreService.NewIngress()
+	if err != nil {
+		return nil, err
+	}
+	ic := &Ingress{
+		is: tmp,
+	}
+	ic.actions = NewActionRegistry("ingresses", apis.CreateAction,
+		&ActionHandler{
+			Action:      apis.CreateAction,
+			Description: "create the ingress",
+			Body:        core.IngressPost{},
+			Handle:      ic.createIngress,
+		},
+		&ActionHandler{
+			Action:      apis.DryRunAction,
+			Description: "validate the ingress with a server side dry-run without creating it",
+			Body:        core.IngressPost{},
+			Handle:      ic.dryRunIngress,
+		},
+	)
+	return ic, nil
+}
+
+func (ic *Ingress) createIngress(c *gin.Context) {
+	clusterID := c.Param("clusterID")
+	namespace := c.Param("namespace")
+	ingressPost := core.IngressPost{}
+	if err := c.ShouldBindJSON(&ingressPost); err != nil {
+		ic.Error(c, consts.ErrorCreateIngress, err, "")
+		return
+	}
+	ingress, err := ic.is.CreateIngress(clusterID, namespace, ingressPost, ic.UserOption(c))
+	if err != nil {
+		ic.Error(c, consts.ErrorCreateIngress, err, "")
+		return
+	}
+	ic.OK(c, ingress, "create ingress success")
+}
+
+func (ic *Ingress) dryRunIngress(c *gin.Context) {
+	clusterID := c.Param("clusterID")
+	namespace := c.Param("namespace")
+	ingressPost := core.IngressPost{}
+	if err := c.ShouldBindJSON(&ingressPost); err != nil {
+		ic.Error(c, consts.ErrorCreateIngress, err, "")
+		return
+	}
+	ingress, err := ic.is.DryRunIngress(clusterID, namespace, ingressPost, ic.UserOption(c))
+	if err != nil {
+		ic.Error(c, consts.ErrorCreateIngress, err, "")
+		return
+	}
+	ic.OK(c, ingress, "dry-run ingress success")
+}
+
+func (ic *Ingress) GetIngresses(c *gin.Context) {
+	pagination := ic.GetPagination(c)
+	clusterID := c.Param("clusterID")
+	namespace := c.Param("namespace")
+	ingresses, count, err := ic.is.GetIngresses(clusterID, namespace, service.WithPagination(pagination), ic.UserOption(c))
+	if err != nil {
+		ic.Error(c, consts.ErrorGetIngresses, err, "")
+		return
+	}
+	ic.PageOK(c, ingresses, count, pagination, "")
+}
+
+// IngressAction Run the create or dry-run action on the ingresses of the namespace
+func (ic *Ingress) IngressAction(c *gin.Context) {
+	ic.actions.Dispatch(c)
+}
+
+func (ic *Ingress) GetIngress(c *gin.Context) {
+	clusterID := c.Param("clusterID")
+	namespace := c.Param("namespace")
+	ingressID := c.Param("ingressID")
+	ingress, err := ic.is.GetIngress(clusterID, namespace, ingressID, ic.UserOption(c))
+	if err != nil {
+		ic.Error(c, consts.ErrorGetIngress, err, "")
+		return
+	}
+	ic.OK(c, ingress, fmt.Sprintf("get ingress %s success", ingressID))
+}
+
+// UpdateIngress Replace the spec of the ingress
+func (ic *Ingress) UpdateIngress(c *gin.Context) {
+	clusterID := c.Param("clusterID")
+	namespace := c.Param("namespace")
+	ingressID := c.Param("ingressID")
+	ingressPost := core.IngressPost{}
+	if err := c.ShouldBindJSON(&ingressPost); err != nil {
+		ic.Error(c, consts.ErrorUpdateIngress, err, "")
+		return
+	}
+	ingress, err := ic.is.UpdateIngress(clusterID, namespace, ingressID, ingressPost, ic.UserOption(c))
+	if err != nil {
+		ic.Error(c, consts.ErrorUpdateIngress, err, "")
+		return
+	}
+	ic.OK(c, ingress, fmt.Sprintf("update ingress %s success", ingressID))
+}
+
+func (ic *Ingress) DeleteIngress(c *gin.Context) {
+	clusterID := c.Param("clusterID")
+	namespace := c.Param("namespace")
+	ingressID := c.Param("ingressID")
+	if err := ic.is.DeleteIngress(clusterID, namespace, ingressID, ic.UserOption(c)); err != nil {
+		ic.Error(c, consts.ErrorDeleteIngress, err, "")
+		return
+	}
+	ic.OK(c, nil, fmt.Sprintf("delete ingress %s success", ingressID))
+}
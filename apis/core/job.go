@@ -0,0 +1,116 @@
+package core
+
+import (
+	"fmt"
+	"muti-kube/apis"
+	"muti-kube/models/core"
+	"muti-kube/pkg/consts"
+	"muti-kube/pkg/service"
+	coreService "muti-kube/pkg/service/core"
+
+	"github.com/gin-gonic/gin"
+)
+
+type Job struct {
+	apis.Base
+	js coreService.JobInterface
+	// actions on the jobs of a namespace
+	actions *ActionRegistry
+}
+
+func NewJob() (*Job, error) {
+	tmp, err := coreService.NewJob()
+	if err != nil {
+		return nil, err
+	}
+	jc := &Job{
+		js: tmp,
+	}
+	jc.actions = NewActionRegistry("jobs", apis.CreateAction,
+		&ActionHandler{
+			Action:      apis.CreateAction,
+			Description: "create the job",
+			Body:        core.JobPost{},
+			Handle:      jc.createJob,
+		},
+		&ActionHandler{
+			Action:      apis.DryRunAction,
+			Description: "validate the job with a server side dry-run without creating it",
+			Body:        core.JobPost{},
+			Handle:      jc.dryRunJob,
+		},
+	)
+	return jc, nil
+}
+
+func (jc *Job) createJob(c *gin.Context) {
+	clusterID := c.Param("clusterID")
+	namespace := c.Param("namespace")
+	jobPost := core.JobPost{}
+	if err := c.ShouldBindJSON(&jobPost); err != nil {
+		jc.Error(c, consts.ErrorCreateJob, err, "")
+		return
+	}
+	job, err := jc.js.CreateJob(clusterID, namespace, jobPost, jc.UserOption(c))
+	if err != nil {
+		jc.Error(c, consts.ErrorCreateJob, err, "")
+		return
+	}
+	jc.OK(c, job, "create job success")
+}
+
+func (jc *Job) dryRunJob(c *gin.Context) {
+	clusterID := c.Param("clusterID")
+	namespace := c.Param("namespace")
+	jobPost := core.JobPost{}
+	if err := c.ShouldBindJSON(&jobPost); err != nil {
+		jc.Error(c, consts.ErrorCreateJob, err, "")
+		return
+	}
+	job, err := jc.js.DryRunJob(clusterID, namespace, jobPost, jc.UserOption(c))
+	if err != nil {
+		jc.Error(c, consts.ErrorCreateJob, err, "")
+		return
+	}
+	jc.OK(c, job, "dry-run job success")
+}
+
+func (jc *Job) GetJobs(c *gin.Context) {
+	pagination := jc.GetPagination(c)
+	clusterID := c.Param("clusterID")
+	namespace := c.Param("namespace")
+	jobs, count, err := jc.js.GetJobs(clusterID, namespace, service.WithPagination(pagination), jc.UserOption(c))
+	if err != nil {
+		jc.Error(c, consts.ErrorGetJobs, err, "")
+		return
+	}
+	jc.PageOK(c, jobs, count, pagination, "")
+}
+
+// JobAction Run the create or dry-run action on the jobs of the namespace
+func (jc *Job) JobAction(c *gin.Context) {
+	jc.actions.Dispatch(c)
+}
+
+func (jc *Job) GetJob(c *gin.Context) {
+	clusterID := c.Param("clusterID")
+	namespace := c.Param("namespace")
+	jobID := c.Param("jobID")
+	job, err := jc.js.GetJob(clusterID, namespace, jobID, jc.UserOption(c))
+	if err != nil {
+		jc.Error(c, consts.ErrorGetJob, err, "")
+		return
+	}
+	jc.OK(c, job, fmt.Sprintf("get job %s success", jobID))
+}
+
+func (jc *Job) DeleteJob(c *gin.Context) {
+	clusterID := c.Param("clusterID")
+	namespace := c.Param("namespace")
+	jobID := c.Param("jobID")
+	if err := jc.js.DeleteJob(clusterID, namespace, jobID, jc.UserOption(c)); err != nil {
+		jc.Error(c, consts.ErrorDeleteJob, err, "")
+		return
+	}
+	jc.OK(c, nil, fmt.Sprintf("delete job %s success", jobID))
+}
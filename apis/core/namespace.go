@@ -0,0 +1,145 @@
+package core
+
+import (
+	"fmt"
+	"muti-kube/apis"
+	"muti-kube/models/core"
+	"muti-kube/pkg/consts"
+	"muti-kube/pkg/service"
+	coreService "muti-kube/pkg/service/core"
+
+	"github.com/gin-gonic/gin"
+)
+
+type Namespace struct {
+	apis.Base
+	ns coreService.NamespaceInterface
+	// actions on the namespaces of a cluster
+	actions *ActionRegistry
+}
+
+func NewNamespace() (*Namespace, error) {
+	tmp, err := coreService.NewNamespace()
+	if err != nil {
+		return nil, err
+	}
+	nc := &Namespace{
+		ns: tmp,
+	}
+	nc.actions = NewActionRegistry("namespaces", apis.CreateAction,
+		&ActionHandler{
+			Action:      apis.CreateAction,
+			Description: "create the namespace with the resource quota and limit range given",
+			Body:        core.NamespacePost{},
+			Handle:      nc.createNamespace,
+		},
+		&ActionHandler{
+			Action:      apis.DryRunAction,
+			Description: "validate the namespace with a server side dry-run without creating it",
+			Body:        core.NamespacePost{},
+			Handle:      nc.dryRunNamespace,
+		},
+	)
+	return nc, nil
+}
+
+func (nc *Namespace) createNamespace(c *gin.Context) {
+	clusterID := c.Param("clusterID")
+	namespacePost := core.NamespacePost{}
+	if err := c.ShouldBindJSON(&namespacePost); err != nil {
+		nc.Error(c, consts.ErrorCreateNamespace, err, "")
+		return
+	}
+	namespace, err := nc.ns.CreateNamespace(clusterID, namespacePost, nc.UserOption(c))
+	if err != nil {
+		nc.Error(c, consts.ErrorCreateNamespace, err, "")
+		return
+	}
+	nc.OK(c, namespace, "create namespace success")
+}
+
+func (nc *Namespace) dryRunNamespace(c *gin.Context) {
+	clusterID := c.Param("clusterID")
+	namespacePost := core.NamespacePost{}
+	if err := c.ShouldBindJSON(&namespacePost); err != nil {
+		nc.Error(c, consts.ErrorCreateNamespace, err, "")
+		return
+	}
+	namespace, err := nc.ns.DryRunNamespace(clusterID, namespacePost, nc.UserOption(c))
+	if err != nil {
+		nc.Error(c, consts.ErrorCreateNamespace, err, "")
+		return
+	}
+	nc.OK(c, namespace, "dry-run namespace success")
+}
+
+func (nc *Namespace) GetNamespaces(c *gin.Context) {
+	pagination := nc.GetPagination(c)
+	clusterID := c.Param("clusterID")
+	namespaces, count, err := nc.ns.GetNamespaces(clusterID, service.WithPagination(pagination), nc.UserOption(c))
+	if err != nil {
+		nc.Error(c, consts.ErrorGetNamespaces, err, "")
+		return
+	}
+	nc.PageOK(c, namespaces, count, pagination, "")
+}
+
+// NamespaceAction Run the create or dry-run action on the namespaces of the cluster
+func (nc *Namespace) NamespaceAction(c *gin.Context) {
+	nc.actions.Dispatch(c)
+}
+
+func (nc *Namespace) GetNamespace(c *gin.Context) {
+	clusterID := c.Param("clusterID")
+	namespaceID := c.Param("namespace")
+	namespace, err := nc.ns.GetNamespace(clusterID, namespaceID, nc.UserOption(c))
+	if err != nil {
+		nc.Error(c, consts.ErrorGetNamespace, err, "")
+		return
+	}
+	nc.OK(c, namespace, fmt.Sprintf("get namespace %s success", namespaceID))
+}
+
+// UpdateNamespace Replace the labels and annotations of the namespace
+func (nc *Namespace) UpdateNamespace(c *gin.Context) {
+	clusterID := c.Param("clusterID")
+	namespaceID := c.Param("namespace")
+	namespaceUpdate := core.NamespaceUpdate{}
+	if err := c.ShouldBindJSON(&namespaceUpdate); err != nil {
+		nc.Error(c, consts.ErrorUpdateNamespace, err, "")
+		return
+	}
+	namespace, err := nc.ns.UpdateNamespace(clusterID, namespaceID, namespaceUpdate, nc.UserOption(c))
+	if err != nil {
+		nc.Error(c, consts.ErrorUpdateNamespace, err, "")
+		return
+	}
+	nc.OK(c, namespace, fmt.Sprintf("update namespace %s success", namespaceID))
+}
+
+func (nc *Namespace) DeleteNamespace(c *gin.Context) {
+	clusterID := c.Param("clusterID")
+	namespaceID := c.Param("namespace")
+	if err := nc.ns.DeleteNamespace(clusterID, namespaceID, nc.UserOption(c)); err != nil {
+		nc.Error(c, consts.ErrorDeleteNamespace, err, "")
+		return
+	}
+	nc.OK(c, nil, fmt.Sprintf("delete namespace %s success", namespaceID))
+}
+
+// GetNamespaceSummary Get the quota usage, limit ranges and metrics of the namespace
+func (nc *Namespace) GetNamespaceSummary(c *gin.Context) {
+	clusterID := c.Param("clusterID")
+	namespaceID := c.Param("namespace")
+	summaryQuery := core.NamespaceSummaryQuery{}
+	if err := c.ShouldBindQuery(&summaryQuery); err != nil {
+		nc.BadRequest(c, consts.ErrorGetNamespaceSummary, err, "")
+		return
+	}
+	summary, err := nc.ns.GetNamespaceSummary(clusterID, namespaceID, summaryQuery, nc.UserOption(c))
+	if err != nil {
+		nc.Error(c, consts.ErrorGetNamespaceSummary, err, "")
+		return
+	}
+	nc.OK(c, summary, fmt.Sprintf("get namespace %s summary success", namespaceID))
+}
@@ -1,7 +1,104 @@
 package core
 
-import "github.com/gin-gonic/gin"
+import (
+	"fmt"
+	"muti-kube/apis"
+	"muti-kube/models/core"
+	"muti-kube/pkg/consts"
+	"muti-kube/pkg/service"
+	coreService "muti-kube/pkg/service/core"
 
-func GetPodList(c *gin.Context) {
+	"github.com/gin-gonic/gin"
+)
 
+type Pod struct {
+	apis.Base
+	ps coreService.PodInterface
+}
+
+func NewPod() (*Pod, error) {
+	tmp, err := coreService.NewPod()
+	if err != nil {
+		return nil, err
+	}
+	return &Pod{
+		ps: tmp,
+	}, nil
+}
+
+// GetPods List the pods of the namespace filtered by selectors or the owning workload
+func (pc *Pod) GetPods(c *gin.Context) {
+	pagination := pc.GetPagination(c)
+	clusterID := c.Param("clusterID")
+	namespace := c.Param("namespace")
+	podQuery := core.PodQuery{}
+	if err := c.ShouldBindQuery(&podQuery); err != nil {
+		pc.BadRequest(c, consts.ErrorGetPods, err, "")
+		return
+	}
+	pods, count, err := pc.ps.GetPods(clusterID, namespace, podQuery, service.WithPagination(pagination), pc.UserOption(c))
+	if err != nil {
+		pc.Error(c, consts.ErrorGetPods, err, "")
+		return
+	}
+	pc.PageOK(c, pods, count, pagination, "")
+}
+
+func (pc *Pod) GetPod(c *gin.Context) {
+	clusterID := c.Param("clusterID")
+	namespace := c.Param("namespace")
+	podID := c.Param("podID")
+	pod, err := pc.ps.GetPod(clusterID, namespace, podID, pc.UserOption(c))
+	if err != nil {
+		pc.Error(c, consts.ErrorGetPod, err, "")
+		return
+	}
+	pc.OK(c, pod, fmt.Sprintf("get pod %s success", podID))
+}
+
+func (pc *Pod) DeletePod(c *gin.Context) {
+	clusterID := c.Param("clusterID")
+	namespace := c.Param("namespace")
+	podID := c.Param("podID")
+	podDelete := core.PodDelete{}
+	if err := c.ShouldBindQuery(&podDelete); err != nil {
+		pc.BadRequest(c, consts.ErrorDeletePod, err, "")
+		return
+	}
+	if err := pc.ps.DeletePod(clusterID, namespace, podID, podDelete, pc.UserOption(c)); err != nil {
+		pc.Error(c, consts.ErrorDeletePod, err, "")
+		return
+	}
+	pc.OK(c, nil, fmt.Sprintf("delete pod %s success", podID))
+}
+
+// GetPodLogs Read the logs of a container of the pod
+func (pc *Pod) GetPodLogs(c *gin.Context) {
+	clusterID := c.Param("clusterID")
+	namespace := c.Param("namespace")
+	podID := c.Param("podID")
+	logQuery := core.PodLogQuery{}
+	if err := c.ShouldBindQuery(&logQuery); err != nil {
+		pc.BadRequest(c, consts.ErrorGetPodLogs, err, "")
+		return
+	}
+	logs, err := pc.ps.GetPodLogs(clusterID, namespace, podID, logQuery, pc.UserOption(c))
+	if err != nil {
+		pc.Error(c, consts.ErrorGetPodLogs, err, "")
+		return
+	}
+	pc.OK(c, logs, fmt.Sprintf("get pod %s logs success", podID))
+}
+
+// GetPodEvents List the events of the pod, the latest first
+func (pc *Pod) GetPodEvents(c *gin.Context) {
+	clusterID := c.Param("clusterID")
+	namespace := c.Param("namespace")
+	podID := c.Param("podID")
+	events, err := pc.ps.GetPodEvents(clusterID, namespace, podID, pc.UserOption(c))
+	if err != nil {
+		pc.Error(c, consts.ErrorGetPodEvents, err, "")
+		return
+	}
+	pc.OK(c, events, fmt.Sprintf("get pod %s events success", podID))
 }
@@ -0,0 +1,116 @@
+package core
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"muti-kube/models/core"
+	"muti-kube/pkg/consts"
+	"muti-kube/pkg/service"
+	"muti-kube/pkg/util/logger"
+	"muti-kube/pkg/util/terminal"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	"k8s.io/client-go/tools/remotecommand"
+)
+
+var upgrader = websocket.Upgrader{
+	Subprotocols: []string{terminal.Protocol},
+	CheckOrigin:  terminal.CheckOrigin,
+}
+
+// StreamPodLogs Stream the logs of a container to the websocket, new logs are sent with follow
+func (pc *Pod) StreamPodLogs(c *gin.Context) {
+	clusterID := c.Param("clusterID")
+	namespace := c.Param("namespace")
+	podID := c.Param("podID")
+	logQuery := core.PodLogQuery{}
+	if err := c.ShouldBindQuery(&logQuery); err != nil {
+		pc.BadRequest(c, consts.ErrorStreamPodLogs, err, "")
+		return
+	}
+	ctx, cancel := context.WithCancel(c.Request.Context())
+	defer cancel()
+	stream, err := pc.ps.StreamPodLogs(clusterID, namespace, podID, logQuery, service.WithContext(ctx), pc.UserOption(c))
+	if err != nil {
+		pc.Error(c, consts.ErrorStreamPodLogs, err, "")
+		return
+	}
+	defer stream.Close()
+	conn, err := upgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		logger.Error(err)
+		return
+	}
+	session := terminal.NewSession(conn)
+	// the client sends nothing, reading only notices it going away
+	go func() {
+		defer cancel()
+		_, _ = io.Copy(io.Discard, session)
+	}()
+	reader := bufio.NewReader(stream)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if len(line) > 0 {
+			if _, werr := session.Write(line); werr != nil {
+				break
+			}
+		}
+		if err != nil {
+			if err != io.EOF && ctx.Err() == nil {
+				session.Fail(err)
+			}
+			break
+		}
+	}
+	session.Close()
+}
+
+// ExecPod Run a command in a container with the stdin, stdout and stderr attached to the websocket
+func (pc *Pod) ExecPod(c *gin.Context) {
+	clusterID := c.Param("clusterID")
+	namespace := c.Param("namespace")
+	podID := c.Param("podID")
+	execQuery := core.PodExecQuery{}
+	if err := c.ShouldBindQuery(&execQuery); err != nil {
+		pc.BadRequest(c, consts.ErrorExecPod, err, "")
+		return
+	}
+	userOption := pc.UserOption(c)
+	conn, err := upgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		logger.Error(err)
+		return
+	}
+	session := terminal.NewSession(conn)
+	streams := remotecommand.StreamOptions{
+		Stdin:  session,
+		Stdout: session,
+		Tty:    execQuery.TTY,
+	}
+	if execQuery.TTY {
+		streams.TerminalSizeQueue = session
+	} else {
+		streams.Stderr = session.Stderr()
+	}
+	// the session is closed once the client goes away, which ends the stream to the member cluster
+	ctx, cancel := context.WithCancel(c.Request.Context())
+	defer cancel()
+	go func() {
+		select {
+		case <-session.Done():
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	if err := pc.ps.ExecPod(clusterID, namespace, podID, execQuery, streams,
+		service.WithContext(ctx), userOption); err != nil {
+		if ctx.Err() == nil {
+			logger.Warn("exec pod ", podID, " failed: ", err)
+			session.Fail(err)
+		}
+		return
+	}
+	session.Close()
+}
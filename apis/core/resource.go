@@ -0,0 +1,333 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"muti-kube/apis"
+	"muti-kube/models/core"
+	"muti-kube/pkg/authorization"
+	"muti-kube/pkg/consts"
+	"muti-kube/pkg/service"
+	coreService "muti-kube/pkg/service/core"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// DynamicResource the generic api of any resource of the member clusters, the path of an object is
+// /resources/:group/:version/:resource[/namespaces/:namespace][/:name]
+type DynamicResource struct {
+	apis.Base
+	ds coreService.DynamicResourceInterface
+	// actions on the objects of a resource
+	actions *ActionRegistry
+}
+
+func NewDynamicResource() (*DynamicResource, error) {
+	tmp, err := coreService.NewDynamicResource()
+	if err != nil {
+		return nil, err
+	}
+	dc := &DynamicResource{
+		ds: tmp,
+	}
+	dc.actions = NewActionRegistry("resources", apis.CreateAction,
+		&ActionHandler{
+			Action:      apis.CreateAction,
+			Description: "create the object",
+			Body:        map[string]interface{}{"apiVersion": "", "kind": "", "metadata": map[string]interface{}{}},
+			Handle:      dc.createResource,
+		},
+		&ActionHandler{
+			Action:      apis.DryRunAction,
+			Description: "validate the object with a server side dry-run without creating it",
+			Body:        map[string]interface{}{"apiVersion": "", "kind": "", "metadata": map[string]interface{}{}},
+			Handle:      dc.dryRunResource,
+		},
+	)
+	return dc, nil
+}
+
+// secretsResource the secrets of the core group, their values are redacted unless read with secrets/reveal
+var secretsResource = schema.GroupResource{Resource: "secrets"}
+
+// resourceRequest the object addressed by the request, the namespace and name are empty
+// when the request addresses all the objects of the resource
+type resourceRequest struct {
+	gvr       schema.GroupVersionResource
+	namespace string
+	name      string
+}
+
+// ParseResourcePath the namespace and name of the path following the resource,
+// one of "", /:name, /namespaces/:namespace or /namespaces/:namespace/:name
+func ParseResourcePath(path string) (string, string, error) {
+	path = strings.Trim(path, "/")
+	if path == "" {
+		return "", "", nil
+	}
+	segments := strings.Split(path, "/")
+	for _, segment := range segments {
+		if segment == "" {
+			return "", "", fmt.Errorf("invalid resource path %q", path)
+		}
+	}
+	switch {
+	case len(segments) == 1:
+		return "", segments[0], nil
+	case len(segments) == 2 && segments[0] == "namespaces":
+		return segments[1], "", nil
+	case len(segments) == 3 && segments[0] == "namespaces":
+		return segments[1], segments[2], nil
+	}
+	return "", "", fmt.Errorf("invalid resource path %q", path)
+}
+
+func parseResourceRequest(c *gin.Context) (*resourceRequest, error) {
+	namespace, name, err := ParseResourcePath(c.Param("path"))
+	if err != nil {
+		return nil, err
+	}
+	return &resourceRequest{
+		gvr:       coreService.GroupVersionResourceOf(c.Param("group"), c.Param("version"), c.Param("resource")),
+		namespace: namespace,
+		name:      name,
+	}, nil
+}
+
+// ResourceAttributes the verb, resource and namespace authorizing the request, the resource is the resource
+// of the path for the core group and resource.group otherwise. Reading secrets requires secrets/reveal
+// as their values are not redacted, the secrets returned by the writes are redacted
+func ResourceAttributes(c *gin.Context) (string, string, string, error) {
+	request, err := parseResourceRequest(c)
+	if err != nil {
+		return "", "", "", err
+	}
+	verb := ""
+	switch c.Request.Method {
+	case http.MethodGet:
+		verb = authorization.VerbGet
+		if request.name == "" {
+			verb = authorization.VerbList
+		}
+	case http.MethodPost:
+		verb = authorization.VerbCreate
+	case http.MethodPut:
+		verb = authorization.VerbUpdate
+	case http.MethodPatch:
+		verb = authorization.VerbPatch
+	case http.MethodDelete:
+		verb = authorization.VerbDelete
+	default:
+		return "", "", "", fmt.Errorf("unsupported method %s", c.Request.Method)
+	}
+	resource := request.gvr.GroupResource().String()
+	if request.gvr.GroupResource() == secretsResource &&
+		(verb == authorization.VerbGet || verb == authorization.VerbList) {
+		resource = authorization.ResourceSecretReveal
+	}
+	return verb, resource, request.namespace, nil
+}
+
+// GetAPIResources Obtain the resources the cluster serves, including custom resources
+func (dc *DynamicResource) GetAPIResources(c *gin.Context) {
+	clusterID := c.Param("clusterID")
+	query := core.APIResourceQuery{}
+	if err := c.ShouldBindQuery(&query); err != nil {
+		dc.Error(c, consts.ErrorGetAPIResources, err, "")
+		return
+	}
+	resources, err := dc.ds.GetAPIResources(clusterID, query, dc.UserOption(c))
+	if err != nil {
+		dc.Error(c, consts.ErrorGetAPIResources, err, "")
+		return
+	}
+	dc.OK(c, resources, "")
+}
+
+// GetResource Obtain the object of the path, or the objects of the resource when the path has no name
+func (dc *DynamicResource) GetResource(c *gin.Context) {
+	clusterID := c.Param("clusterID")
+	request, err := parseResourceRequest(c)
+	if err != nil {
+		dc.BadRequest(c, consts.ErrorInvalidResourcePath, err, nil)
+		return
+	}
+	if request.name == "" {
+		dc.getResources(c, clusterID, request)
+		return
+	}
+	object, err := dc.ds.GetResource(clusterID, request.gvr, request.namespace, request.name, dc.UserOption(c))
+	if err != nil {
+		dc.Error(c, consts.ErrorGetResource, err, "")
+		return
+	}
+	dc.OK(c, object, "")
+}
+
+func (dc *DynamicResource) getResources(c *gin.Context, clusterID string, request *resourceRequest) {
+	pagination := dc.GetPagination(c)
+	query := core.ResourceQuery{}
+	if err := c.ShouldBindQuery(&query); err != nil {
+		dc.Error(c, consts.ErrorGetResources, err, "")
+		return
+	}
+	objects, count, err := dc.ds.GetResources(clusterID, request.gvr, request.namespace, query,
+		service.WithPagination(pagination), dc.UserOption(c))
+	if err != nil {
+		dc.Error(c, consts.ErrorGetResources, err, "")
+		return
+	}
+	dc.PageOK(c, objects, count, pagination, "")
+}
+
+// ResourceAction Run the create or dry-run action on the resource of the path
+func (dc *DynamicResource) ResourceAction(c *gin.Context) {
+	dc.actions.Dispatch(c)
+}
+
+func (dc *DynamicResource) createResource(c *gin.Context) {
+	dc.create(c, dc.ds.CreateResource, "create")
+}
+
+func (dc *DynamicResource) dryRunResource(c *gin.Context) {
+	dc.create(c, dc.ds.DryRunResource, "dry-run")
+}
+
+func (dc *DynamicResource) create(c *gin.Context,
+	create func(string, schema.GroupVersionResource, string, *unstructured.Unstructured,
+		...service.OpOption) (*unstructured.Unstructured, error), action string) {
+	clusterID := c.Param("clusterID")
+	request, err := parseResourceRequest(c)
+	if err != nil {
+		dc.BadRequest(c, consts.ErrorInvalidResourcePath, err, nil)
+		return
+	}
+	if request.name != "" {
+		dc.BadRequest(c, consts.ErrorInvalidResourcePath,
+			errors.New("objects are created on the path of the resource, without the name"), nil)
+		return
+	}
+	object, err := bindObject(c)
+	if err != nil {
+		dc.Error(c, consts.ErrorCreateResource, err, "")
+		return
+	}
+	object, err = create(clusterID, request.gvr, request.namespace, object, dc.UserOption(c))
+	if err != nil {
+		dc.Error(c, consts.ErrorCreateResource, err, "")
+		return
+	}
+	dc.OK(c, writtenObject(request.gvr, object),
+		fmt.Sprintf("%s %s %s success", action, request.gvr.Resource, object.GetName()))
+}
+
+// ApplyResource Create or update the object of the path with server side apply, force=true takes over
+// the fields managed by others
+func (dc *DynamicResource) ApplyResource(c *gin.Context) {
+	clusterID := c.Param("clusterID")
+	request, err := parseObjectRequest(c)
+	if err != nil {
+		dc.BadRequest(c, consts.ErrorInvalidResourcePath, err, nil)
+		return
+	}
+	apply := core.ResourceApply{}
+	if err := c.ShouldBindQuery(&apply); err != nil {
+		dc.Error(c, consts.ErrorApplyResource, err, "")
+		return
+	}
+	object, err := bindObject(c)
+	if err != nil {
+		dc.Error(c, consts.ErrorApplyResource, err, "")
+		return
+	}
+	object, err = dc.ds.ApplyResource(clusterID, request.gvr, request.namespace, request.name, object, apply, dc.UserOption(c))
+	if err != nil {
+		dc.Error(c, consts.ErrorApplyResource, err, "")
+		return
+	}
+	dc.OK(c, writtenObject(request.gvr, object), "")
+}
+
+// PatchResource Patch the object of the path, the patch type is the content type of the request,
+// a merge patch when not given
+func (dc *DynamicResource) PatchResource(c *gin.Context) {
+	clusterID := c.Param("clusterID")
+	request, err := parseObjectRequest(c)
+	if err != nil {
+		dc.BadRequest(c, consts.ErrorInvalidResourcePath, err, nil)
+		return
+	}
+	patchType := types.PatchType(c.ContentType())
+	if patchType == "" || patchType == "application/json" {
+		patchType = types.MergePatchType
+	}
+	data, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		dc.Error(c, consts.ErrorPatchResource, err, "")
+		return
+	}
+	object, err := dc.ds.PatchResource(clusterID, request.gvr, request.namespace, request.name, patchType, data, dc.UserOption(c))
+	if err != nil {
+		dc.Error(c, consts.ErrorPatchResource, err, "")
+		return
+	}
+	dc.OK(c, writtenObject(request.gvr, object), "")
+}
+
+func (dc *DynamicResource) DeleteResource(c *gin.Context) {
+	clusterID := c.Param("clusterID")
+	request, err := parseObjectRequest(c)
+	if err != nil {
+		dc.BadRequest(c, consts.ErrorInvalidResourcePath, err, nil)
+		return
+	}
+	resourceDelete := core.ResourceDelete{}
+	if err := c.ShouldBindQuery(&resourceDelete); err != nil {
+		dc.Error(c, consts.ErrorDeleteResource, err, "")
+		return
+	}
+	if err := dc.ds.DeleteResource(clusterID, request.gvr, request.namespace, request.name,
+		resourceDelete, dc.UserOption(c)); err != nil {
+		dc.Error(c, consts.ErrorDeleteResource, err, "")
+		return
+	}
+	dc.OK(c, nil, fmt.Sprintf("delete %s %s success", request.gvr.Resource, request.name))
+}
+
+// writtenObject the object returned by the writes, the values of secrets are redacted as the writes
+// do not require secrets/reveal
+func writtenObject(gvr schema.GroupVersionResource, object *unstructured.Unstructured) *unstructured.Unstructured {
+	if gvr.GroupResource() != secretsResource {
+		return object
+	}
+	return coreService.RedactSecretObject(object)
+}
+
+// parseObjectRequest the request must address a single object
+func parseObjectRequest(c *gin.Context) (*resourceRequest, error) {
+	request, err := parseResourceRequest(c)
+	if err != nil {
+		return nil, err
+	}
+	if request.name == "" {
+		return nil, errors.New("the name of the object is required")
+	}
+	return request, nil
+}
+
+func bindObject(c *gin.Context) (*unstructured.Unstructured, error) {
+	object := &unstructured.Unstructured{}
+	if err := c.ShouldBindJSON(&object.Object); err != nil {
+		return nil, err
+	}
+	if object.Object == nil {
+		return nil, errors.New("the object is required")
+	}
+	return object, nil
+}
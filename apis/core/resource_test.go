@@ -0,0 +1,127 @@
+package core
+
+import (
+	"muti-kube/models/core"
+	"muti-kube/pkg/authorization"
+	"muti-kube/pkg/service"
+	coreService "muti-kube/pkg/service/core"
+	"muti-kube/pkg/util/logger"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestParseResourcePath(t *testing.T) {
+	tests := []struct {
+		path      string
+		namespace string
+		name      string
+		invalid   bool
+	}{
+		{path: ""},
+		{path: "/"},
+		{path: "/node-1", name: "node-1"},
+		{path: "/namespaces/default", namespace: "default"},
+		{path: "/namespaces/default/web", namespace: "default", name: "web"},
+		{path: "/namespaces", name: "namespaces"},
+		{path: "/web/status", invalid: true},
+		{path: "/namespaces//web", invalid: true},
+		{path: "/namespaces/default/web/status", invalid: true},
+	}
+	for _, test := range tests {
+		namespace, name, err := ParseResourcePath(test.path)
+		if (err != nil) != test.invalid {
+			t.Errorf("path %q: unexpected error %v", test.path, err)
+			continue
+		}
+		if namespace != test.namespace || name != test.name {
+			t.Errorf("path %q: got namespace %q name %q", test.path, namespace, name)
+		}
+	}
+}
+
+func TestResourceAttributes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Any("/resources/:group/:version/:resource/*path", func(c *gin.Context) {
+		verb, resource, namespace, err := ResourceAttributes(c)
+		if err != nil {
+			c.String(http.StatusBadRequest, err.Error())
+			return
+		}
+		c.String(http.StatusOK, verb+" "+resource+" "+namespace)
+	})
+	tests := []struct {
+		method string
+		url    string
+		want   string
+	}{
+		{http.MethodGet, "/resources/apps/v1/deployments/namespaces/default", "list deployments.apps default"},
+		{http.MethodGet, "/resources/core/v1/nodes/node-1", "get nodes "},
+		{http.MethodPut, "/resources/example.com/v1/widgets/namespaces/default/w", "update widgets.example.com default"},
+		{http.MethodGet, "/resources/core/v1/secrets/namespaces/default/token", "get " + authorization.ResourceSecretReveal + " default"},
+		{http.MethodDelete, "/resources/core/v1/secrets/namespaces/default/token", "delete secrets default"},
+	}
+	for _, test := range tests {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(test.method, test.url, nil))
+		if w.Code != http.StatusOK || w.Body.String() != test.want {
+			t.Errorf("%s %s: got %d %q, want %q", test.method, test.url, w.Code, w.Body.String(), test.want)
+		}
+	}
+}
+
+// secretResources returns the stored secret from every write
+type secretResources struct {
+	coreService.DynamicResourceInterface
+	secret *unstructured.Unstructured
+}
+
+func (s *secretResources) ApplyResource(string, schema.GroupVersionResource, string, string,
+	*unstructured.Unstructured, core.ResourceApply, ...service.OpOption) (*unstructured.Unstructured, error) {
+	return s.secret, nil
+}
+
+func (s *secretResources) PatchResource(string, schema.GroupVersionResource, string, string,
+	types.PatchType, []byte, ...service.OpOption) (*unstructured.Unstructured, error) {
+	return s.secret, nil
+}
+
+func TestWriteSecretRedacted(t *testing.T) {
+	logger.Init()
+	gin.SetMode(gin.TestMode)
+	secret := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "Secret",
+		"metadata": map[string]interface{}{
+			"name":        "token",
+			"namespace":   "default",
+			"annotations": map[string]interface{}{"kubectl.kubernetes.io/last-applied-configuration": `{"data":{"token":"c2VjcmV0"}}`},
+		},
+		"data":       map[string]interface{}{"token": "c2VjcmV0"},
+		"stringData": map[string]interface{}{"password": "secret"},
+	}}
+	dc := &DynamicResource{ds: &secretResources{secret: secret}}
+	r := gin.New()
+	r.PUT("/clusters/:clusterID/resources/:group/:version/:resource/*path", dc.ApplyResource)
+	r.PATCH("/clusters/:clusterID/resources/:group/:version/:resource/*path", dc.PatchResource)
+	for _, method := range []string{http.MethodPut, http.MethodPatch} {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(method, "/clusters/c1/resources/core/v1/secrets/namespaces/default/token",
+			strings.NewReader(`{}`)))
+		body := w.Body.String()
+		if w.Code != http.StatusOK || strings.Contains(body, "c2VjcmV0") || strings.Contains(body, `"secret"`) ||
+			!strings.Contains(body, `"token":null`) {
+			t.Errorf("%s: the values of the secret are returned: %d %s", method, w.Code, body)
+		}
+	}
+	if secret.Object["data"].(map[string]interface{})["token"] != "c2VjcmV0" {
+		t.Error("the stored secret is modified")
+	}
+}
@@ -0,0 +1,193 @@
+package core
+
+import (
+	"fmt"
+	"muti-kube/apis"
+	"muti-kube/models/core"
+	"muti-kube/pkg/consts"
+	"muti-kube/pkg/service"
+	coreService "muti-kube/pkg/service/core"
+
+	"github.com/gin-gonic/gin"
+)
+
+type Secret struct {
+	apis.Base
+	ss coreService.SecretInterface
+	// actions on the secrets of a namespace
+	actions *ActionRegistry
+}
+
+func NewSecret() (*Secret, error) {
+	tmp, err := coreService.NewSecret()
+	if err != nil {
+		return nil, err
+	}
+	sc := &Secret{
+		ss: tmp,
+	}
+	sc.actions = NewActionRegistry("secrets", apis.CreateAction,
+		&ActionHandler{
+			Action:      apis.CreateAction,
+			Description: "create the secret",
+			Body:        core.SecretPost{},
+			Handle:      sc.createSecret,
+		},
+		&ActionHandler{
+			Action:      apis.DryRunAction,
+			Description: "validate the secret with a server side dry-run without creating it",
+			Body:        core.SecretPost{},
+			Handle:      sc.dryRunSecret,
+		},
+		&ActionHandler{
+			Action:      apis.CreateDockerRegistryAction,
+			Description: "create a kubernetes.io/dockerconfigjson secret of the registry credentials",
+			Body:        core.DockerRegistrySecretPost{},
+			Handle:      sc.createDockerRegistrySecret,
+		},
+		&ActionHandler{
+			Action:      apis.CreateTLSAction,
+			Description: "create a kubernetes.io/tls secret of the PEM encoded certificate and key",
+			Body:        core.TLSSecretPost{},
+			Handle:      sc.createTLSSecret,
+		},
+	)
+	return sc, nil
+}
+
+func (sc *Secret) createSecret(c *gin.Context) {
+	clusterID := c.Param("clusterID")
+	namespace := c.Param("namespace")
+	secretPost := core.SecretPost{}
+	if err := c.ShouldBindJSON(&secretPost); err != nil {
+		sc.Error(c, consts.ErrorCreateSecret, err, "")
+		return
+	}
+	secret, err := sc.ss.CreateSecret(clusterID, namespace, secretPost, sc.UserOption(c))
+	if err != nil {
+		sc.Error(c, consts.ErrorCreateSecret, err, "")
+		return
+	}
+	sc.OK(c, secret, "create secret success")
+}
+
+func (sc *Secret) dryRunSecret(c *gin.Context) {
+	clusterID := c.Param("clusterID")
+	namespace := c.Param("namespace")
+	secretPost := core.SecretPost{}
+	if err := c.ShouldBindJSON(&secretPost); err != nil {
+		sc.Error(c, consts.ErrorCreateSecret, err, "")
+		return
+	}
+	secret, err := sc.ss.DryRunSecret(clusterID, namespace, secretPost, sc.UserOption(c))
+	if err != nil {
+		sc.Error(c, consts.ErrorCreateSecret, err, "")
+		return
+	}
+	sc.OK(c, secret, "dry-run secret success")
+}
+
+func (sc *Secret) createDockerRegistrySecret(c *gin.Context) {
+	clusterID := c.Param("clusterID")
+	namespace := c.Param("namespace")
+	secretPost := core.DockerRegistrySecretPost{}
+	if err := c.ShouldBindJSON(&secretPost); err != nil {
+		sc.Error(c, consts.ErrorCreateSecret, err, "")
+		return
+	}
+	secret, err := sc.ss.CreateDockerRegistrySecret(clusterID, namespace, secretPost, sc.UserOption(c))
+	if err != nil {
+		sc.Error(c, consts.ErrorCreateSecret, err, "")
+		return
+	}
+	sc.OK(c, secret, "create docker-registry secret success")
+}
+
+func (sc *Secret) createTLSSecret(c *gin.Context) {
+	clusterID := c.Param("clusterID")
+	namespace := c.Param("namespace")
+	secretPost := core.TLSSecretPost{}
+	if err := c.ShouldBindJSON(&secretPost); err != nil {
+		sc.Error(c, consts.ErrorCreateSecret, err, "")
+		return
+	}
+	secret, err := sc.ss.CreateTLSSecret(clusterID, namespace, secretPost, sc.UserOption(c))
+	if err != nil {
+		sc.Error(c, consts.ErrorCreateSecret, err, "")
+		return
+	}
+	sc.OK(c, secret, "create tls secret success")
+}
+
+// GetSecrets List the secrets of the namespace with the values redacted
+func (sc *Secret) GetSecrets(c *gin.Context) {
+	pagination := sc.GetPagination(c)
+	clusterID := c.Param("clusterID")
+	namespace := c.Param("namespace")
+	secrets, count, err := sc.ss.GetSecrets(clusterID, namespace, service.WithPagination(pagination), sc.UserOption(c))
+	if err != nil {
+		sc.Error(c, consts.ErrorGetSecrets, err, "")
+		return
+	}
+	sc.PageOK(c, secrets, count, pagination, "")
+}
+
+// SecretAction Run the create, dry-run, create-docker-registry or create-tls action on the secrets of the namespace
+func (sc *Secret) SecretAction(c *gin.Context) {
+	sc.actions.Dispatch(c)
+}
+
+// GetSecret Get the secret with the values redacted
+func (sc *Secret) GetSecret(c *gin.Context) {
+	clusterID := c.Param("clusterID")
+	namespace := c.Param("namespace")
+	secretID := c.Param("secretID")
+	secret, err := sc.ss.GetSecret(clusterID, namespace, secretID, sc.UserOption(c))
+	if err != nil {
+		sc.Error(c, consts.ErrorGetSecret, err, "")
+		return
+	}
+	sc.OK(c, secret, fmt.Sprintf("get secret %s success", secretID))
+}
+
+// RevealSecret Get the secret with its values, authorized separately from reading secrets
+func (sc *Secret) RevealSecret(c *gin.Context) {
+	clusterID := c.Param("clusterID")
+	namespace := c.Param("namespace")
+	secretID := c.Param("secretID")
+	secret, err := sc.ss.RevealSecret(clusterID, namespace, secretID, sc.UserOption(c))
+	if err != nil {
+		sc.Error(c, consts.ErrorRevealSecret, err, "")
+		return
+	}
+	sc.OK(c, secret, fmt.Sprintf("reveal secret %s success", secretID))
+}
+
+// UpdateSecret Replace the data of the secret, the changed keys are returned without values
+func (sc *Secret) UpdateSecret(c *gin.Context) {
+	clusterID := c.Param("clusterID")
+	namespace := c.Param("namespace")
+	secretID := c.Param("secretID")
+	secretPost := core.SecretPost{}
+	if err := c.ShouldBindJSON(&secretPost); err != nil {
+		sc.Error(c, consts.ErrorUpdateSecret, err, "")
+		return
+	}
+	update, err := sc.ss.UpdateSecret(clusterID, namespace, secretID, secretPost, sc.UserOption(c))
+	if err != nil {
+		sc.Error(c, consts.ErrorUpdateSecret, err, "")
+		return
+	}
+	sc.OK(c, update, fmt.Sprintf("update secret %s success", secretID))
+}
+
+func (sc *Secret) DeleteSecret(c *gin.Context) {
+	clusterID := c.Param("clusterID")
+	namespace := c.Param("namespace")
+	secretID := c.Param("secretID")
+	if err := sc.ss.DeleteSecret(clusterID, namespace, secretID, sc.UserOption(c)); err != nil {
+		sc.Error(c, consts.ErrorDeleteSecret, err, "")
+		return
+	}
+	sc.OK(c, nil, fmt.Sprintf("delete secret %s success", secretID))
+}
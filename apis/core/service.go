@@ -0,0 +1,147 @@
+package core
+
+import (
+	"fmt"
+	"muti-kube/apis"
+	"muti-kube/models/core"
+	"muti-kube/pkg/consts"
+	"muti-kube/pkg/service"
+	coreService "muti-kube/pkg/service/core"
+
+	"github.com/gin-gonic/gin"
+)
+
+type Service struct {
+	apis.Base
+	ss coreService.ServiceInterface
+	// actions on the services of a namespace
+	actions *ActionRegistry
+}
+
+func NewService() (*Service, error) {
+	tmp, err := coreService.NewService()
+	if err != nil {
+		return nil, err
+	}
+	sc := &Service{
+		ss: tmp,
+	}
+	sc.actions = NewActionRegistry("services", apis.CreateAction,
+		&ActionHandler{
+			Action:      apis.CreateAction,
+			Description: "create the service",
+			Body:        core.ServicePost{},
+			Handle:      sc.createService,
+		},
+		&ActionHandler{
+			Action:      apis.DryRunAction,
+			Description: "validate the service with a server side dry-run without creating it",
+			Body:        core.ServicePost{},
+			Handle:      sc.dryRunService,
+		},
+	)
+	return sc, nil
+}
+
+func (sc *Service) createService(c *gin.Context) {
+	clusterID := c.Param("clusterID")
+	namespace := c.Param("namespace")
+	servicePost := core.ServicePost{}
+	if err := c.ShouldBindJSON(&servicePost); err != nil {
+		sc.Error(c, consts.ErrorCreateService, err, "")
+		return
+	}
+	svc, err := sc.ss.CreateService(clusterID, namespace, servicePost, sc.UserOption(c))
+	if err != nil {
+		sc.Error(c, consts.ErrorCreateService, err, "")
+		return
+	}
+	sc.OK(c, svc, "create service success")
+}
+
+func (sc *Service) dryRunService(c *gin.Context) {
+	clusterID := c.Param("clusterID")
+	namespace := c.Param("namespace")
+	servicePost := core.ServicePost{}
+	if err := c.ShouldBindJSON(&servicePost); err != nil {
+		sc.Error(c, consts.ErrorCreateService, err, "")
+		return
+	}
+	svc, err := sc.ss.DryRunService(clusterID, namespace, servicePost, sc.UserOption(c))
+	if err != nil {
+		sc.Error(c, consts.ErrorCreateService, err, "")
+		return
+	}
+	sc.OK(c, svc, "dry-run service success")
+}
+
+func (sc *Service) GetServices(c *gin.Context) {
+	pagination := sc.GetPagination(c)
+	clusterID := c.Param("clusterID")
+	namespace := c.Param("namespace")
+	services, count, err := sc.ss.GetServices(clusterID, namespace, service.WithPagination(pagination), sc.UserOption(c))
+	if err != nil {
+		sc.Error(c, consts.ErrorGetServices, err, "")
+		return
+	}
+	sc.PageOK(c, services, count, pagination, "")
+}
+
+// ServiceAction Run the create or dry-run action on the services of the namespace
+func (sc *Service) ServiceAction(c *gin.Context) {
+	sc.actions.Dispatch(c)
+}
+
+func (sc *Service) GetService(c *gin.Context) {
+	clusterID := c.Param("clusterID")
+	namespace := c.Param("namespace")
+	serviceID := c.Param("serviceID")
+	svc, err := sc.ss.GetService(clusterID, namespace, serviceID, sc.UserOption(c))
+	if err != nil {
+		sc.Error(c, consts.ErrorGetService, err, "")
+		return
+	}
+	sc.OK(c, svc, fmt.Sprintf("get service %s success", serviceID))
+}
+
+// UpdateService Replace the spec of the service, the allocated cluster ips and node ports are kept
+func (sc *Service) UpdateService(c *gin.Context) {
+	clusterID := c.Param("clusterID")
+	namespace := c.Param("namespace")
+	serviceID := c.Param("serviceID")
+	servicePost := core.ServicePost{}
+	if err := c.ShouldBindJSON(&servicePost); err != nil {
+		sc.Error(c, consts.ErrorUpdateService, err, "")
+		return
+	}
+	svc, err := sc.ss.UpdateService(clusterID, namespace, serviceID, servicePost, sc.UserOption(c))
+	if err != nil {
+		sc.Error(c, consts.ErrorUpdateService, err, "")
+		return
+	}
+	sc.OK(c, svc, fmt.Sprintf("update service %s success", serviceID))
+}
+
+func (sc *Service) DeleteService(c *gin.Context) {
+	clusterID := c.Param("clusterID")
+	namespace := c.Param("namespace")
+	serviceID := c.Param("serviceID")
+	if err := sc.ss.DeleteService(clusterID, namespace, serviceID, sc.UserOption(c)); err != nil {
+		sc.Error(c, consts.ErrorDeleteService, err, "")
+		return
+	}
+	sc.OK(c, nil, fmt.Sprintf("delete service %s success", serviceID))
+}
+
+// GetServicePods List the pods backing the service
+func (sc *Service) GetServicePods(c *gin.Context) {
+	clusterID := c.Param("clusterID")
+	namespace := c.Param("namespace")
+	serviceID := c.Param("serviceID")
+	pods, err := sc.ss.GetServicePods(clusterID, namespace, serviceID, sc.UserOption(c))
+	if err != nil {
+		sc.Error(c, consts.ErrorGetServicePods, err, "")
+		return
+	}
+	sc.OK(c, pods, fmt.Sprintf("get service %s pods success", serviceID))
+}
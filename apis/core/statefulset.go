@@ -0,0 +1,166 @@
+package core
+
+import (
+	"fmt"
+	"muti-kube/apis"
+	"muti-kube/models/core"
+	"muti-kube/pkg/consts"
+	"muti-kube/pkg/service"
+	coreService "muti-kube/pkg/service/core"
+
+	"github.com/gin-gonic/gin"
+)
+
+type StatefulSet struct {
+	apis.Base
+	ss coreService.StatefulSetInterface
+	// actions on the statefulsets of a namespace
+	actions *ActionRegistry
+	// actions on one statefulset
+	itemActions *ActionRegistry
+}
+
+func NewStatefulSet() (*StatefulSet, error) {
+	tmp, err := coreService.NewStatefulSet()
+	if err != nil {
+		return nil, err
+	}
+	sc := &StatefulSet{
+		ss: tmp,
+	}
+	sc.actions = NewActionRegistry("statefulsets", apis.CreateAction,
+		&ActionHandler{
+			Action:      apis.CreateAction,
+			Description: "create the statefulset",
+			Body:        core.StatefulSetPost{},
+			Handle:      sc.createStatefulSet,
+		},
+		&ActionHandler{
+			Action:      apis.DryRunAction,
+			Description: "validate the statefulset with a server side dry-run without creating it",
+			Body:        core.StatefulSetPost{},
+			Handle:      sc.dryRunStatefulSet,
+		},
+	)
+	sc.itemActions = NewActionRegistry("statefulset", "",
+		&ActionHandler{
+			Action:      apis.ScaleReplicasAction,
+			Description: "scale the statefulset to replicas",
+			Body:        core.StatefulSetScale{},
+			Handle:      sc.scaleStatefulSet,
+		},
+	)
+	return sc, nil
+}
+
+func (sc *StatefulSet) createStatefulSet(c *gin.Context) {
+	clusterID := c.Param("clusterID")
+	namespace := c.Param("namespace")
+	statefulSetPost := core.StatefulSetPost{}
+	if err := c.ShouldBindJSON(&statefulSetPost); err != nil {
+		sc.Error(c, consts.ErrorCreateStatefulSet, err, "")
+		return
+	}
+	statefulSet, err := sc.ss.CreateStatefulSet(clusterID, namespace, statefulSetPost, sc.UserOption(c))
+	if err != nil {
+		sc.Error(c, consts.ErrorCreateStatefulSet, err, "")
+		return
+	}
+	sc.OK(c, statefulSet, "create statefulset success")
+}
+
+func (sc *StatefulSet) dryRunStatefulSet(c *gin.Context) {
+	clusterID := c.Param("clusterID")
+	namespace := c.Param("namespace")
+	statefulSetPost := core.StatefulSetPost{}
+	if err := c.ShouldBindJSON(&statefulSetPost); err != nil {
+		sc.Error(c, consts.ErrorCreateStatefulSet, err, "")
+		return
+	}
+	statefulSet, err := sc.ss.DryRunStatefulSet(clusterID, namespace, statefulSetPost, sc.UserOption(c))
+	if err != nil {
+		sc.Error(c, consts.ErrorCreateStatefulSet, err, "")
+		return
+	}
+	sc.OK(c, statefulSet, "dry-run statefulset success")
+}
+
+func (sc *StatefulSet) scaleStatefulSet(c *gin.Context) {
+	clusterID := c.Param("clusterID")
+	namespace := c.Param("namespace")
+	statefulSetID := c.Param("statefulSetID")
+	statefulSetScale := core.StatefulSetScale{}
+	if err := c.ShouldBindJSON(&statefulSetScale); err != nil {
+		sc.Error(c, consts.ErrorScaleStatefulSet, err, "")
+		return
+	}
+	err := sc.ss.ScaleStatefulSet(clusterID, namespace, statefulSetID, *statefulSetScale.Replicas, sc.UserOption(c))
+	if err != nil {
+		sc.Error(c, consts.ErrorScaleStatefulSet, err, "")
+		return
+	}
+	sc.OK(c, nil, fmt.Sprintf("relicas %d statefulset success", *statefulSetScale.Replicas))
+}
+
+func (sc *StatefulSet) GetStatefulSets(c *gin.Context) {
+	pagination := sc.GetPagination(c)
+	clusterID := c.Param("clusterID")
+	namespace := c.Param("namespace")
+	statefulSets, count, err := sc.ss.GetStatefulSets(clusterID, namespace, service.WithPagination(pagination), sc.UserOption(c))
+	if err != nil {
+		sc.Error(c, consts.ErrorGetStatefulSets, err, "")
+		return
+	}
+	sc.PageOK(c, statefulSets, count, pagination, "")
+}
+
+// StatefulSetAction Run the create or dry-run action on the statefulsets of the namespace
+func (sc *StatefulSet) StatefulSetAction(c *gin.Context) {
+	sc.actions.Dispatch(c)
+}
+
+// StatefulSetItemAction Run the scale action on one statefulset
+func (sc *StatefulSet) StatefulSetItemAction(c *gin.Context) {
+	sc.itemActions.Dispatch(c)
+}
+
+func (sc *StatefulSet) GetStatefulSet(c *gin.Context) {
+	clusterID := c.Param("clusterID")
+	namespace := c.Param("namespace")
+	statefulSetID := c.Param("statefulSetID")
+	statefulSet, err := sc.ss.GetStatefulSet(clusterID, namespace, statefulSetID, sc.UserOption(c))
+	if err != nil {
+		sc.Error(c, consts.ErrorGetStatefulSet, err, "")
+		return
+	}
+	sc.OK(c, statefulSet, fmt.Sprintf("get statefulset %s success", statefulSetID))
+}
+
+// UpdateStatefulSet Replace the spec of the statefulset
+func (sc *StatefulSet) UpdateStatefulSet(c *gin.Context) {
+	clusterID := c.Param("clusterID")
+	namespace := c.Param("namespace")
+	statefulSetID := c.Param("statefulSetID")
+	statefulSetPost := core.StatefulSetPost{}
+	if err := c.ShouldBindJSON(&statefulSetPost); err != nil {
+		sc.Error(c, consts.ErrorUpdateStatefulSet, err, "")
+		return
+	}
+	statefulSet, err := sc.ss.UpdateStatefulSet(clusterID, namespace, statefulSetID, statefulSetPost, sc.UserOption(c))
+	if err != nil {
+		sc.Error(c, consts.ErrorUpdateStatefulSet, err, "")
+		return
+	}
+	sc.OK(c, statefulSet, fmt.Sprintf("update statefulset %s success", statefulSetID))
+}
+
+func (sc *StatefulSet) DeleteStatefulSet(c *gin.Context) {
+	clusterID := c.Param("clusterID")
+	namespace := c.Param("namespace")
+	statefulSetID := c.Param("statefulSetID")
+	if err := sc.ss.DeleteStatefulSet(clusterID, namespace, statefulSetID, sc.UserOption(c)); err != nil {
+		sc.Error(c, consts.ErrorDeleteStatefulSet, err, "")
+		return
+	}
+	sc.OK(c, nil, fmt.Sprintf("delete statefulset %s success", statefulSetID))
+}
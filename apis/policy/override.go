@@ -0,0 +1,128 @@
+package policy
+
+import (
+	"fmt"
+	"muti-kube/apis"
+	"muti-kube/apis/core"
+	policyModels "muti-kube/models/policy"
+	"muti-kube/pkg/consts"
+	"muti-kube/pkg/service"
+	policyService "muti-kube/pkg/service/policy"
+
+	"github.com/gin-gonic/gin"
+)
+
+type OverridePolicy struct {
+	apis.Base
+	ovs policyService.OverridePolicyInterface
+	// actions on the override policies of a namespace
+	actions *core.ActionRegistry
+}
+
+func NewOverridePolicy() (*OverridePolicy, error) {
+	tmp, err := policyService.NewOverridePolicy()
+	if err != nil {
+		return nil, err
+	}
+	oc := &OverridePolicy{
+		ovs: tmp,
+	}
+	oc.actions = core.NewActionRegistry("overridepolicies", apis.CreateAction,
+		&core.ActionHandler{
+			Action:      apis.CreateAction,
+			Description: "create the policy, the propagated deployments of the targeted clusters are updated",
+			Body:        policyModels.OverridePolicyPost{},
+			Handle:      oc.createOverridePolicy,
+		},
+		&core.ActionHandler{
+			Action:      apis.DryRunAction,
+			Description: "validate the policy with a server side dry-run without creating it",
+			Body:        policyModels.OverridePolicyPost{},
+			Handle:      oc.dryRunOverridePolicy,
+		},
+	)
+	return oc, nil
+}
+
+func (oc *OverridePolicy) createOverridePolicy(c *gin.Context) {
+	namespace := c.Param("namespace")
+	policyPost := policyModels.OverridePolicyPost{}
+	if err := c.ShouldBindJSON(&policyPost); err != nil {
+		oc.Error(c, consts.ErrorCreateOverridePolicy, err, "")
+		return
+	}
+	policy, err := oc.ovs.CreateOverridePolicy(namespace, policyPost, oc.UserOption(c))
+	if err != nil {
+		oc.Error(c, consts.ErrorCreateOverridePolicy, err, "")
+		return
+	}
+	oc.OK(c, policy, "create override policy success")
+}
+
+func (oc *OverridePolicy) dryRunOverridePolicy(c *gin.Context) {
+	namespace := c.Param("namespace")
+	policyPost := policyModels.OverridePolicyPost{}
+	if err := c.ShouldBindJSON(&policyPost); err != nil {
+		oc.Error(c, consts.ErrorCreateOverridePolicy, err, "")
+		return
+	}
+	policy, err := oc.ovs.DryRunOverridePolicy(namespace, policyPost, oc.UserOption(c))
+	if err != nil {
+		oc.Error(c, consts.ErrorCreateOverridePolicy, err, "")
+		return
+	}
+	oc.OK(c, policy, "dry-run override policy success")
+}
+
+func (oc *OverridePolicy) GetOverridePolicies(c *gin.Context) {
+	pagination := oc.GetPagination(c)
+	namespace := c.Param("namespace")
+	policies, count, err := oc.ovs.GetOverridePolicies(namespace, service.WithPagination(pagination), oc.UserOption(c))
+	if err != nil {
+		oc.Error(c, consts.ErrorGetOverridePolicies, err, "")
+		return
+	}
+	oc.PageOK(c, policies, count, pagination, "")
+}
+
+// OverridePolicyAction Run the create or dry-run action on the override policies of the namespace
+func (oc *OverridePolicy) OverridePolicyAction(c *gin.Context) {
+	oc.actions.Dispatch(c)
+}
+
+func (oc *OverridePolicy) GetOverridePolicy(c *gin.Context) {
+	namespace := c.Param("namespace")
+	name := c.Param("name")
+	policy, err := oc.ovs.GetOverridePolicy(namespace, name, oc.UserOption(c))
+	if err != nil {
+		oc.Error(c, consts.ErrorGetOverridePolicy, err, "")
+		return
+	}
+	oc.OK(c, policy, fmt.Sprintf("get override policy %s success", name))
+}
+
+func (oc *OverridePolicy) UpdateOverridePolicy(c *gin.Context) {
+	namespace := c.Param("namespace")
+	name := c.Param("name")
+	policyUpdate := policyModels.OverridePolicyUpdate{}
+	if err := c.ShouldBindJSON(&policyUpdate); err != nil {
+		oc.Error(c, consts.ErrorUpdateOverridePolicy, err, "")
+		return
+	}
+	policy, err := oc.ovs.UpdateOverridePolicy(namespace, name, policyUpdate, oc.UserOption(c))
+	if err != nil {
+		oc.Error(c, consts.ErrorUpdateOverridePolicy, err, "")
+		return
+	}
+	oc.OK(c, policy, fmt.Sprintf("update override policy %s success", name))
+}
+
+func (oc *OverridePolicy) DeleteOverridePolicy(c *gin.Context) {
+	namespace := c.Param("namespace")
+	name := c.Param("name")
+	if err := oc.ovs.DeleteOverridePolicy(namespace, name, oc.UserOption(c)); err != nil {
+		oc.Error(c, consts.ErrorDeleteOverridePolicy, err, "")
+		return
+	}
+	oc.OK(c, nil, fmt.Sprintf("delete override policy %s success", name))
+}
@@ -0,0 +1,146 @@
+package policy
+
+import (
+	"fmt"
+	"muti-kube/apis"
+	"muti-kube/apis/core"
+	policyModels "muti-kube/models/policy"
+	"muti-kube/pkg/consts"
+	"muti-kube/pkg/service"
+	policyService "muti-kube/pkg/service/policy"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PropagationPolicy struct {
+	apis.Base
+	ps policyService.PropagationPolicyInterface
+	// actions on the propagation policies of a namespace
+	actions *core.ActionRegistry
+}
+
+func NewPropagationPolicy() (*PropagationPolicy, error) {
+	tmp, err := policyService.NewPropagationPolicy()
+	if err != nil {
+		return nil, err
+	}
+	pc := &PropagationPolicy{
+		ps: tmp,
+	}
+	pc.actions = core.NewActionRegistry("propagationpolicies", apis.CreateAction,
+		&core.ActionHandler{
+			Action:      apis.CreateAction,
+			Description: "create the policy, its deployment is propagated to the selected clusters",
+			Body:        policyModels.PropagationPolicyPost{},
+			Handle:      pc.createPropagationPolicy,
+		},
+		&core.ActionHandler{
+			Action:      apis.DryRunAction,
+			Description: "validate the policy with a server side dry-run without creating it",
+			Body:        policyModels.PropagationPolicyPost{},
+			Handle:      pc.dryRunPropagationPolicy,
+		},
+	)
+	return pc, nil
+}
+
+func (pc *PropagationPolicy) createPropagationPolicy(c *gin.Context) {
+	namespace := c.Param("namespace")
+	policyPost := policyModels.PropagationPolicyPost{}
+	if err := c.ShouldBindJSON(&policyPost); err != nil {
+		pc.Error(c, consts.ErrorCreatePropagationPolicy, err, "")
+		return
+	}
+	policy, err := pc.ps.CreatePropagationPolicy(namespace, policyPost, pc.UserOption(c))
+	if err != nil {
+		pc.Error(c, consts.ErrorCreatePropagationPolicy, err, "")
+		return
+	}
+	pc.OK(c, policy, "create propagation policy success")
+}
+
+func (pc *PropagationPolicy) dryRunPropagationPolicy(c *gin.Context) {
+	namespace := c.Param("namespace")
+	policyPost := policyModels.PropagationPolicyPost{}
+	if err := c.ShouldBindJSON(&policyPost); err != nil {
+		pc.Error(c, consts.ErrorCreatePropagationPolicy, err, "")
+		return
+	}
+	policy, err := pc.ps.DryRunPropagationPolicy(namespace, policyPost, pc.UserOption(c))
+	if err != nil {
+		pc.Error(c, consts.ErrorCreatePropagationPolicy, err, "")
+		return
+	}
+	pc.OK(c, policy, "dry-run propagation policy success")
+}
+
+func (pc *PropagationPolicy) GetPropagationPolicies(c *gin.Context) {
+	pagination := pc.GetPagination(c)
+	namespace := c.Param("namespace")
+	policies, count, err := pc.ps.GetPropagationPolicies(namespace, service.WithPagination(pagination), pc.UserOption(c))
+	if err != nil {
+		pc.Error(c, consts.ErrorGetPropagationPolicies, err, "")
+		return
+	}
+	pc.PageOK(c, policies, count, pagination, "")
+}
+
+// PropagationPolicyAction Run the create or dry-run action on the propagation policies of the namespace
+func (pc *PropagationPolicy) PropagationPolicyAction(c *gin.Context) {
+	pc.actions.Dispatch(c)
+}
+
+// GetPropagationPolicy Get the policy with the sync status of its deployment in every selected cluster
+func (pc *PropagationPolicy) GetPropagationPolicy(c *gin.Context) {
+	namespace := c.Param("namespace")
+	name := c.Param("name")
+	policy, err := pc.ps.GetPropagationPolicy(namespace, name, pc.UserOption(c))
+	if err != nil {
+		pc.Error(c, consts.ErrorGetPropagationPolicy, err, "")
+		return
+	}
+	pc.OK(c, policy, fmt.Sprintf("get propagation policy %s success", name))
+}
+
+func (pc *PropagationPolicy) UpdatePropagationPolicy(c *gin.Context) {
+	namespace := c.Param("namespace")
+	name := c.Param("name")
+	policyUpdate := policyModels.PropagationPolicyUpdate{}
+	if err := c.ShouldBindJSON(&policyUpdate); err != nil {
+		pc.Error(c, consts.ErrorUpdatePropagationPolicy, err, "")
+		return
+	}
+	policy, err := pc.ps.UpdatePropagationPolicy(namespace, name, policyUpdate, pc.UserOption(c))
+	if err != nil {
+		pc.Error(c, consts.ErrorUpdatePropagationPolicy, err, "")
+		return
+	}
+	pc.OK(c, policy, fmt.Sprintf("update propagation policy %s success", name))
+}
+
+func (pc *PropagationPolicy) DeletePropagationPolicy(c *gin.Context) {
+	namespace := c.Param("namespace")
+	name := c.Param("name")
+	if err := pc.ps.DeletePropagationPolicy(namespace, name, pc.UserOption(c)); err != nil {
+		pc.Error(c, consts.ErrorDeletePropagationPolicy, err, "")
+		return
+	}
+	pc.OK(c, nil, fmt.Sprintf("delete propagation policy %s success", name))
+}
+
+// PreviewPropagationPolicy Render the deployment of the policy for the cluster of the query with the overrides applied
+func (pc *PropagationPolicy) PreviewPropagationPolicy(c *gin.Context) {
+	namespace := c.Param("namespace")
+	name := c.Param("name")
+	query := policyModels.PreviewQuery{}
+	if err := c.ShouldBindQuery(&query); err != nil {
+		pc.Error(c, consts.ErrorPreviewPropagationPolicy, err, "")
+		return
+	}
+	deployment, err := pc.ps.PreviewPropagationPolicy(namespace, name, query.Cluster, pc.UserOption(c))
+	if err != nil {
+		pc.Error(c, consts.ErrorPreviewPropagationPolicy, err, "")
+		return
+	}
+	pc.OK(c, deployment, "")
+}
@@ -1,12 +1,19 @@
 package cmd
 
 import (
+	"context"
 	"muti-kube/cmd/app/config"
+	"muti-kube/pkg/controller/failover"
+	"muti-kube/pkg/controller/propagation"
 	"muti-kube/pkg/periodic"
+	clusterService "muti-kube/pkg/service/cluster"
 	"muti-kube/pkg/util/logger"
 	"muti-kube/router"
+	"net/http"
 	"os"
 	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/spf13/cobra"
@@ -16,6 +23,9 @@ var (
 	configFile string
 )
 
+// shutdownTimeout how long in-flight requests are given to finish on shutdown
+const shutdownTimeout = 10 * time.Second
+
 func serverPreRun() {
 	config.LoadConfigFile(configFile)
 }
@@ -37,26 +47,79 @@ func newCmdServer() *cobra.Command {
 }
 
 func run() error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	if err := migrateKubeConfigs(); err != nil {
+		return err
+	}
 	r := router.InitRouter()
 	gin.SetMode(gin.DebugMode)
+	server := &http.Server{Addr: ":9000", Handler: r}
 	go func() {
-		if err := r.Run(":9000"); err != nil {
-			logger.Error()
+		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			logger.Error(err)
 		}
 	}()
+	periodicDone := make(chan struct{})
+	go func() {
+		defer close(periodicDone)
+		runPeriodic(ctx)
+	}()
+	propagationDone := make(chan struct{})
+	go func() {
+		defer close(propagationDone)
+		runPropagation(ctx)
+	}()
+	failoverDone := make(chan struct{})
 	go func() {
-		runPeriodic()
+		defer close(failoverDone)
+		runFailover(ctx)
 	}()
-	quit := make(chan os.Signal)
-	signal.Notify(quit, os.Interrupt)
-	<-quit
+	<-ctx.Done()
+	logger.Info("shutting down muti-kube")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		logger.Warn(err)
+	}
+	<-periodicDone
+	<-propagationDone
+	<-failoverDone
 	return nil
 }
 
-func runPeriodic() {
-	ticketPeriodic, err := periodic.NewTicketPeriodic()
+// migrateKubeConfigs move the kubeconfigs stored inline in clusters created by older versions into secrets
+func migrateKubeConfigs() error {
+	cs, err := clusterService.NewClusterService()
+	if err != nil {
+		return err
+	}
+	return cs.MigrateKubeConfigs()
+}
+
+func runPeriodic(ctx context.Context) {
+	ticketPeriodic, err := periodic.NewTicketPeriodic(periodic.NewPeriodicOptionsFromConfig())
+	if err != nil {
+		logger.Warn(err)
+		return
+	}
+	ticketPeriodic.Run(ctx)
+}
+
+func runPropagation(ctx context.Context) {
+	controller, err := propagation.NewPropagationController(propagation.NewPropagationOptionsFromConfig())
+	if err != nil {
+		logger.Warn(err)
+		return
+	}
+	controller.Run(ctx)
+}
+
+func runFailover(ctx context.Context) {
+	controller, err := failover.NewFailoverController(failover.NewFailoverOptionsFromConfig())
 	if err != nil {
 		logger.Warn(err)
+		return
 	}
-	ticketPeriodic.Start()
+	controller.Run(ctx)
 }
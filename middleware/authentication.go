@@ -1 +1,88 @@
 package middleware
+
+import (
+	"encoding/base64"
+	"errors"
+	"muti-kube/models/auth"
+	"muti-kube/models/common"
+	"muti-kube/pkg/authentication"
+	"muti-kube/pkg/consts"
+	"muti-kube/pkg/util/logger"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// UserContextKey key of the authenticated user in the gin context
+const UserContextKey = "muti-kube/user"
+
+// Authentication rejects requests without a bearer token accepted by the authenticator
+// and places the authenticated user into the gin context
+func Authentication(authenticator authentication.Authenticator) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token := bearerToken(c)
+		if token == "" {
+			unauthorized(c, errors.New("missing bearer token"))
+			return
+		}
+		user, ok, err := authenticator.AuthenticateToken(c.Request.Context(), token)
+		if err != nil {
+			unauthorized(c, err)
+			return
+		}
+		if !ok {
+			unauthorized(c, errors.New("invalid bearer token"))
+			return
+		}
+		c.Set(UserContextKey, user)
+		c.Next()
+	}
+}
+
+// GetUser the user authenticated by the Authentication middleware
+func GetUser(c *gin.Context) (*auth.User, bool) {
+	value, ok := c.Get(UserContextKey)
+	if !ok {
+		return nil, false
+	}
+	user, ok := value.(*auth.User)
+	return user, ok
+}
+
+// websocketTokenProtocolPrefix browsers can not set headers on websockets, the token is offered
+// as the subprotocol base64url.bearer.authorization.k8s.io.{base64url token} as kube-apiserver accepts
+const websocketTokenProtocolPrefix = "base64url.bearer.authorization.k8s.io."
+
+func bearerToken(c *gin.Context) string {
+	header := c.GetHeader("Authorization")
+	if len(header) > 7 && strings.EqualFold(header[:7], "Bearer ") {
+		return strings.TrimSpace(header[7:])
+	}
+	return websocketToken(c)
+}
+
+func websocketToken(c *gin.Context) string {
+	for _, protocols := range c.Request.Header.Values("Sec-WebSocket-Protocol") {
+		for _, protocol := range strings.Split(protocols, ",") {
+			protocol = strings.TrimSpace(protocol)
+			if !strings.HasPrefix(protocol, websocketTokenProtocolPrefix) {
+				continue
+			}
+			token, err := base64.RawURLEncoding.DecodeString(
+				strings.TrimRight(strings.TrimPrefix(protocol, websocketTokenProtocolPrefix), "="))
+			if err != nil {
+				return ""
+			}
+			return string(token)
+		}
+	}
+	return ""
+}
+
+func unauthorized(c *gin.Context, err error) {
+	var res common.Response
+	res.Msg = err.Error()
+	logger.Warn("authentication failed: ", res.Msg)
+	c.AbortWithStatusJSON(http.StatusUnauthorized, res.ReturnError(consts.ErrorUnauthorized))
+}
@@ -0,0 +1,87 @@
+package middleware
+
+import (
+	"errors"
+	"muti-kube/models/common"
+	"muti-kube/pkg/authorization"
+	"muti-kube/pkg/consts"
+	"muti-kube/pkg/util/logger"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Authorization rejects requests the authenticated user is not allowed to run, the cluster and
+// namespace are taken from the clusterID and namespace path parameters. A nil authorizer allows everything
+func Authorization(authorizer authorization.Authorizer, verb string, resource string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		authorize(c, authorizer, verb, resource, c.Param("namespace"))
+	}
+}
+
+// AttributesFunc resolves the verb, resource and namespace of a request whose resource is only known from its path
+type AttributesFunc func(c *gin.Context) (verb string, resource string, namespace string, err error)
+
+// DynamicAuthorization as Authorization with the verb, resource and namespace resolved from the request,
+// requests whose attributes can not be resolved are rejected, they never reach the handler unauthorized
+func DynamicAuthorization(authorizer authorization.Authorizer, attributes AttributesFunc) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		verb, resource, namespace, err := attributes(c)
+		if err != nil {
+			var res common.Response
+			res.Msg = err.Error()
+			logger.Warn("authorization attributes: ", res.Msg)
+			c.AbortWithStatusJSON(http.StatusBadRequest, res.ReturnError(consts.ErrorAuthorizationAttributes))
+			return
+		}
+		authorize(c, authorizer, verb, resource, namespace)
+	}
+}
+
+// RequestAuthorization as Authorization for the matched requests, the others pass through. For the requests
+// needing a permission beyond the one of their route, as the actions writing other resources
+func RequestAuthorization(authorizer authorization.Authorizer, matches func(c *gin.Context) bool,
+	verb string, resource string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !matches(c) {
+			c.Next()
+			return
+		}
+		authorize(c, authorizer, verb, resource, c.Param("namespace"))
+	}
+}
+
+func authorize(c *gin.Context, authorizer authorization.Authorizer, verb string, resource string, namespace string) {
+	if authorizer == nil {
+		c.Next()
+		return
+	}
+	user, ok := GetUser(c)
+	if !ok {
+		forbidden(c, errors.New("no authenticated user"))
+		return
+	}
+	allowed, reason, err := authorizer.Authorize(authorization.Attributes{
+		User:      user,
+		Verb:      verb,
+		Resource:  resource,
+		Cluster:   c.Param("clusterID"),
+		Namespace: namespace,
+	})
+	if err != nil {
+		forbidden(c, err)
+		return
+	}
+	if !allowed {
+		forbidden(c, errors.New(reason))
+		return
+	}
+	c.Next()
+}
+
+func forbidden(c *gin.Context, err error) {
+	var res common.Response
+	res.Msg = err.Error()
+	logger.Warn("authorization failed: ", res.Msg)
+	c.AbortWithStatusJSON(http.StatusForbidden, res.ReturnError(consts.ErrorForbidden))
+}
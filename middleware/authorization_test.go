@@ -0,0 +1,69 @@
+package middleware
+
+import (
+	"errors"
+	"muti-kube/models/auth"
+	"muti-kube/pkg/authorization"
+	"muti-kube/pkg/util/logger"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestDynamicAuthorizationRejectsUnresolved(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	logger.Init()
+	r := gin.New()
+	handled := false
+	unresolved := func(c *gin.Context) (string, string, string, error) {
+		return "", "", "", errors.New("invalid resource path")
+	}
+	r.GET("/resources/*path", DynamicAuthorization(nil, unresolved), func(c *gin.Context) {
+		handled = true
+		c.Status(http.StatusOK)
+	})
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/resources/x", nil))
+	if w.Code != http.StatusBadRequest || handled {
+		t.Errorf("unresolved request got %d, handled %v", w.Code, handled)
+	}
+}
+
+// resourceAuthorizer allows every verb on the resources it holds
+type resourceAuthorizer map[string]bool
+
+func (a resourceAuthorizer) Authorize(attributes authorization.Attributes) (bool, string, error) {
+	return a[attributes.Resource], attributes.Resource + " denied", nil
+}
+
+func TestRequestAuthorization(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	logger.Init()
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set(UserContextKey, &auth.User{Name: "alice"})
+	})
+	isTrigger := func(c *gin.Context) bool {
+		return c.Query("action") == "trigger"
+	}
+	r.POST("/cronjobs/:name", RequestAuthorization(resourceAuthorizer{"cronjobs": true}, isTrigger,
+		authorization.VerbCreate, authorization.ResourceJobs), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	tests := []struct {
+		url  string
+		code int
+	}{
+		{"/cronjobs/backup?action=suspend", http.StatusOK},
+		{"/cronjobs/backup?action=trigger", http.StatusForbidden},
+	}
+	for _, test := range tests {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, test.url, nil))
+		if w.Code != test.code {
+			t.Errorf("%s: got %d, want %d", test.url, w.Code, test.code)
+		}
+	}
+}
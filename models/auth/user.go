@@ -0,0 +1,22 @@
+package auth
+
+// User the authenticated caller of the api
+type User struct {
+	Name   string   `json:"name"`
+	UID    string   `json:"uid,omitempty"`
+	Groups []string `json:"groups,omitempty"`
+}
+
+// AccessReview asks whether the current user may run the verb on the resource
+type AccessReview struct {
+	Verb      string `json:"verb" form:"verb" binding:"required"`
+	Resource  string `json:"resource" form:"resource" binding:"required"`
+	Cluster   string `json:"cluster" form:"cluster"`
+	Namespace string `json:"namespace" form:"namespace"`
+}
+
+type AccessReviewResult struct {
+	User    *User  `json:"user,omitempty"`
+	Allowed bool   `json:"allowed"`
+	Reason  string `json:"reason,omitempty"`
+}
@@ -17,4 +17,27 @@ type Post struct {
 	DisplayName   string `json:"displayname"`
 	KubeConfig    string `json:"kubeconfig"`
 	PrometheusURL string `json:"prometheusurl"`
+	// Labels the labels of the cluster, the cluster.crd.muti-kube.com/ labels are set from the fields below
+	Labels     map[string]string `json:"labels,omitempty"`
+	Region     string            `json:"region,omitempty"`
+	Zone       string            `json:"zone,omitempty"`
+	Provider   string            `json:"provider,omitempty"`
+	ClusterSet string            `json:"clusterset,omitempty"`
+}
+
+// Patch only the non-empty fields are applied to the cluster, labels replace the labels of the cluster
+type Patch struct {
+	DisplayName   *string           `json:"displayname,omitempty"`
+	KubeConfig    *string           `json:"kubeconfig,omitempty"`
+	PrometheusURL *string           `json:"prometheusurl,omitempty"`
+	Labels        map[string]string `json:"labels,omitempty"`
+	Region        *string           `json:"region,omitempty"`
+	Zone          *string           `json:"zone,omitempty"`
+	Provider      *string           `json:"provider,omitempty"`
+	ClusterSet    *string           `json:"clusterset,omitempty"`
+}
+
+// Query filters the clusters by the labels of the Cluster objects
+type Query struct {
+	LabelSelector string `json:"label_selector" form:"label_selector"`
 }
@@ -0,0 +1,21 @@
+package cluster
+
+import "muti-kube/pkg/api/cluster/v1alpha1"
+
+// ClusterSet the cluster set with the names of its clusters
+type ClusterSet struct {
+	v1alpha1.ClusterSet
+	Clusters []string `json:"clusters"`
+}
+
+type ClusterSetPost struct {
+	Name        string `json:"name" binding:"required"`
+	DisplayName string `json:"displayname"`
+	Description string `json:"description"`
+}
+
+// ClusterSetUpdate replaces the display name and description of the set
+type ClusterSetUpdate struct {
+	DisplayName string `json:"displayname"`
+	Description string `json:"description"`
+}
@@ -1,7 +1,62 @@
 package cluster
 
-type PatchStringValue struct {
-	Op    string `json:"op"`
-	Path  string `json:"path"`
-	Value bool   `json:"value"`
+import (
+	"muti-kube/pkg/api/cluster/v1alpha1"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// NodeResource resource breakdown of a node of the cluster
+type NodeResource struct {
+	Name                     string `json:"name"`
+	Ready                    bool   `json:"ready"`
+	Unschedulable            bool   `json:"unschedulable"`
+	HasMetrics               bool   `json:"has_metrics"`
+	v1alpha1.ResourceSummary `json:",inline"`
+}
+
+// NodeLabels labels merged into the labels of the node, a null value removes the label
+type NodeLabels struct {
+	Labels map[string]*string `json:"labels" binding:"required"`
+}
+
+// NodeTaints replace the taints of the node
+type NodeTaints struct {
+	Taints []v1.Taint `json:"taints"`
+}
+
+// NodeDrain the options of draining a node, as kubectl drain
+type NodeDrain struct {
+	// GracePeriodSeconds of the pods evicted, the grace period of each pod when not given
+	GracePeriodSeconds *int64 `json:"grace_period_seconds"`
+	// IgnoreDaemonSets skip the pods of daemonsets, the drain fails on them otherwise
+	IgnoreDaemonSets bool `json:"ignore_daemonsets"`
+	// DeleteEmptyDirData evict pods using emptyDir volumes, their data is lost
+	DeleteEmptyDirData bool `json:"delete_emptydir_data"`
+	// Force evict pods not managed by a controller, they are not recreated
+	Force bool `json:"force"`
+	// TimeoutSeconds give up after, 300 seconds when not given
+	TimeoutSeconds int64 `json:"timeout_seconds"`
+}
+
+// phases of a drain
+const (
+	DrainRunning   = "Running"
+	DrainSucceeded = "Succeeded"
+	DrainFailed    = "Failed"
+)
+
+// NodeDrainStatus the progress of the drain of a node, pending are the namespace/name of the pods
+// not evicted yet, evictions blocked by a PodDisruptionBudget are retried until the timeout
+type NodeDrainStatus struct {
+	Node           string       `json:"node"`
+	Phase          string       `json:"phase"`
+	Message        string       `json:"message,omitempty"`
+	Total          int          `json:"total"`
+	Evicted        int          `json:"evicted"`
+	Pending        []string     `json:"pending"`
+	Skipped        []string     `json:"skipped"`
+	StartTime      metav1.Time  `json:"start_time"`
+	CompletionTime *metav1.Time `json:"completion_time,omitempty"`
 }
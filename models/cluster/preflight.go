@@ -0,0 +1,33 @@
+package cluster
+
+// PreflightCheck result of one check run before a cluster is imported
+type PreflightCheck struct {
+	Name     string `json:"name"`
+	Required bool   `json:"required"`
+	Passed   bool   `json:"passed"`
+	Message  string `json:"message,omitempty"`
+}
+
+// PreflightReport results of all checks run against a cluster before it is imported
+type PreflightReport struct {
+	Passed        bool             `json:"passed"`
+	ServerVersion string           `json:"server_version,omitempty"`
+	Checks        []PreflightCheck `json:"checks"`
+}
+
+// AddCheck record the result of a check, a failed required check fails the report
+func (r *PreflightReport) AddCheck(name string, required bool, err error) bool {
+	check := PreflightCheck{
+		Name:     name,
+		Required: required,
+		Passed:   err == nil,
+	}
+	if err != nil {
+		check.Message = err.Error()
+		if required {
+			r.Passed = false
+		}
+	}
+	r.Checks = append(r.Checks, check)
+	return check.Passed
+}
@@ -0,0 +1,68 @@
+package core
+
+import (
+	v1 "k8s.io/api/core/v1"
+)
+
+type ConfigMapPost struct {
+	v1.ConfigMap `json:",inline"`
+}
+
+// SecretPost a null value of data keeps the current value on update,
+// so a redacted secret read can be sent back with only the changed keys filled
+type SecretPost struct {
+	v1.Secret `json:",inline"`
+}
+
+// DockerRegistrySecretPost creates a kubernetes.io/dockerconfigjson secret,
+// as kubectl create secret docker-registry
+type DockerRegistrySecretPost struct {
+	Name        string            `json:"name" binding:"required"`
+	Labels      map[string]string `json:"labels"`
+	Annotations map[string]string `json:"annotations"`
+	Server      string            `json:"server" binding:"required"`
+	Username    string            `json:"username" binding:"required"`
+	Password    string            `json:"password" binding:"required"`
+	Email       string            `json:"email"`
+}
+
+// TLSSecretPost creates a kubernetes.io/tls secret from the PEM encoded certificate and key,
+// as kubectl create secret tls
+type TLSSecretPost struct {
+	Name        string            `json:"name" binding:"required"`
+	Labels      map[string]string `json:"labels"`
+	Annotations map[string]string `json:"annotations"`
+	Cert        string            `json:"cert" binding:"required"`
+	Key         string            `json:"key" binding:"required"`
+}
+
+// Secret the secret returned by the api, the values of data are null when redacted
+type Secret struct {
+	v1.Secret `json:",inline"`
+	Redacted  bool `json:"redacted"`
+}
+
+// operations of a data change
+const (
+	DataAdded   = "added"
+	DataRemoved = "removed"
+	DataChanged = "changed"
+)
+
+// DataChange a key of the data changed by an update, the values are only given for configmaps
+type DataChange struct {
+	Key       string `json:"key"`
+	Operation string `json:"operation"`
+	Old       string `json:"old,omitempty"`
+	New       string `json:"new,omitempty"`
+}
+
+type ConfigMapUpdate struct {
+	ConfigMap *v1.ConfigMap `json:"configmap"`
+	Changes   []DataChange  `json:"changes"`
+}
+
+type SecretUpdate struct {
+	Secret  *Secret      `json:"secret"`
+	Changes []DataChange `json:"changes"`
+}
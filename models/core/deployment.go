@@ -1,8 +1,46 @@
 package core
 
-import appsv1 "k8s.io/api/apps/v1"
+import (
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
 
 type DeploymentPost struct {
 	appsv1.Deployment `json:",inline"`
-	Replicas int32 `json:"replicas"`
+	Replicas          int32 `json:"replicas"`
+}
+
+// DeploymentScale the desired replicas of the deployment
+type DeploymentScale struct {
+	Replicas *int32 `json:"replicas" binding:"required"`
+}
+
+// DeploymentRollback the revision to roll the deployment back to, 0 means the previous revision
+type DeploymentRollback struct {
+	Revision int64 `json:"revision"`
+}
+
+// DeploymentRolloutStatus the progress of the latest rollout of the deployment
+type DeploymentRolloutStatus struct {
+	Revision          int64  `json:"revision"`
+	Replicas          int32  `json:"replicas"`
+	UpdatedReplicas   int32  `json:"updated_replicas"`
+	ReadyReplicas     int32  `json:"ready_replicas"`
+	AvailableReplicas int32  `json:"available_replicas"`
+	Paused            bool   `json:"paused"`
+	Done              bool   `json:"done"`
+	Failed            bool   `json:"failed"`
+	Message           string `json:"message"`
+}
+
+// DeploymentRevision one revision of the deployment, backed by a ReplicaSet
+type DeploymentRevision struct {
+	Revision          int64              `json:"revision"`
+	ReplicaSet        string             `json:"replica_set"`
+	Replicas          int32              `json:"replicas"`
+	ChangeCause       string             `json:"change_cause,omitempty"`
+	Current           bool               `json:"current"`
+	CreationTimestamp metav1.Time        `json:"creation_timestamp"`
+	Template          v1.PodTemplateSpec `json:"template"`
 }
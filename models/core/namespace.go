@@ -0,0 +1,50 @@
+package core
+
+import (
+	"muti-kube/pkg/simple/client/monitoring"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+// NamespacePost the resource quota and limit range given are created in the namespace with it
+type NamespacePost struct {
+	Name          string                `json:"name" binding:"required"`
+	Labels        map[string]string     `json:"labels"`
+	Annotations   map[string]string     `json:"annotations"`
+	ResourceQuota *v1.ResourceQuotaSpec `json:"resource_quota"`
+	LimitRange    *v1.LimitRangeSpec    `json:"limit_range"`
+}
+
+// NamespaceUpdate replaces the labels and annotations of the namespace
+type NamespaceUpdate struct {
+	Labels      map[string]string `json:"labels"`
+	Annotations map[string]string `json:"annotations"`
+}
+
+// NamespaceSummaryQuery the namespace_* metrics of the summary, the default ones when empty
+type NamespaceSummaryQuery struct {
+	Metrics []string `json:"metrics" form:"metrics"`
+}
+
+// ResourceUsage the hard limit and usage of a resource of a quota, ratio is used / hard
+type ResourceUsage struct {
+	Resource string  `json:"resource"`
+	Hard     string  `json:"hard"`
+	Used     string  `json:"used"`
+	Ratio    float64 `json:"ratio"`
+}
+
+type QuotaUsage struct {
+	Name      string          `json:"name"`
+	Resources []ResourceUsage `json:"resources"`
+}
+
+// NamespaceSummary the namespace with its quota usage, limit ranges and metrics,
+// metrics_error is set instead of failing when prometheus can not be queried
+type NamespaceSummary struct {
+	Namespace    *v1.Namespace       `json:"namespace"`
+	Quotas       []QuotaUsage        `json:"quotas"`
+	LimitRanges  []v1.LimitRange     `json:"limit_ranges"`
+	Metrics      []monitoring.Metric `json:"metrics"`
+	MetricsError string              `json:"metrics_error,omitempty"`
+}
@@ -0,0 +1,20 @@
+package core
+
+import (
+	v1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+)
+
+type ServicePost struct {
+	v1.Service `json:",inline"`
+}
+
+type IngressPost struct {
+	networkingv1.Ingress `json:",inline"`
+}
+
+// EndpointSliceQuery filters the endpointslices of a namespace
+type EndpointSliceQuery struct {
+	// Service only the endpointslices of the service
+	Service string `json:"service" form:"service"`
+}
@@ -0,0 +1,36 @@
+package core
+
+// PodQuery filters the pods of a namespace
+type PodQuery struct {
+	LabelSelector string `json:"label_selector" form:"label_selector"`
+	FieldSelector string `json:"field_selector" form:"field_selector"`
+	// OwnerKind and OwnerName select the pods of a workload, pods of a Deployment or
+	// CronJob are found through the ReplicaSets or Jobs they own
+	OwnerKind string `json:"owner_kind" form:"owner_kind"`
+	OwnerName string `json:"owner_name" form:"owner_name"`
+}
+
+// PodLogQuery the options of reading the logs of a container
+type PodLogQuery struct {
+	Container    string `json:"container" form:"container"`
+	TailLines    *int64 `json:"tail_lines" form:"tail_lines"`
+	SinceSeconds *int64 `json:"since_seconds" form:"since_seconds"`
+	Previous     bool   `json:"previous" form:"previous"`
+	Timestamps   bool   `json:"timestamps" form:"timestamps"`
+	LimitBytes   *int64 `json:"limit_bytes" form:"limit_bytes"`
+	// Follow keep streaming new logs, only for the websocket stream
+	Follow bool `json:"follow" form:"follow"`
+}
+
+// PodExecQuery the command run in a container of the pod
+type PodExecQuery struct {
+	Container string `json:"container" form:"container"`
+	// Command defaults to /bin/sh, repeat the query for the arguments
+	Command []string `json:"command" form:"command"`
+	TTY     bool     `json:"tty" form:"tty"`
+}
+
+// PodDelete the options of deleting a pod
+type PodDelete struct {
+	GracePeriodSeconds *int64 `json:"grace_period_seconds" form:"grace_period_seconds"`
+}
@@ -0,0 +1,52 @@
+package core
+
+// CoreGroup the group of the path of the resources of the legacy api group, whose group is empty
+const CoreGroup = "core"
+
+// APIResource a resource served by the member cluster, subresources are not listed
+type APIResource struct {
+	// Group is core for the legacy api group
+	Group      string   `json:"group"`
+	Version    string   `json:"version"`
+	Resource   string   `json:"resource"`
+	Kind       string   `json:"kind"`
+	Namespaced bool     `json:"namespaced"`
+	Verbs      []string `json:"verbs"`
+	ShortNames []string `json:"short_names,omitempty"`
+	Categories []string `json:"categories,omitempty"`
+	// Preferred whether the version is the preferred version of the group
+	Preferred bool `json:"preferred"`
+}
+
+// APIResourceList the resources of the member cluster, groups failing discovery are reported
+// in failed_groups instead of failing the whole request
+type APIResourceList struct {
+	Resources    []APIResource     `json:"resources"`
+	FailedGroups map[string]string `json:"failed_groups,omitempty"`
+}
+
+// APIResourceQuery filters the discovered resources
+type APIResourceQuery struct {
+	Group      string `json:"group" form:"group"`
+	Namespaced *bool  `json:"namespaced" form:"namespaced"`
+	// Preferred only the preferred version of each group
+	Preferred bool `json:"preferred" form:"preferred"`
+}
+
+// ResourceQuery filters the objects of a resource
+type ResourceQuery struct {
+	LabelSelector string `json:"label_selector" form:"label_selector"`
+	FieldSelector string `json:"field_selector" form:"field_selector"`
+}
+
+// ResourceApply the options of server side apply
+type ResourceApply struct {
+	// Force take the ownership of the fields managed by other field managers
+	Force bool `json:"force" form:"force"`
+}
+
+// ResourceDelete the options of deleting an object
+type ResourceDelete struct {
+	// PropagationPolicy Orphan, Background or Foreground, the default of the resource when not given
+	PropagationPolicy string `json:"propagation_policy" form:"propagation_policy"`
+}
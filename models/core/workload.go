@@ -0,0 +1,27 @@
+package core
+
+import (
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+)
+
+type StatefulSetPost struct {
+	appsv1.StatefulSet `json:",inline"`
+}
+
+// StatefulSetScale the desired replicas of the statefulset
+type StatefulSetScale struct {
+	Replicas *int32 `json:"replicas" binding:"required"`
+}
+
+type DaemonSetPost struct {
+	appsv1.DaemonSet `json:",inline"`
+}
+
+type JobPost struct {
+	batchv1.Job `json:",inline"`
+}
+
+type CronJobPost struct {
+	batchv1.CronJob `json:",inline"`
+}
@@ -0,0 +1,23 @@
+package policy
+
+import "muti-kube/pkg/api/cluster/v1alpha1"
+
+// OverridePolicyPost creates an override policy in the namespace of the path
+type OverridePolicyPost struct {
+	Name        string                      `json:"name" binding:"required"`
+	Labels      map[string]string           `json:"labels"`
+	Annotations map[string]string           `json:"annotations"`
+	Spec        v1alpha1.OverridePolicySpec `json:"spec"`
+}
+
+// OverridePolicyUpdate replaces the spec of the policy, labels and annotations are kept when not given
+type OverridePolicyUpdate struct {
+	Labels      map[string]string           `json:"labels"`
+	Annotations map[string]string           `json:"annotations"`
+	Spec        v1alpha1.OverridePolicySpec `json:"spec"`
+}
+
+// PreviewQuery the member cluster the deployment of a propagation policy is rendered for
+type PreviewQuery struct {
+	Cluster string `form:"cluster" binding:"required"`
+}
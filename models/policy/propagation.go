@@ -0,0 +1,18 @@
+package policy
+
+import "muti-kube/pkg/api/cluster/v1alpha1"
+
+// PropagationPolicyPost creates a propagation policy in the namespace of the path
+type PropagationPolicyPost struct {
+	Name        string                         `json:"name" binding:"required"`
+	Labels      map[string]string              `json:"labels"`
+	Annotations map[string]string              `json:"annotations"`
+	Spec        v1alpha1.PropagationPolicySpec `json:"spec"`
+}
+
+// PropagationPolicyUpdate replaces the spec of the policy, labels and annotations are kept when not given
+type PropagationPolicyUpdate struct {
+	Labels      map[string]string              `json:"labels"`
+	Annotations map[string]string              `json:"annotations"`
+	Spec        v1alpha1.PropagationPolicySpec `json:"spec"`
+}
@@ -0,0 +1,69 @@
+package v1alpha1
+
+import (
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// OverridePolicy changes the deployments propagated by the propagation policies of its namespace
+// per member cluster, the overrides are applied on top of the template before the deployment is
+// created or updated in the cluster
+type OverridePolicy struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              OverridePolicySpec `json:"spec"`
+}
+
+type OverridePolicySpec struct {
+	// PropagationPolicies the names of the propagation policies of the namespace the rules apply to,
+	// the rules apply to all of them when empty
+	// +optional
+	PropagationPolicies []string `json:"propagationPolicies,omitempty"`
+	// OverrideRules applied in order to the deployment of every cluster they target
+	OverrideRules []OverrideRule `json:"overrideRules"`
+}
+
+// OverrideRule the overriders applied to the deployment of the clusters selected by the target
+type OverrideRule struct {
+	TargetCluster ClusterAffinity `json:"targetCluster"`
+	Overriders    Overriders      `json:"overriders"`
+}
+
+// Overriders the field overrides are applied before the json patch
+type Overriders struct {
+	// +optional
+	FieldOverrides []FieldOverride `json:"fieldOverrides,omitempty"`
+	// +optional
+	JSONPatch []JSONPatchOperation `json:"jsonPatch,omitempty"`
+}
+
+// FieldOverride set the field of the path to the value, the path is dotted and list items are addressed
+// by index, such as spec.template.spec.containers.0.image. Missing objects on the path are created
+type FieldOverride struct {
+	Path  string               `json:"path"`
+	Value apiextensionsv1.JSON `json:"value"`
+}
+
+// JSONPatchOperation an operation of a RFC 6902 JSON patch
+type JSONPatchOperation struct {
+	// Op one of add, remove, replace, move, copy and test
+	Op   string `json:"op"`
+	Path string `json:"path"`
+	// +optional
+	From string `json:"from,omitempty"`
+	// +optional
+	Value *apiextensionsv1.JSON `json:"value,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type OverridePolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []OverridePolicy `json:"items"`
+}
@@ -0,0 +1,159 @@
+package v1alpha1
+
+import (
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// PropagationPolicyFinalizer keeps the policy until its deployments are removed from the member clusters
+	PropagationPolicyFinalizer = "crd.muti-kube.com/propagation"
+	// PropagationPolicyNamespaceLabel and PropagationPolicyNameLabel mark the deployments of the member clusters
+	// created by a policy, deployments without them are never updated or removed by the policy
+	PropagationPolicyNamespaceLabel = "propagationpolicy.crd.muti-kube.com/namespace"
+	PropagationPolicyNameLabel      = "propagationpolicy.crd.muti-kube.com/name"
+	// PolicyUserAnnotation the api user who last wrote the spec of a propagation or override policy, the
+	// deployments are only written while the user may write them, policies without it are trusted
+	PolicyUserAnnotation = "crd.muti-kube.com/user"
+)
+
+// Condition types and phases of a propagation policy
+const (
+	// PropagationSynced the deployment is up to date in every selected cluster
+	PropagationSynced = "Synced"
+	// PropagationFailed the deployment could not be created, updated or removed in the cluster
+	PropagationFailed = "Failed"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:subresource:status
+
+// PropagationPolicy places the template deployment into the member clusters selected by the placement,
+// the deployment is created in the namespace of the policy
+type PropagationPolicy struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              PropagationPolicySpec `json:"spec"`
+	// +optional
+	Status PropagationPolicyStatus `json:"status,omitempty"`
+}
+
+type PropagationPolicySpec struct {
+	Template  DeploymentTemplate `json:"template"`
+	Placement Placement          `json:"placement"`
+}
+
+// DeploymentTemplate the deployment created in the selected clusters
+type DeploymentTemplate struct {
+	// +optional
+	Metadata TemplateMeta          `json:"metadata,omitempty"`
+	Spec     appsv1.DeploymentSpec `json:"spec"`
+}
+
+// TemplateMeta the name, labels and annotations of the deployment, the name defaults to the name of the policy
+type TemplateMeta struct {
+	// +optional
+	Name string `json:"name,omitempty"`
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// Placement the clusters the deployment is propagated to
+type Placement struct {
+	ClusterAffinity ClusterAffinity `json:"clusterAffinity"`
+	// ReplicaScheduling divides the replicas of the template across the selected clusters,
+	// every cluster runs the replicas of the template when not set
+	// +optional
+	ReplicaScheduling *ReplicaScheduling `json:"replicaScheduling,omitempty"`
+}
+
+// Replica division types
+const (
+	// ReplicaDivisionStatic divides the replicas by the weights of the policy
+	ReplicaDivisionStatic = "Static"
+	// ReplicaDivisionDynamic divides the replicas by the number of replicas each cluster can still run,
+	// estimated from the allocatable and requested resources in the status of the cluster
+	ReplicaDivisionDynamic = "Dynamic"
+)
+
+// ReplicaScheduling the replicas are only scheduled to the ready clusters, the replicas of a cluster
+// failed over are moved to the others
+type ReplicaScheduling struct {
+	// Type Static or Dynamic
+	Type string `json:"type"`
+	// MigrateBack schedule the replicas again when a cluster left out of the schedule recovers,
+	// otherwise it runs no replicas until the replicas are scheduled again for another reason
+	// +optional
+	MigrateBack bool `json:"migrateBack,omitempty"`
+	// StaticWeights the weight of a cluster is the weight of the first entry targeting it, 0 when none does
+	// +optional
+	StaticWeights []StaticClusterWeight `json:"staticWeights,omitempty"`
+}
+
+type StaticClusterWeight struct {
+	TargetCluster ClusterAffinity `json:"targetCluster"`
+	Weight        int64           `json:"weight"`
+}
+
+// ClusterAffinity selects clusters by name, by ClusterSet and by the labels of the Cluster objects, a cluster
+// must satisfy every field given, an empty affinity selects no cluster
+type ClusterAffinity struct {
+	// +optional
+	ClusterNames []string `json:"clusterNames,omitempty"`
+	// ClusterSets the cluster must belong to one of the sets
+	// +optional
+	ClusterSets []string `json:"clusterSets,omitempty"`
+	// +optional
+	LabelSelector *metav1.LabelSelector `json:"labelSelector,omitempty"`
+}
+
+type PropagationPolicyStatus struct {
+	// ObservedGeneration the generation of the policy the status was computed for
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// Clusters the clusters the deployment is propagated to, and the clusters it is still to be removed from
+	// +optional
+	Clusters []ClusterPropagationStatus `json:"clusters,omitempty"`
+	// UnavailableClusters the selected clusters not ready or failed over when the replicas were last scheduled
+	// +optional
+	UnavailableClusters []string `json:"unavailableClusters,omitempty"`
+}
+
+// ClusterPropagationStatus the sync status of the deployment in one member cluster
+type ClusterPropagationStatus struct {
+	Cluster string `json:"cluster"`
+	// Deployment the name of the deployment propagated to the cluster, the deployment is removed by this name
+	// once the cluster is no longer selected, the policy is deleted or the template is renamed
+	// +optional
+	Deployment string `json:"deployment,omitempty"`
+	// Phase Synced or Failed
+	Phase string `json:"phase"`
+	// +optional
+	Message string `json:"message,omitempty"`
+	// +optional
+	Replicas int32 `json:"replicas,omitempty"`
+	// +optional
+	ReadyReplicas int32 `json:"readyReplicas,omitempty"`
+	// +optional
+	AvailableReplicas int32 `json:"availableReplicas,omitempty"`
+	// ScheduledReplicas the replicas scheduled to the cluster by the replica scheduling of the placement
+	// +optional
+	ScheduledReplicas *int32 `json:"scheduledReplicas,omitempty"`
+	// +optional
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type PropagationPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []PropagationPolicy `json:"items"`
+}
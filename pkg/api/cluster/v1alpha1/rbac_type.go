@@ -0,0 +1,80 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Subject kinds of a role binding
+const (
+	UserKind  = "User"
+	GroupKind = "Group"
+)
+
+// PolicyWildcard matches every verb, resource, cluster or namespace
+const PolicyWildcard = "*"
+
+// +genclient
+// +genclient:nonNamespaced
+// +genclient:noStatus
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// Role a set of rules granting access to resources of member clusters
+type Role struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Rules             []PolicyRule `json:"rules"`
+}
+
+// PolicyRule grants the verbs on the resources in the clusters and namespaces,
+// an empty clusters or namespaces list matches all of them
+type PolicyRule struct {
+	Verbs     []string `json:"verbs"`
+	Resources []string `json:"resources"`
+	// +optional
+	Clusters []string `json:"clusters,omitempty"`
+	// +optional
+	Namespaces []string `json:"namespaces,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type RoleList struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Role `json:"items"`
+}
+
+// +genclient
+// +genclient:nonNamespaced
+// +genclient:noStatus
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// RoleBinding grants the rules of a role to users and groups
+type RoleBinding struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Subjects          []Subject `json:"subjects"`
+	RoleRef           RoleRef   `json:"roleRef"`
+}
+
+type Subject struct {
+	// Kind User or Group
+	Kind string `json:"kind"`
+	Name string `json:"name"`
+}
+
+type RoleRef struct {
+	Name string `json:"name"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type RoleBindingList struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RoleBinding `json:"items"`
+}
@@ -1,12 +1,14 @@
 package v1alpha1
 
 import (
+	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // +genclient
 // +genclient:nonNamespaced
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:subresource:status
 
 type Cluster struct {
 	metav1.TypeMeta `json:",inline"`
@@ -16,21 +18,108 @@ type Cluster struct {
 	Status            ClusterStatus `json:"status"`
 }
 
-// +k8s:deepcopy-gen=false
-
 type ClusterSpec struct {
-	KubeConfig    string `json:"kubeconfig"`
-	DisplayName   string `json:"displayname"`
-	PrometheusURL string `json:"prometheusurl"`
+	// KubeConfig deprecated inline kubeconfig of clusters imported before kubeconfigs
+	// were moved into secrets, it is migrated into a secret on start
+	// +optional
+	KubeConfig string `json:"kubeconfig,omitempty"`
+	// KubeConfigSecretRef the secret in the muti-kube namespace holding the kubeconfig
+	// +optional
+	KubeConfigSecretRef *SecretReference `json:"kubeconfigSecretRef,omitempty"`
+	DisplayName         string           `json:"displayname"`
+	PrometheusURL       string           `json:"prometheusurl"`
+	// Region, Zone and Provider where the cluster runs, they are mirrored into the ClusterRegionLabel,
+	// ClusterZoneLabel and ClusterProviderLabel labels of the cluster to be selected by label selectors
+	// +optional
+	Region string `json:"region,omitempty"`
+	// +optional
+	Zone string `json:"zone,omitempty"`
+	// +optional
+	Provider string `json:"provider,omitempty"`
+	// Taints the failover controller taints the clusters not ready for longer than the grace period
+	// with ClusterNotReadyTaint, no replicas are scheduled to a cluster with a NoExecute taint
+	// +optional
+	Taints []v1.Taint `json:"taints,omitempty"`
 }
 
-// +k8s:deepcopy-gen=false
+// ClusterNotReadyTaint the key of the NoExecute taint of the clusters failed over
+const ClusterNotReadyTaint = "cluster.crd.muti-kube.com/not-ready"
+
+// Labels of a cluster managed by muti-kube, they are not set through the labels of the cluster api
+const (
+	ClusterRegionLabel   = "cluster.crd.muti-kube.com/region"
+	ClusterZoneLabel     = "cluster.crd.muti-kube.com/zone"
+	ClusterProviderLabel = "cluster.crd.muti-kube.com/provider"
+	// ClusterSetLabel the name of the ClusterSet the cluster belongs to
+	ClusterSetLabel = "cluster.crd.muti-kube.com/clusterset"
+)
+
+// SecretReference a key of a secret in the muti-kube namespace
+type SecretReference struct {
+	Name string `json:"name"`
+	Key  string `json:"key"`
+}
+
+// Condition types of a cluster
+const (
+	// ClusterReady the cluster is reachable and can be managed
+	ClusterReady = "Ready"
+	// ClusterAPIServerReachable the api server of the cluster answers requests
+	ClusterAPIServerReachable = "APIServerReachable"
+	// ClusterPrometheusReachable the prometheus of the cluster is healthy
+	ClusterPrometheusReachable = "PrometheusReachable"
+	// ClusterMetricsAPIAvailable the metrics.k8s.io api of the cluster is served
+	ClusterMetricsAPIAvailable = "MetricsAPIAvailable"
+)
 
 type ClusterStatus struct {
 	CPUCapacity    int64 `json:"cpu_capacity"`
 	MemoryCapacity int64 `json:"memory_capacity"`
 	CPUUsage       int64 `json:"cpu_usage"`
 	MemoryUsage    int64 `json:"memory_usage"`
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// +optional
+	KubernetesVersion string `json:"kubernetes_version,omitempty"`
+	// +optional
+	NodeCount int32 `json:"node_count,omitempty"`
+	// +optional
+	ReadyNodeCount int32 `json:"ready_node_count,omitempty"`
+	// LastProbeTime the last time the cluster was probed
+	// +optional
+	LastProbeTime *metav1.Time `json:"last_probe_time,omitempty"`
+	// LastSuccessfulProbeTime the last time the api server of the cluster answered the probe
+	// +optional
+	LastSuccessfulProbeTime *metav1.Time `json:"last_successful_probe_time,omitempty"`
+	// LastError the error of the last failed probe
+	// +optional
+	LastError string `json:"last_error,omitempty"`
+	// Resources the resource rollup of the nodes of the cluster
+	// +optional
+	Resources *ClusterResources `json:"resources,omitempty"`
+}
+
+// ResourceSummary capacity, allocatable, requests, limits and usage of cpu, memory, pods and ephemeral storage
+type ResourceSummary struct {
+	// +optional
+	Capacity v1.ResourceList `json:"capacity,omitempty"`
+	// +optional
+	Allocatable v1.ResourceList `json:"allocatable,omitempty"`
+	// +optional
+	Requests v1.ResourceList `json:"requests,omitempty"`
+	// +optional
+	Limits v1.ResourceList `json:"limits,omitempty"`
+	// Usage the pods usage is the number of non-terminated pods, the others come from the metrics api
+	// +optional
+	Usage v1.ResourceList `json:"usage,omitempty"`
+}
+
+type ClusterResources struct {
+	ResourceSummary `json:",inline"`
+	// NodeCount number of nodes summed into capacity, allocatable, requests and limits
+	NodeCount int32 `json:"node_count"`
+	// MetricsNodeCount number of nodes whose metrics are summed into usage
+	MetricsNodeCount int32 `json:"metrics_node_count"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
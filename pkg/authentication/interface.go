@@ -0,0 +1,33 @@
+package authentication
+
+import (
+	"context"
+	"muti-kube/models/auth"
+)
+
+// Authenticator verifies a bearer token, ok is false when the token is not handled by the authenticator
+type Authenticator interface {
+	AuthenticateToken(ctx context.Context, token string) (user *auth.User, ok bool, err error)
+}
+
+type unionAuthenticator []Authenticator
+
+// NewUnionAuthenticator tries the authenticators in order until one of them accepts the token
+func NewUnionAuthenticator(authenticators ...Authenticator) Authenticator {
+	return unionAuthenticator(authenticators)
+}
+
+func (u unionAuthenticator) AuthenticateToken(ctx context.Context, token string) (*auth.User, bool, error) {
+	var lastErr error
+	for _, authenticator := range u {
+		user, ok, err := authenticator.AuthenticateToken(ctx, token)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		if ok {
+			return user, true, nil
+		}
+	}
+	return nil, false, lastErr
+}
@@ -0,0 +1,135 @@
+package authentication
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"muti-kube/models/auth"
+	"time"
+
+	"gopkg.in/square/go-jose.v2"
+	"gopkg.in/square/go-jose.v2/jwt"
+)
+
+type JWTOptions struct {
+	// Secret shared secret of HS256 signed tokens
+	Secret string `json:"secret,omitempty" yaml:"secret"`
+	// JWKSFile path of the JSON Web Key Set verifying RS256 signed tokens
+	JWKSFile string `json:"jwksFile,omitempty" yaml:"jwksFile"`
+	// Issuer expected iss claim, not checked when empty
+	Issuer string `json:"issuer,omitempty" yaml:"issuer"`
+	// Audience expected aud claim, not checked when empty
+	Audience string `json:"audience,omitempty" yaml:"audience"`
+	// UsernameClaim claim holding the user name, defaults to sub
+	UsernameClaim string `json:"usernameClaim,omitempty" yaml:"usernameClaim"`
+	// GroupsClaim claim holding the groups of the user, defaults to groups
+	GroupsClaim string `json:"groupsClaim,omitempty" yaml:"groupsClaim"`
+}
+
+type jwtAuthenticator struct {
+	options *JWTOptions
+	secret  []byte
+	keySet  *jose.JSONWebKeySet
+}
+
+// NewJWTAuthenticator authenticates HS256 tokens with the shared secret and RS256 tokens with the JWKS file
+func NewJWTAuthenticator(options *JWTOptions) (Authenticator, error) {
+	a := &jwtAuthenticator{options: options}
+	if options.Secret != "" {
+		a.secret = []byte(options.Secret)
+	}
+	if options.JWKSFile != "" {
+		data, err := ioutil.ReadFile(options.JWKSFile)
+		if err != nil {
+			return nil, err
+		}
+		a.keySet = &jose.JSONWebKeySet{}
+		if err = json.Unmarshal(data, a.keySet); err != nil {
+			return nil, fmt.Errorf("parse jwks file %s: %v", options.JWKSFile, err)
+		}
+	}
+	if a.secret == nil && a.keySet == nil {
+		return nil, errors.New("jwt authentication needs a secret or a jwks file")
+	}
+	if a.options.UsernameClaim == "" {
+		a.options.UsernameClaim = "sub"
+	}
+	if a.options.GroupsClaim == "" {
+		a.options.GroupsClaim = "groups"
+	}
+	return a, nil
+}
+
+func (a *jwtAuthenticator) AuthenticateToken(ctx context.Context, token string) (*auth.User, bool, error) {
+	tok, err := jwt.ParseSigned(token)
+	if err != nil || len(tok.Headers) == 0 {
+		// not a jwt, leave it to the other authenticators
+		return nil, false, nil
+	}
+	claims := jwt.Claims{}
+	custom := map[string]interface{}{}
+	if err = a.verify(tok, &claims, &custom); err != nil {
+		return nil, false, err
+	}
+	expected := jwt.Expected{
+		Issuer: a.options.Issuer,
+		Time:   time.Now(),
+	}
+	if a.options.Audience != "" {
+		expected.Audience = jwt.Audience{a.options.Audience}
+	}
+	// tokens without an expiry would be accepted forever, nbf and iat are checked when given
+	if claims.Expiry == nil {
+		return nil, false, errors.New("jwt claim exp is required")
+	}
+	if err = claims.ValidateWithLeeway(expected, jwt.DefaultLeeway); err != nil {
+		return nil, false, err
+	}
+	name, _ := custom[a.options.UsernameClaim].(string)
+	if name == "" {
+		return nil, false, fmt.Errorf("jwt claim %s is empty", a.options.UsernameClaim)
+	}
+	user := &auth.User{
+		Name: name,
+		UID:  claims.Subject,
+	}
+	if groups, ok := custom[a.options.GroupsClaim].([]interface{}); ok {
+		for _, group := range groups {
+			if groupName, ok := group.(string); ok {
+				user.Groups = append(user.Groups, groupName)
+			}
+		}
+	}
+	return user, true, nil
+}
+
+// verify check the signature of the token with the key matching its algorithm and key id
+func (a *jwtAuthenticator) verify(tok *jwt.JSONWebToken, out ...interface{}) error {
+	header := tok.Headers[0]
+	switch jose.SignatureAlgorithm(header.Algorithm) {
+	case jose.HS256:
+		if a.secret == nil {
+			return errors.New("HS256 tokens are not accepted")
+		}
+		return tok.Claims(a.secret, out...)
+	case jose.RS256:
+		if a.keySet == nil {
+			return errors.New("RS256 tokens are not accepted")
+		}
+		keys := a.keySet.Keys
+		if header.KeyID != "" {
+			keys = a.keySet.Key(header.KeyID)
+		}
+		err := fmt.Errorf("no key found for kid %q", header.KeyID)
+		for _, key := range keys {
+			if err = tok.Claims(key, out...); err == nil {
+				return nil
+			}
+		}
+		return err
+	default:
+		return fmt.Errorf("unsupported jwt algorithm %s", header.Algorithm)
+	}
+}
@@ -0,0 +1,68 @@
+package authentication
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"gopkg.in/square/go-jose.v2"
+	"gopkg.in/square/go-jose.v2/jwt"
+)
+
+func signHS256(t *testing.T, secret string, claims interface{}) string {
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.HS256, Key: []byte(secret)}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	token, err := jwt.Signed(signer).Claims(claims).CompactSerialize()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return token
+}
+
+func TestJWTAuthenticator(t *testing.T) {
+	authenticator, err := NewJWTAuthenticator(&JWTOptions{
+		Secret:   "secret",
+		Issuer:   "muti-kube-test",
+		Audience: "muti-kube",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+	claims := map[string]interface{}{
+		"sub":    "alice",
+		"iss":    "muti-kube-test",
+		"aud":    "muti-kube",
+		"exp":    now.Add(time.Hour).Unix(),
+		"groups": []string{"team-a"},
+	}
+	user, ok, err := authenticator.AuthenticateToken(context.Background(), signHS256(t, "secret", claims))
+	if err != nil || !ok {
+		t.Fatalf("valid token rejected: %v", err)
+	}
+	if user.Name != "alice" || len(user.Groups) != 1 || user.Groups[0] != "team-a" {
+		t.Errorf("unexpected user %+v", user)
+	}
+
+	if _, ok, _ = authenticator.AuthenticateToken(context.Background(), signHS256(t, "other", claims)); ok {
+		t.Error("token signed with another secret accepted")
+	}
+	claims["exp"] = now.Add(-time.Hour).Unix()
+	if _, ok, _ = authenticator.AuthenticateToken(context.Background(), signHS256(t, "secret", claims)); ok {
+		t.Error("expired token accepted")
+	}
+	delete(claims, "exp")
+	if _, ok, _ = authenticator.AuthenticateToken(context.Background(), signHS256(t, "secret", claims)); ok {
+		t.Error("token without exp accepted")
+	}
+	claims["exp"] = now.Add(time.Hour).Unix()
+	claims["nbf"] = now.Add(time.Hour).Unix()
+	if _, ok, _ = authenticator.AuthenticateToken(context.Background(), signHS256(t, "secret", claims)); ok {
+		t.Error("token not valid yet accepted")
+	}
+	if _, ok, err = authenticator.AuthenticateToken(context.Background(), "static-token"); ok || err != nil {
+		t.Errorf("non jwt token should be left to other authenticators, ok=%v err=%v", ok, err)
+	}
+}
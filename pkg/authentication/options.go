@@ -0,0 +1,53 @@
+package authentication
+
+import (
+	"github.com/spf13/viper"
+	"k8s.io/client-go/kubernetes"
+)
+
+type Options struct {
+	// Tokens static bearer tokens
+	Tokens []StaticToken `json:"tokens,omitempty" yaml:"tokens"`
+	// JWT verifies HS256/RS256 signed json web tokens
+	JWT *JWTOptions `json:"jwt,omitempty" yaml:"jwt"`
+	// TokenReview verifies kubernetes tokens against the host cluster
+	TokenReview bool `json:"tokenReview,omitempty" yaml:"tokenReview"`
+	// Impersonation requests to member clusters impersonate the authenticated user and groups
+	Impersonation bool `json:"impersonation,omitempty" yaml:"impersonation"`
+}
+
+// NewAuthenticationOptionsFromConfig read the options from settings.authentication of the config file
+func NewAuthenticationOptionsFromConfig() (*Options, error) {
+	options := &Options{}
+	if err := viper.UnmarshalKey("settings.authentication", options); err != nil {
+		return nil, err
+	}
+	return options, nil
+}
+
+// Enabled whether any authenticator is configured, the api is served without authorization otherwise
+func (o *Options) Enabled() bool {
+	return len(o.Tokens) > 0 || o.JWT != nil || o.TokenReview
+}
+
+// NewAuthenticator build the configured authenticators, nil is returned when none is configured
+func (o *Options) NewAuthenticator(hostClient kubernetes.Interface) (Authenticator, error) {
+	var authenticators []Authenticator
+	if len(o.Tokens) > 0 {
+		authenticators = append(authenticators, NewTokenAuthenticator(o.Tokens))
+	}
+	if o.JWT != nil {
+		jwtAuthenticator, err := NewJWTAuthenticator(o.JWT)
+		if err != nil {
+			return nil, err
+		}
+		authenticators = append(authenticators, jwtAuthenticator)
+	}
+	if o.TokenReview {
+		authenticators = append(authenticators, NewTokenReviewAuthenticator(hostClient))
+	}
+	if len(authenticators) == 0 {
+		return nil, nil
+	}
+	return NewUnionAuthenticator(authenticators...), nil
+}
@@ -0,0 +1,40 @@
+package authentication
+
+import (
+	"context"
+	"crypto/subtle"
+	"muti-kube/models/auth"
+)
+
+// StaticToken a bearer token configured in the config file
+type StaticToken struct {
+	Token  string   `json:"token" yaml:"token"`
+	User   string   `json:"user" yaml:"user"`
+	UID    string   `json:"uid,omitempty" yaml:"uid"`
+	Groups []string `json:"groups,omitempty" yaml:"groups"`
+}
+
+type tokenAuthenticator struct {
+	tokens []StaticToken
+}
+
+// NewTokenAuthenticator authenticates the static bearer tokens from the config file
+func NewTokenAuthenticator(tokens []StaticToken) Authenticator {
+	return &tokenAuthenticator{tokens: tokens}
+}
+
+func (a *tokenAuthenticator) AuthenticateToken(ctx context.Context, token string) (*auth.User, bool, error) {
+	for _, item := range a.tokens {
+		if item.Token == "" {
+			continue
+		}
+		if subtle.ConstantTimeCompare([]byte(item.Token), []byte(token)) == 1 {
+			return &auth.User{
+				Name:   item.User,
+				UID:    item.UID,
+				Groups: item.Groups,
+			}, true, nil
+		}
+	}
+	return nil, false, nil
+}
@@ -0,0 +1,42 @@
+package authentication
+
+import (
+	"context"
+	"errors"
+	"muti-kube/models/auth"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+type tokenReviewAuthenticator struct {
+	client kubernetes.Interface
+}
+
+// NewTokenReviewAuthenticator authenticates kubernetes tokens through a TokenReview against the host cluster
+func NewTokenReviewAuthenticator(client kubernetes.Interface) Authenticator {
+	return &tokenReviewAuthenticator{client: client}
+}
+
+func (a *tokenReviewAuthenticator) AuthenticateToken(ctx context.Context, token string) (*auth.User, bool, error) {
+	review, err := a.client.AuthenticationV1().TokenReviews().Create(ctx, &authenticationv1.TokenReview{
+		Spec: authenticationv1.TokenReviewSpec{
+			Token: token,
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return nil, false, err
+	}
+	if review.Status.Error != "" {
+		return nil, false, errors.New(review.Status.Error)
+	}
+	if !review.Status.Authenticated {
+		return nil, false, nil
+	}
+	return &auth.User{
+		Name:   review.Status.User.Username,
+		UID:    review.Status.User.UID,
+		Groups: review.Status.User.Groups,
+	}, true, nil
+}
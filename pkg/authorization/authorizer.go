@@ -0,0 +1,108 @@
+package authorization
+
+import (
+	"fmt"
+	"muti-kube/models/auth"
+	"muti-kube/pkg/api/cluster/v1alpha1"
+	clusterlisters "muti-kube/pkg/client/cluster/listers/cluster/v1alpha1"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// Attributes the request to authorize, an empty cluster or namespace means the request is not
+// scoped to a single cluster or namespace and only matches rules covering all of them
+type Attributes struct {
+	User      *auth.User
+	Verb      string
+	Resource  string
+	Cluster   string
+	Namespace string
+}
+
+type Authorizer interface {
+	Authorize(attributes Attributes) (allowed bool, reason string, err error)
+}
+
+type rbacAuthorizer struct {
+	roleLister        clusterlisters.RoleLister
+	roleBindingLister clusterlisters.RoleBindingLister
+}
+
+// NewRBACAuthorizer authorizes requests with the Role and RoleBinding resources of crd.muti-kube.com
+func NewRBACAuthorizer(roleLister clusterlisters.RoleLister,
+	roleBindingLister clusterlisters.RoleBindingLister) Authorizer {
+	return &rbacAuthorizer{
+		roleLister:        roleLister,
+		roleBindingLister: roleBindingLister,
+	}
+}
+
+func (a *rbacAuthorizer) Authorize(attributes Attributes) (bool, string, error) {
+	if attributes.User == nil {
+		return false, "no user", nil
+	}
+	bindings, err := a.roleBindingLister.List(labels.Everything())
+	if err != nil {
+		return false, "", err
+	}
+	for _, binding := range bindings {
+		if !bindingAppliesTo(binding, attributes.User) {
+			continue
+		}
+		role, err := a.roleLister.Get(binding.RoleRef.Name)
+		if apierrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return false, "", err
+		}
+		for _, rule := range role.Rules {
+			if RuleAllows(rule, attributes) {
+				return true, fmt.Sprintf("allowed by role binding %s of role %s", binding.Name, role.Name), nil
+			}
+		}
+	}
+	return false, fmt.Sprintf("user %s can not %s %s in cluster %q namespace %q",
+		attributes.User.Name, attributes.Verb, attributes.Resource, attributes.Cluster, attributes.Namespace), nil
+}
+
+func bindingAppliesTo(binding *v1alpha1.RoleBinding, user *auth.User) bool {
+	for _, subject := range binding.Subjects {
+		switch subject.Kind {
+		case v1alpha1.UserKind:
+			if subject.Name == user.Name {
+				return true
+			}
+		case v1alpha1.GroupKind:
+			for _, group := range user.Groups {
+				if subject.Name == group {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// RuleAllows whether the rule grants the request
+func RuleAllows(rule v1alpha1.PolicyRule, attributes Attributes) bool {
+	return matches(rule.Verbs, attributes.Verb, false) &&
+		matches(rule.Resources, attributes.Resource, false) &&
+		matches(rule.Clusters, attributes.Cluster, true) &&
+		matches(rule.Namespaces, attributes.Namespace, true)
+}
+
+// matches whether the value is listed, an empty list matches everything when emptyMatchesAll is set,
+// an empty value is only matched by the wildcard
+func matches(values []string, value string, emptyMatchesAll bool) bool {
+	if len(values) == 0 {
+		return emptyMatchesAll
+	}
+	for _, item := range values {
+		if item == v1alpha1.PolicyWildcard || (value != "" && item == value) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,81 @@
+package authorization
+
+import (
+	"muti-kube/models/auth"
+	"muti-kube/pkg/api/cluster/v1alpha1"
+	clusterlisters "muti-kube/pkg/client/cluster/listers/cluster/v1alpha1"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+func newTestAuthorizer(t *testing.T) Authorizer {
+	roleIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	bindingIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	objects := []struct {
+		indexer cache.Indexer
+		obj     interface{}
+	}{
+		{roleIndexer, &v1alpha1.Role{
+			ObjectMeta: metav1.ObjectMeta{Name: "payments-deployer"},
+			Rules: []v1alpha1.PolicyRule{{
+				Verbs:      []string{VerbList, VerbCreate},
+				Resources:  []string{ResourceDeployments},
+				Clusters:   []string{"cluster-abc"},
+				Namespaces: []string{"payments"},
+			}},
+		}},
+		{roleIndexer, &v1alpha1.Role{
+			ObjectMeta: metav1.ObjectMeta{Name: "viewer"},
+			Rules: []v1alpha1.PolicyRule{{
+				Verbs:     []string{VerbList, VerbGet},
+				Resources: []string{v1alpha1.PolicyWildcard},
+			}},
+		}},
+		{bindingIndexer, &v1alpha1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: "team-a"},
+			Subjects:   []v1alpha1.Subject{{Kind: v1alpha1.GroupKind, Name: "team-a"}},
+			RoleRef:    v1alpha1.RoleRef{Name: "payments-deployer"},
+		}},
+		{bindingIndexer, &v1alpha1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: "bob"},
+			Subjects:   []v1alpha1.Subject{{Kind: v1alpha1.UserKind, Name: "bob"}},
+			RoleRef:    v1alpha1.RoleRef{Name: "viewer"},
+		}},
+	}
+	for _, item := range objects {
+		if err := item.indexer.Add(item.obj); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return NewRBACAuthorizer(clusterlisters.NewRoleLister(roleIndexer), clusterlisters.NewRoleBindingLister(bindingIndexer))
+}
+
+func TestRBACAuthorizer(t *testing.T) {
+	authorizer := newTestAuthorizer(t)
+	alice := &auth.User{Name: "alice", Groups: []string{"team-a"}}
+	bob := &auth.User{Name: "bob"}
+	cases := []struct {
+		attributes Attributes
+		allowed    bool
+	}{
+		{Attributes{User: alice, Verb: VerbCreate, Resource: ResourceDeployments, Cluster: "cluster-abc", Namespace: "payments"}, true},
+		{Attributes{User: alice, Verb: VerbDelete, Resource: ResourceDeployments, Cluster: "cluster-abc", Namespace: "payments"}, false},
+		{Attributes{User: alice, Verb: VerbCreate, Resource: ResourceDeployments, Cluster: "cluster-abc", Namespace: "default"}, false},
+		{Attributes{User: alice, Verb: VerbCreate, Resource: ResourceDeployments, Cluster: "cluster-xyz", Namespace: "payments"}, false},
+		{Attributes{User: alice, Verb: VerbList, Resource: ResourceClusters}, false},
+		{Attributes{User: bob, Verb: VerbList, Resource: ResourceClusters}, true},
+		{Attributes{User: bob, Verb: VerbGet, Resource: ResourceDeployments, Cluster: "cluster-xyz", Namespace: "default"}, true},
+		{Attributes{User: bob, Verb: VerbDelete, Resource: ResourceClusters, Cluster: "cluster-xyz"}, false},
+	}
+	for i, c := range cases {
+		allowed, reason, err := authorizer.Authorize(c.attributes)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if allowed != c.allowed {
+			t.Errorf("case %d: allowed = %v, want %v (%s)", i, allowed, c.allowed, reason)
+		}
+	}
+}
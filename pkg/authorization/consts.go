@@ -0,0 +1,42 @@
+package authorization
+
+// verbs of the rules
+const (
+	VerbGet    = "get"
+	VerbList   = "list"
+	VerbCreate = "create"
+	VerbUpdate = "update"
+	VerbPatch  = "patch"
+	VerbDelete = "delete"
+)
+
+// resources of the rules
+const (
+	ResourceClusters       = "clusters"
+	ResourceNodes          = "nodes"
+	ResourceNodeMetrics    = "nodes/metrics"
+	ResourceDeployments    = "deployments"
+	ResourceStatefulSets   = "statefulsets"
+	ResourceDaemonSets     = "daemonsets"
+	ResourceJobs           = "jobs"
+	ResourceCronJobs       = "cronjobs"
+	ResourcePods           = "pods"
+	ResourcePodLogs        = "pods/log"
+	ResourcePodExec        = "pods/exec"
+	ResourceServices       = "services"
+	ResourceIngresses      = "ingresses"
+	ResourceEndpointSlices = "endpointslices"
+	ResourceConfigMaps     = "configmaps"
+	ResourceSecrets        = "secrets"
+	ResourceNamespaces     = "namespaces"
+	// ResourcePropagationPolicies the propagation policies of the host cluster, they are not scoped by cluster
+	ResourcePropagationPolicies = "propagationpolicies"
+	// ResourceOverridePolicies the override policies of the host cluster, they are not scoped by cluster
+	ResourceOverridePolicies = "overridepolicies"
+	// ResourceClusterSets the cluster sets of the host cluster, they are not scoped by cluster
+	ResourceClusterSets = "clustersets"
+	// ResourceSecretReveal reading the values of secrets, the other secret apis redact them
+	ResourceSecretReveal = "secrets/reveal"
+	// ResourceNodeDrain evicting all the pods of a node
+	ResourceNodeDrain = "nodes/drain"
+)
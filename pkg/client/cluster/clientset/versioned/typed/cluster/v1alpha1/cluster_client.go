@@ -16,6 +16,11 @@ import (
 type CrdV1alpha1Interface interface {
 	RESTClient() rest.Interface
 	ClustersGetter
+	ClusterSetsGetter
+	OverridePoliciesGetter
+	PropagationPoliciesGetter
+	RolesGetter
+	RoleBindingsGetter
 }
 
 // CrdV1alpha1Client is used to interact with features provided by the crd.muti-kube.com group.
@@ -27,6 +32,26 @@ func (c *CrdV1alpha1Client) Clusters() ClusterInterface {
 	return newClusters(c)
 }
 
+func (c *CrdV1alpha1Client) ClusterSets() ClusterSetInterface {
+	return newClusterSets(c)
+}
+
+func (c *CrdV1alpha1Client) OverridePolicies(namespace string) OverridePolicyInterface {
+	return newOverridePolicies(c, namespace)
+}
+
+func (c *CrdV1alpha1Client) PropagationPolicies(namespace string) PropagationPolicyInterface {
+	return newPropagationPolicies(c, namespace)
+}
+
+func (c *CrdV1alpha1Client) Roles() RoleInterface {
+	return newRoles(c)
+}
+
+func (c *CrdV1alpha1Client) RoleBindings() RoleBindingInterface {
+	return newRoleBindings(c)
+}
+
 // NewForConfig creates a new CrdV1alpha1Client for the given config.
 // NewForConfig is equivalent to NewForConfigAndClient(c, httpClient),
 // where httpClient was generated with rest.HTTPClientFor(c).
@@ -20,6 +20,26 @@ func (c *FakeCrdV1alpha1) Clusters() v1alpha1.ClusterInterface {
 	return &FakeClusters{c}
 }
 
+func (c *FakeCrdV1alpha1) ClusterSets() v1alpha1.ClusterSetInterface {
+	return &FakeClusterSets{c}
+}
+
+func (c *FakeCrdV1alpha1) OverridePolicies(namespace string) v1alpha1.OverridePolicyInterface {
+	return &FakeOverridePolicies{c, namespace}
+}
+
+func (c *FakeCrdV1alpha1) PropagationPolicies(namespace string) v1alpha1.PropagationPolicyInterface {
+	return &FakePropagationPolicies{c, namespace}
+}
+
+func (c *FakeCrdV1alpha1) Roles() v1alpha1.RoleInterface {
+	return &FakeRoles{c}
+}
+
+func (c *FakeCrdV1alpha1) RoleBindings() v1alpha1.RoleBindingInterface {
+	return &FakeRoleBindings{c}
+}
+
 // RESTClient returns a RESTClient that is used to communicate
 // with API server by this client implementation.
 func (c *FakeCrdV1alpha1) RESTClient() rest.Interface {
@@ -6,3 +6,13 @@ Copyright The kube-cloud Authors.
 package v1alpha1
 
 type ClusterExpansion interface{}
+
+type ClusterSetExpansion interface{}
+
+type OverridePolicyExpansion interface{}
+
+type PropagationPolicyExpansion interface{}
+
+type RoleExpansion interface{}
+
+type RoleBindingExpansion interface{}
@@ -0,0 +1,76 @@
+/*
+Copyright The kube-cloud Authors.
+*/
+// Code generated by informer-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	"context"
+	clusterv1alpha1 "muti-kube/pkg/api/cluster/v1alpha1"
+	versioned "muti-kube/pkg/client/cluster/clientset/versioned"
+	internalinterfaces "muti-kube/pkg/client/cluster/informers/externalversions/internalinterfaces"
+	v1alpha1 "muti-kube/pkg/client/cluster/listers/cluster/v1alpha1"
+	time "time"
+
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	watch "k8s.io/apimachinery/pkg/watch"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// ClusterSetInformer provides access to a shared informer and lister for
+// ClusterSets.
+type ClusterSetInformer interface {
+	Informer() cache.SharedIndexInformer
+	Lister() v1alpha1.ClusterSetLister
+}
+
+type clusterSetInformer struct {
+	factory          internalinterfaces.SharedInformerFactory
+	tweakListOptions internalinterfaces.TweakListOptionsFunc
+}
+
+// NewClusterSetInformer constructs a new informer for ClusterSet type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewClusterSetInformer(client versioned.Interface, resyncPeriod time.Duration, indexers cache.Indexers) cache.SharedIndexInformer {
+	return NewFilteredClusterSetInformer(client, resyncPeriod, indexers, nil)
+}
+
+// NewFilteredClusterSetInformer constructs a new informer for ClusterSet type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewFilteredClusterSetInformer(client versioned.Interface, resyncPeriod time.Duration, indexers cache.Indexers, tweakListOptions internalinterfaces.TweakListOptionsFunc) cache.SharedIndexInformer {
+	return cache.NewSharedIndexInformer(
+		&cache.ListWatch{
+			ListFunc: func(options v1.ListOptions) (runtime.Object, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.CrdV1alpha1().ClusterSets().List(context.TODO(), options)
+			},
+			WatchFunc: func(options v1.ListOptions) (watch.Interface, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.CrdV1alpha1().ClusterSets().Watch(context.TODO(), options)
+			},
+		},
+		&clusterv1alpha1.ClusterSet{},
+		resyncPeriod,
+		indexers,
+	)
+}
+
+func (f *clusterSetInformer) defaultInformer(client versioned.Interface, resyncPeriod time.Duration) cache.SharedIndexInformer {
+	return NewFilteredClusterSetInformer(client, resyncPeriod, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}, f.tweakListOptions)
+}
+
+func (f *clusterSetInformer) Informer() cache.SharedIndexInformer {
+	return f.factory.InformerFor(&clusterv1alpha1.ClusterSet{}, f.defaultInformer)
+}
+
+func (f *clusterSetInformer) Lister() v1alpha1.ClusterSetLister {
+	return v1alpha1.NewClusterSetLister(f.Informer().GetIndexer())
+}
@@ -13,6 +13,16 @@ import (
 type Interface interface {
 	// Clusters returns a ClusterInformer.
 	Clusters() ClusterInformer
+	// ClusterSets returns a ClusterSetInformer.
+	ClusterSets() ClusterSetInformer
+	// OverridePolicies returns a OverridePolicyInformer.
+	OverridePolicies() OverridePolicyInformer
+	// PropagationPolicies returns a PropagationPolicyInformer.
+	PropagationPolicies() PropagationPolicyInformer
+	// Roles returns a RoleInformer.
+	Roles() RoleInformer
+	// RoleBindings returns a RoleBindingInformer.
+	RoleBindings() RoleBindingInformer
 }
 
 type version struct {
@@ -30,3 +40,28 @@ func New(f internalinterfaces.SharedInformerFactory, namespace string, tweakList
 func (v *version) Clusters() ClusterInformer {
 	return &clusterInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
 }
+
+// ClusterSets returns a ClusterSetInformer.
+func (v *version) ClusterSets() ClusterSetInformer {
+	return &clusterSetInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
+// OverridePolicies returns a OverridePolicyInformer.
+func (v *version) OverridePolicies() OverridePolicyInformer {
+	return &overridePolicyInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// PropagationPolicies returns a PropagationPolicyInformer.
+func (v *version) PropagationPolicies() PropagationPolicyInformer {
+	return &propagationPolicyInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// Roles returns a RoleInformer.
+func (v *version) Roles() RoleInformer {
+	return &roleInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
+// RoleBindings returns a RoleBindingInformer.
+func (v *version) RoleBindings() RoleBindingInformer {
+	return &roleBindingInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
@@ -8,3 +8,31 @@ package v1alpha1
 // ClusterListerExpansion allows custom methods to be added to
 // ClusterLister.
 type ClusterListerExpansion interface{}
+
+// ClusterSetListerExpansion allows custom methods to be added to
+// ClusterSetLister.
+type ClusterSetListerExpansion interface{}
+
+// OverridePolicyListerExpansion allows custom methods to be added to
+// OverridePolicyLister.
+type OverridePolicyListerExpansion interface{}
+
+// OverridePolicyNamespaceListerExpansion allows custom methods to be added to
+// OverridePolicyNamespaceLister.
+type OverridePolicyNamespaceListerExpansion interface{}
+
+// PropagationPolicyListerExpansion allows custom methods to be added to
+// PropagationPolicyLister.
+type PropagationPolicyListerExpansion interface{}
+
+// PropagationPolicyNamespaceListerExpansion allows custom methods to be added to
+// PropagationPolicyNamespaceLister.
+type PropagationPolicyNamespaceListerExpansion interface{}
+
+// RoleListerExpansion allows custom methods to be added to
+// RoleLister.
+type RoleListerExpansion interface{}
+
+// RoleBindingListerExpansion allows custom methods to be added to
+// RoleBindingLister.
+type RoleBindingListerExpansion interface{}
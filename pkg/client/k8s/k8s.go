@@ -6,6 +6,7 @@ import (
 	promresourcesclient "github.com/prometheus-operator/prometheus-operator/pkg/client/versioned"
 	apiextensionsclient "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
 	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/discovery/cached/memory"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
@@ -18,13 +19,17 @@ type Client interface {
 	Prometheus() promresourcesclient.Interface
 	Metrics() metrics.Interface
 	Config() *rest.Config
+	// Dynamic the client of any resource, including custom resources
+	Dynamic() dynamic.Interface
+	// Discovery the resources served by the cluster, cached in memory until invalidated
+	Discovery() discovery.CachedDiscoveryInterface
 }
 
 type kubernetesClient struct {
 	// kubernetes client interface
 	k8s kubernetes.Interface
 	// discovery client
-	discoveryClient discovery.DiscoveryInterface
+	discoveryClient discovery.CachedDiscoveryInterface
 	// dynamic client
 	dynamicClient dynamic.Interface
 	master        string
@@ -45,7 +50,7 @@ func NewKubernetesClientOrDie(options *KubernetesOptions) (client Client) {
 	config.Burst = options.Burst
 	k := &kubernetesClient{
 		k8s:             kubernetes.NewForConfigOrDie(config),
-		discoveryClient: discovery.NewDiscoveryClientForConfigOrDie(config),
+		discoveryClient: memory.NewMemCacheClient(discovery.NewDiscoveryClientForConfigOrDie(config)),
 		dynamicClient:   dynamic.NewForConfigOrDie(config),
 		master:          config.Host,
 		config:          config,
@@ -96,9 +101,11 @@ func NewKubernetesClientWithConfig(config *rest.Config) (client Client, err erro
 		return
 	}
 
-	if k.discoveryClient, err = discovery.NewDiscoveryClientForConfig(config); err != nil {
+	discoveryClient, err := discovery.NewDiscoveryClientForConfig(config)
+	if err != nil {
 		return
 	}
+	k.discoveryClient = memory.NewMemCacheClient(discoveryClient)
 
 	if k.dynamicClient, err = dynamic.NewForConfig(config); err != nil {
 		return
@@ -133,3 +140,11 @@ func (k *kubernetesClient) Prometheus() promresourcesclient.Interface {
 func (k *kubernetesClient) Metrics() metrics.Interface {
 	return k.metricsClient
 }
+
+func (k *kubernetesClient) Dynamic() dynamic.Interface {
+	return k.dynamicClient
+}
+
+func (k *kubernetesClient) Discovery() discovery.CachedDiscoveryInterface {
+	return k.discoveryClient
+}
@@ -0,0 +1,158 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"muti-kube/pkg/api/cluster/v1alpha1"
+	"muti-kube/pkg/util/encryption"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	corelisters "k8s.io/client-go/listers/core/v1"
+)
+
+const (
+	// KubeConfigSecretKey key of the kubeconfig in the secret
+	KubeConfigSecretKey = "kubeconfig"
+	// EncryptionAnnotation records the encryption of the secret data
+	EncryptionAnnotation = "crd.muti-kube.com/encryption"
+	// ClusterLabel the cluster a secret belongs to
+	ClusterLabel = "crd.muti-kube.com/cluster"
+)
+
+// KubeConfigStore keeps the kubeconfigs of member clusters in secrets, optionally envelope encrypted
+type KubeConfigStore interface {
+	// Get the kubeconfig of the cluster, inline kubeconfigs of not yet migrated clusters are returned as is
+	Get(cluster *v1alpha1.Cluster) ([]byte, error)
+	// Save create or update the secret holding the kubeconfig of the cluster
+	Save(ctx context.Context, clusterName string, kubeConfig []byte) (*v1alpha1.SecretReference, error)
+	// Delete the secret holding the kubeconfig of the cluster
+	Delete(ctx context.Context, clusterName string) error
+}
+
+type kubeConfigStore struct {
+	namespace    string
+	client       kubernetes.Interface
+	secretLister corelisters.SecretLister
+	encryptor    encryption.Encryptor
+}
+
+// NewKubeConfigStore the secrets are stored in the namespace, encryptor may be nil to store plain kubeconfigs
+func NewKubeConfigStore(namespace string, client kubernetes.Interface,
+	secretLister corelisters.SecretLister, encryptor encryption.Encryptor) KubeConfigStore {
+	return &kubeConfigStore{
+		namespace:    namespace,
+		client:       client,
+		secretLister: secretLister,
+		encryptor:    encryptor,
+	}
+}
+
+// KubeConfigSecretName name of the secret holding the kubeconfig of the cluster
+func KubeConfigSecretName(clusterName string) string {
+	return fmt.Sprintf("%s-kubeconfig", clusterName)
+}
+
+func (s *kubeConfigStore) Get(cluster *v1alpha1.Cluster) ([]byte, error) {
+	ref := cluster.Spec.KubeConfigSecretRef
+	if ref == nil {
+		if cluster.Spec.KubeConfig == "" {
+			return nil, fmt.Errorf("cluster %s has no kubeconfig", cluster.Name)
+		}
+		return []byte(cluster.Spec.KubeConfig), nil
+	}
+	secret, err := s.secretLister.Secrets(s.namespace).Get(ref.Name)
+	if apierrors.IsNotFound(err) {
+		// the secret may be created moments ago and not observed by the informer yet
+		secret, err = s.client.CoreV1().Secrets(s.namespace).Get(context.Background(), ref.Name, metav1.GetOptions{})
+	}
+	if err != nil {
+		return nil, err
+	}
+	data, ok := secret.Data[ref.Key]
+	if !ok {
+		return nil, fmt.Errorf("key %s not found in secret %s/%s", ref.Key, s.namespace, ref.Name)
+	}
+	algorithm := secret.Annotations[EncryptionAnnotation]
+	if algorithm == "" {
+		return data, nil
+	}
+	if algorithm != encryption.EnvelopeAlgorithm || s.encryptor == nil {
+		return nil, fmt.Errorf("secret %s/%s is encrypted with %s, no matching key is configured",
+			s.namespace, ref.Name, algorithm)
+	}
+	return s.encryptor.Decrypt(data)
+}
+
+func (s *kubeConfigStore) Save(ctx context.Context, clusterName string, kubeConfig []byte) (*v1alpha1.SecretReference, error) {
+	secret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      KubeConfigSecretName(clusterName),
+			Namespace: s.namespace,
+			Labels: map[string]string{
+				ClusterLabel: clusterName,
+			},
+		},
+		Type: v1.SecretTypeOpaque,
+		Data: map[string][]byte{
+			KubeConfigSecretKey: kubeConfig,
+		},
+	}
+	if s.encryptor != nil {
+		data, err := s.encryptor.Encrypt(kubeConfig)
+		if err != nil {
+			return nil, err
+		}
+		secret.Data[KubeConfigSecretKey] = data
+		secret.Annotations = map[string]string{
+			EncryptionAnnotation: encryption.EnvelopeAlgorithm,
+		}
+	}
+	if err := s.ensureNamespace(ctx); err != nil {
+		return nil, err
+	}
+	secrets := s.client.CoreV1().Secrets(s.namespace)
+	_, err := secrets.Create(ctx, secret, metav1.CreateOptions{})
+	if apierrors.IsAlreadyExists(err) {
+		var existing *v1.Secret
+		existing, err = secrets.Get(ctx, secret.Name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		existing.Labels = secret.Labels
+		existing.Annotations = secret.Annotations
+		existing.Data = secret.Data
+		_, err = secrets.Update(ctx, existing, metav1.UpdateOptions{})
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &v1alpha1.SecretReference{
+		Name: secret.Name,
+		Key:  KubeConfigSecretKey,
+	}, nil
+}
+
+func (s *kubeConfigStore) Delete(ctx context.Context, clusterName string) error {
+	err := s.client.CoreV1().Secrets(s.namespace).Delete(ctx, KubeConfigSecretName(clusterName), metav1.DeleteOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+func (s *kubeConfigStore) ensureNamespace(ctx context.Context) error {
+	_, err := s.client.CoreV1().Namespaces().Get(ctx, s.namespace, metav1.GetOptions{})
+	if !apierrors.IsNotFound(err) {
+		return err
+	}
+	_, err = s.client.CoreV1().Namespaces().Create(ctx, &v1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{Name: s.namespace},
+	}, metav1.CreateOptions{})
+	if apierrors.IsAlreadyExists(err) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,203 @@
+package k8s
+
+import (
+	"context"
+	"muti-kube/pkg/api/cluster/v1alpha1"
+	clusterv1alpha1 "muti-kube/pkg/client/cluster/clientset/versioned/typed/cluster/v1alpha1"
+	clusterinformers "muti-kube/pkg/client/cluster/informers/externalversions/cluster/v1alpha1"
+	clusterlisters "muti-kube/pkg/client/cluster/listers/cluster/v1alpha1"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilcache "k8s.io/apimachinery/pkg/util/cache"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// ClientRegistry caches the clients of member clusters keyed by cluster name
+type ClientRegistry interface {
+	// Get returns the client of the member cluster, building it on first use
+	Get(clusterName string, opts ...ClientOption) (Client, error)
+	// Remove drops the cached client of the member cluster
+	Remove(clusterName string)
+}
+
+// ClientOption customizes the client returned by the registry
+type ClientOption func(*clientOptions)
+
+type clientOptions struct {
+	impersonate *rest.ImpersonationConfig
+}
+
+// WithImpersonation the requests of the returned client are sent on behalf of the user and groups,
+// the kubeconfig of the member cluster must be allowed to impersonate them
+func WithImpersonation(userName string, groups []string) ClientOption {
+	return func(o *clientOptions) {
+		o.impersonate = &rest.ImpersonationConfig{
+			UserName: userName,
+			Groups:   groups,
+		}
+	}
+}
+
+const (
+	// impersonatedClientsPerCluster and impersonatedClientTTL bound the impersonated clients kept per cluster,
+	// every distinct user and groups would otherwise keep a client and its discovery cache forever
+	impersonatedClientsPerCluster = 128
+	impersonatedClientTTL         = 10 * time.Minute
+)
+
+type clientEntry struct {
+	client     Client
+	kubeConfig string
+	// impersonated clients of the cluster keyed by user and groups, the least recently used are evicted,
+	// the clients share the transport of the cluster through the tls cache of client-go
+	impersonated *utilcache.LRUExpireCache
+}
+
+type clientRegistry struct {
+	mu             sync.RWMutex
+	clients        map[string]*clientEntry
+	lister         clusterlisters.ClusterLister
+	clustersClient clusterv1alpha1.ClusterInterface
+	store          KubeConfigStore
+}
+
+// NewClientRegistry creates a ClientRegistry driven by the cluster informer,
+// cached clients are dropped when the kubeconfig of a cluster changes or the cluster is deleted
+func NewClientRegistry(clustersClient clusterv1alpha1.ClusterInterface,
+	informer clusterinformers.ClusterInformer, store KubeConfigStore) ClientRegistry {
+	r := &clientRegistry{
+		clients:        make(map[string]*clientEntry),
+		lister:         informer.Lister(),
+		clustersClient: clustersClient,
+		store:          store,
+	}
+	informer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		UpdateFunc: r.onClusterUpdate,
+		DeleteFunc: r.onClusterDelete,
+	})
+	return r
+}
+
+func (r *clientRegistry) Get(clusterName string, opts ...ClientOption) (Client, error) {
+	options := &clientOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+	entry, err := r.getEntry(clusterName)
+	if err != nil {
+		return nil, err
+	}
+	if options.impersonate == nil {
+		return entry.client, nil
+	}
+	return r.getImpersonated(entry, options.impersonate)
+}
+
+// getEntry returns the cached entry of the cluster, it is rebuilt when the kubeconfig changed
+func (r *clientRegistry) getEntry(clusterName string) (*clientEntry, error) {
+	cluster, err := r.getCluster(clusterName)
+	if err != nil {
+		return nil, err
+	}
+	kubeConfig, err := r.store.Get(cluster)
+	if err != nil {
+		return nil, err
+	}
+	r.mu.RLock()
+	entry, ok := r.clients[clusterName]
+	r.mu.RUnlock()
+	if ok && entry.kubeConfig == string(kubeConfig) {
+		return entry, nil
+	}
+	config, err := clientcmd.RESTConfigFromKubeConfig(kubeConfig)
+	if err != nil {
+		return nil, err
+	}
+	client, err := NewKubernetesClientWithConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	entry = &clientEntry{
+		client:       client,
+		kubeConfig:   string(kubeConfig),
+		impersonated: utilcache.NewLRUExpireCache(impersonatedClientsPerCluster),
+	}
+	r.mu.Lock()
+	r.clients[clusterName] = entry
+	r.mu.Unlock()
+	return entry, nil
+}
+
+// getImpersonated returns the client of the cluster acting as the impersonated user
+func (r *clientRegistry) getImpersonated(entry *clientEntry, impersonate *rest.ImpersonationConfig) (Client, error) {
+	groups := append([]string(nil), impersonate.Groups...)
+	sort.Strings(groups)
+	key := impersonate.UserName + "\x00" + strings.Join(groups, "\x00")
+	if cached, ok := entry.impersonated.Get(key); ok {
+		return cached.(Client), nil
+	}
+	config := rest.CopyConfig(entry.client.Config())
+	config.Impersonate = rest.ImpersonationConfig{
+		UserName: impersonate.UserName,
+		Groups:   groups,
+	}
+	client, err := NewKubernetesClientWithConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	entry.impersonated.Add(key, client, impersonatedClientTTL)
+	return client, nil
+}
+
+func (r *clientRegistry) Remove(clusterName string) {
+	r.mu.Lock()
+	delete(r.clients, clusterName)
+	r.mu.Unlock()
+}
+
+// getCluster reads the cluster from the informer cache and falls back to the
+// api server for clusters created before the cache observed them
+func (r *clientRegistry) getCluster(clusterName string) (*v1alpha1.Cluster, error) {
+	cluster, err := r.lister.Get(clusterName)
+	if err == nil {
+		return cluster, nil
+	}
+	if !apierrors.IsNotFound(err) {
+		return nil, err
+	}
+	return r.clustersClient.Get(context.Background(), clusterName, metav1.GetOptions{})
+}
+
+func (r *clientRegistry) onClusterUpdate(oldObj, newObj interface{}) {
+	oldCluster, ok := oldObj.(*v1alpha1.Cluster)
+	if !ok {
+		return
+	}
+	newCluster, ok := newObj.(*v1alpha1.Cluster)
+	if !ok {
+		return
+	}
+	if oldCluster.Spec.KubeConfig != newCluster.Spec.KubeConfig ||
+		!reflect.DeepEqual(oldCluster.Spec.KubeConfigSecretRef, newCluster.Spec.KubeConfigSecretRef) {
+		r.Remove(newCluster.Name)
+	}
+}
+
+func (r *clientRegistry) onClusterDelete(obj interface{}) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	cluster, ok := obj.(*v1alpha1.Cluster)
+	if !ok {
+		return
+	}
+	r.Remove(cluster.Name)
+}
@@ -0,0 +1,40 @@
+package k8s
+
+import (
+	"fmt"
+	"testing"
+
+	utilcache "k8s.io/apimachinery/pkg/util/cache"
+	"k8s.io/client-go/rest"
+)
+
+func TestGetImpersonatedBounded(t *testing.T) {
+	client, err := NewKubernetesClientWithConfig(&rest.Config{Host: "https://127.0.0.1:6443"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := &clientRegistry{}
+	entry := &clientEntry{
+		client:       client,
+		impersonated: utilcache.NewLRUExpireCache(impersonatedClientsPerCluster),
+	}
+	first, err := r.getImpersonated(entry, &rest.ImpersonationConfig{UserName: "alice", Groups: []string{"b", "a"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, _ := r.getImpersonated(entry, &rest.ImpersonationConfig{UserName: "alice", Groups: []string{"a", "b"}})
+	if first != second {
+		t.Error("the client of the same user and groups is not reused")
+	}
+	if impersonate := first.Config().Impersonate; impersonate.UserName != "alice" || len(impersonate.Groups) != 2 {
+		t.Errorf("unexpected impersonation %+v", impersonate)
+	}
+	for i := 0; i < impersonatedClientsPerCluster+10; i++ {
+		if _, err := r.getImpersonated(entry, &rest.ImpersonationConfig{UserName: fmt.Sprintf("user-%d", i)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if size := len(entry.impersonated.Keys()); size > impersonatedClientsPerCluster {
+		t.Errorf("%d impersonated clients cached, expected at most %d", size, impersonatedClientsPerCluster)
+	}
+}
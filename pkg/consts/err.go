@@ -1,18 +1,191 @@
 package consts
 
+// common api error code
+const (
+	ErrorUnsupportedAction = 10000
+)
+
 // cluster api error code
 const (
-	ERRGETCLUSTERS    = 10001
-	ERRGETCLUSTER     = 10002
-	ERRCREATECLUSTER  = 10003
-	ERRGETNODEMETRICS = 10004
+	ERRGETCLUSTERS      = 10001
+	ERRGETCLUSTER       = 10002
+	ERRCREATECLUSTER    = 10003
+	ERRGETNODEMETRICS   = 10004
+	ERRUPDATECLUSTER    = 10005
+	ERRDELETECLUSTER    = 10006
+	ERRGETNODES         = 10007
+	ERRGETNODE          = 10008
+	ERRCORDONNODE       = 10009
+	ERRDRAINNODE        = 10010
+	ERRGETNODEDRAIN     = 10011
+	ERRUPDATENODELABELS = 10012
+	ERRUPDATENODETAINTS = 10013
 )
 
 // deployment api error code
 const (
-	ErrorGetDeployments   = 10100
-	ErrorCreateDeployment = 10101
+	ErrorGetDeployments          = 10100
+	ErrorCreateDeployment        = 10101
 	ErrorScaleReplicasDeployment = 10102
-	ErrorDeleteDeployment = 10103
-	ErrorGetDeployment = 10104
+	ErrorDeleteDeployment        = 10103
+	ErrorGetDeployment           = 10104
+	ErrorUpdateDeployment        = 10105
+	ErrorPatchDeployment         = 10106
+	ErrorRestartDeployment       = 10107
+	ErrorPauseDeployment         = 10108
+	ErrorGetDeploymentStatus     = 10109
+	ErrorGetDeploymentRevisions  = 10110
+	ErrorRollbackDeployment      = 10111
+)
+
+// statefulset api error code
+const (
+	ErrorGetStatefulSets   = 10300
+	ErrorGetStatefulSet    = 10301
+	ErrorCreateStatefulSet = 10302
+	ErrorUpdateStatefulSet = 10303
+	ErrorDeleteStatefulSet = 10304
+	ErrorScaleStatefulSet  = 10305
+)
+
+// daemonset api error code
+const (
+	ErrorGetDaemonSets   = 10400
+	ErrorGetDaemonSet    = 10401
+	ErrorCreateDaemonSet = 10402
+	ErrorUpdateDaemonSet = 10403
+	ErrorDeleteDaemonSet = 10404
+)
+
+// job api error code
+const (
+	ErrorGetJobs   = 10500
+	ErrorGetJob    = 10501
+	ErrorCreateJob = 10502
+	ErrorDeleteJob = 10503
+)
+
+// cronjob api error code
+const (
+	ErrorGetCronJobs    = 10600
+	ErrorGetCronJob     = 10601
+	ErrorCreateCronJob  = 10602
+	ErrorUpdateCronJob  = 10603
+	ErrorDeleteCronJob  = 10604
+	ErrorSuspendCronJob = 10605
+	ErrorTriggerCronJob = 10606
+)
+
+// pod api error code
+const (
+	ErrorGetPods       = 10700
+	ErrorGetPod        = 10701
+	ErrorDeletePod     = 10702
+	ErrorGetPodLogs    = 10703
+	ErrorGetPodEvents  = 10704
+	ErrorStreamPodLogs = 10705
+	ErrorExecPod       = 10706
+)
+
+// service api error code
+const (
+	ErrorGetServices    = 10800
+	ErrorGetService     = 10801
+	ErrorCreateService  = 10802
+	ErrorUpdateService  = 10803
+	ErrorDeleteService  = 10804
+	ErrorGetServicePods = 10805
+)
+
+// ingress api error code
+const (
+	ErrorGetIngresses  = 10900
+	ErrorGetIngress    = 10901
+	ErrorCreateIngress = 10902
+	ErrorUpdateIngress = 10903
+	ErrorDeleteIngress = 10904
+)
+
+// endpointslice api error code
+const (
+	ErrorGetEndpointSlices = 11000
+	ErrorGetEndpointSlice  = 11001
+)
+
+// configmap api error code
+const (
+	ErrorGetConfigMaps   = 11100
+	ErrorGetConfigMap    = 11101
+	ErrorCreateConfigMap = 11102
+	ErrorUpdateConfigMap = 11103
+	ErrorDeleteConfigMap = 11104
+)
+
+// secret api error code
+const (
+	ErrorGetSecrets   = 11200
+	ErrorGetSecret    = 11201
+	ErrorCreateSecret = 11202
+	ErrorUpdateSecret = 11203
+	ErrorDeleteSecret = 11204
+	ErrorRevealSecret = 11205
+)
+
+// namespace api error code
+const (
+	ErrorGetNamespaces       = 11300
+	ErrorGetNamespace        = 11301
+	ErrorCreateNamespace     = 11302
+	ErrorUpdateNamespace     = 11303
+	ErrorDeleteNamespace     = 11304
+	ErrorGetNamespaceSummary = 11305
+)
+
+// dynamic resource api error code
+const (
+	ErrorGetAPIResources     = 11400
+	ErrorGetResources        = 11401
+	ErrorGetResource         = 11402
+	ErrorCreateResource      = 11403
+	ErrorApplyResource       = 11404
+	ErrorPatchResource       = 11405
+	ErrorDeleteResource      = 11406
+	ErrorInvalidResourcePath = 11407
+)
+
+// propagation policy api error code
+const (
+	ErrorGetPropagationPolicies   = 11500
+	ErrorGetPropagationPolicy     = 11501
+	ErrorCreatePropagationPolicy  = 11502
+	ErrorUpdatePropagationPolicy  = 11503
+	ErrorDeletePropagationPolicy  = 11504
+	ErrorPreviewPropagationPolicy = 11505
+)
+
+// override policy api error code
+const (
+	ErrorGetOverridePolicies  = 11600
+	ErrorGetOverridePolicy    = 11601
+	ErrorCreateOverridePolicy = 11602
+	ErrorUpdateOverridePolicy = 11603
+	ErrorDeleteOverridePolicy = 11604
+)
+
+// cluster set api error code
+const (
+	ErrorGetClusterSets   = 11700
+	ErrorGetClusterSet    = 11701
+	ErrorCreateClusterSet = 11702
+	ErrorUpdateClusterSet = 11703
+	ErrorDeleteClusterSet = 11704
+)
+
+// auth api error code
+const (
+	ErrorUnauthorized = 10200
+	ErrorForbidden    = 10201
+	ErrorAccessReview = 10202
+	// ErrorAuthorizationAttributes the resource of the request could not be resolved to authorize it
+	ErrorAuthorizationAttributes = 10203
 )
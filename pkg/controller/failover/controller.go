@@ -0,0 +1,208 @@
+package failover
+
+import (
+	"context"
+	"fmt"
+	"muti-kube/pkg/api/cluster/v1alpha1"
+	clusterv1alpha1 "muti-kube/pkg/client/cluster/clientset/versioned/typed/cluster/v1alpha1"
+	clusterlisters "muti-kube/pkg/client/cluster/listers/cluster/v1alpha1"
+	baseController "muti-kube/pkg/controller"
+	baseService "muti-kube/pkg/service"
+	"muti-kube/pkg/util/logger"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/client-go/util/workqueue"
+)
+
+const (
+	reasonFailedOver   = "FailedOver"
+	reasonRecovered    = "Recovered"
+	failoverController = "failover-controller"
+)
+
+// Action the decision of the failover controller on a cluster
+type Action string
+
+const (
+	// ActionNone the cluster is left as it is
+	ActionNone Action = ""
+	// ActionTaint the cluster is tainted, the replicas are moved away by the propagation controller
+	ActionTaint Action = "Taint"
+	// ActionUntaint the cluster recovered, the taint is removed
+	ActionUntaint Action = "Untaint"
+)
+
+type Controller interface {
+	// Run fail over the clusters until the context is done
+	Run(ctx context.Context)
+}
+
+// controller taints the clusters not ready for longer than the grace period with the NoExecute
+// ClusterNotReadyTaint and removes the taint once they are ready, the propagation controller
+// schedules no replicas to tainted clusters
+type controller struct {
+	clusterLister  clusterlisters.ClusterLister
+	clustersClient clusterv1alpha1.ClusterInterface
+	queue          workqueue.RateLimitingInterface
+	recorder       record.EventRecorder
+	options        *Options
+}
+
+func NewFailoverController(options *Options) (Controller, error) {
+	base, err := baseService.NewBase()
+	if err != nil {
+		return nil, err
+	}
+	c := &controller{
+		clusterLister:  base.GetClusterLister(),
+		clustersClient: base.GetClusterClient(),
+		queue:          workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "failover"),
+		recorder:       baseController.NewEventRecorder(base.GetHostClient(), failoverController),
+		options:        options,
+	}
+	base.GetClusterInformer().Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    c.enqueue,
+		UpdateFunc: func(oldObj, newObj interface{}) { c.enqueue(newObj) },
+	})
+	return c, nil
+}
+
+func (c *controller) Run(ctx context.Context) {
+	defer c.queue.ShutDown()
+	go wait.UntilWithContext(ctx, c.worker, time.Second)
+	<-ctx.Done()
+}
+
+func (c *controller) enqueue(obj interface{}) {
+	key, err := cache.MetaNamespaceKeyFunc(obj)
+	if err != nil {
+		logger.Warn(err)
+		return
+	}
+	c.queue.Add(key)
+}
+
+func (c *controller) worker(ctx context.Context) {
+	for c.processNextItem(ctx) {
+	}
+}
+
+func (c *controller) processNextItem(ctx context.Context) bool {
+	item, quit := c.queue.Get()
+	if quit {
+		return false
+	}
+	defer c.queue.Done(item)
+	key := item.(string)
+	if err := c.sync(ctx, key); err != nil {
+		logger.Warn(fmt.Sprintf("failover: cluster %s sync failed ", key), err)
+		c.queue.AddRateLimited(key)
+		return true
+	}
+	c.queue.Forget(key)
+	return true
+}
+
+// Decide the action on the cluster at the time, and the delay after which a cluster not ready
+// within the grace period is decided again. Clusters never probed are left alone
+func Decide(cluster *v1alpha1.Cluster, gracePeriod time.Duration, now time.Time) (Action, time.Duration) {
+	tainted := hasNotReadyTaint(cluster)
+	ready := meta.FindStatusCondition(cluster.Status.Conditions, v1alpha1.ClusterReady)
+	switch {
+	case ready == nil:
+		return ActionNone, 0
+	case ready.Status == metav1.ConditionTrue:
+		if tainted {
+			return ActionUntaint, 0
+		}
+		return ActionNone, 0
+	case tainted:
+		return ActionNone, 0
+	}
+	notReadyFor := now.Sub(ready.LastTransitionTime.Time)
+	if notReadyFor >= gracePeriod {
+		return ActionTaint, 0
+	}
+	return ActionNone, gracePeriod - notReadyFor
+}
+
+func hasNotReadyTaint(cluster *v1alpha1.Cluster) bool {
+	for _, taint := range cluster.Spec.Taints {
+		if taint.Key == v1alpha1.ClusterNotReadyTaint {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *controller) sync(ctx context.Context, key string) error {
+	cluster, err := c.clusterLister.Get(key)
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	action, after := Decide(cluster, c.options.GracePeriod, now)
+	switch action {
+	case ActionTaint:
+		if err := c.updateTaints(ctx, key, func(taints []v1.Taint) []v1.Taint {
+			for _, taint := range taints {
+				if taint.Key == v1alpha1.ClusterNotReadyTaint {
+					return taints
+				}
+			}
+			return append(taints, v1.Taint{
+				Key:       v1alpha1.ClusterNotReadyTaint,
+				Effect:    v1.TaintEffectNoExecute,
+				TimeAdded: &metav1.Time{Time: now},
+			})
+		}); err != nil {
+			return err
+		}
+		c.recorder.Eventf(cluster, v1.EventTypeWarning, reasonFailedOver,
+			"cluster not ready for longer than the grace period %s, its replicas are moved to the ready clusters",
+			c.options.GracePeriod)
+	case ActionUntaint:
+		if err := c.updateTaints(ctx, key, func(taints []v1.Taint) []v1.Taint {
+			kept := make([]v1.Taint, 0, len(taints))
+			for _, taint := range taints {
+				if taint.Key != v1alpha1.ClusterNotReadyTaint {
+					kept = append(kept, taint)
+				}
+			}
+			return kept
+		}); err != nil {
+			return err
+		}
+		c.recorder.Event(cluster, v1.EventTypeNormal, reasonRecovered,
+			"cluster is ready again, replicas are scheduled to it by the policies migrating back")
+	default:
+		if after > 0 {
+			c.queue.AddAfter(key, after)
+		}
+	}
+	return nil
+}
+
+// updateTaints replace the taints of the latest version of the cluster, the status is left to the periodic probe
+func (c *controller) updateTaints(ctx context.Context, name string, update func([]v1.Taint) []v1.Taint) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		current, err := c.clustersClient.Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		current.Spec.Taints = update(current.Spec.Taints)
+		_, err = c.clustersClient.Update(ctx, current, metav1.UpdateOptions{})
+		return err
+	})
+}
@@ -0,0 +1,46 @@
+package failover
+
+import (
+	"muti-kube/pkg/api/cluster/v1alpha1"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestDecide(t *testing.T) {
+	now := time.Now()
+	newCluster := func(status metav1.ConditionStatus, notReadyFor time.Duration, tainted bool) *v1alpha1.Cluster {
+		cluster := &v1alpha1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "beijing"}}
+		if status != "" {
+			cluster.Status.Conditions = []metav1.Condition{{
+				Type:               v1alpha1.ClusterReady,
+				Status:             status,
+				LastTransitionTime: metav1.NewTime(now.Add(-notReadyFor)),
+			}}
+		}
+		if tainted {
+			cluster.Spec.Taints = []v1.Taint{{Key: v1alpha1.ClusterNotReadyTaint, Effect: v1.TaintEffectNoExecute}}
+		}
+		return cluster
+	}
+	cases := []struct {
+		cluster *v1alpha1.Cluster
+		action  Action
+		after   time.Duration
+	}{
+		{newCluster("", 0, false), ActionNone, 0},
+		{newCluster(metav1.ConditionTrue, time.Hour, false), ActionNone, 0},
+		{newCluster(metav1.ConditionTrue, time.Minute, true), ActionUntaint, 0},
+		{newCluster(metav1.ConditionFalse, 2*time.Minute, false), ActionNone, 3 * time.Minute},
+		{newCluster(metav1.ConditionFalse, 5*time.Minute, false), ActionTaint, 0},
+		{newCluster(metav1.ConditionFalse, time.Hour, true), ActionNone, 0},
+	}
+	for i, c := range cases {
+		action, after := Decide(c.cluster, 5*time.Minute, now)
+		if action != c.action || after != c.after {
+			t.Errorf("case %d: decided %q after %s, expected %q after %s", i, action, after, c.action, c.after)
+		}
+	}
+}
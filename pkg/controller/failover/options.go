@@ -0,0 +1,32 @@
+package failover
+
+import (
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+type Options struct {
+	// GracePeriod a cluster not ready for longer than the period is failed over, its replicas are moved
+	// to the ready clusters selected by the same policies
+	GracePeriod time.Duration `json:"gracePeriod" yaml:"gracePeriod"`
+}
+
+func NewFailoverOptions() *Options {
+	return &Options{
+		GracePeriod: 5 * time.Minute,
+	}
+}
+
+// NewFailoverOptionsFromConfig read the options from settings.failover of the config file,
+// missing values keep their defaults
+func NewFailoverOptionsFromConfig() *Options {
+	options := NewFailoverOptions()
+	if viper.IsSet("settings.failover.gracePeriod") {
+		options.GracePeriod = viper.GetDuration("settings.failover.gracePeriod")
+	}
+	if options.GracePeriod < time.Minute {
+		options.GracePeriod = time.Minute
+	}
+	return options
+}
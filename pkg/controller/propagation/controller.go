@@ -0,0 +1,641 @@
+package propagation
+
+import (
+	"context"
+	"fmt"
+	"muti-kube/pkg/api/cluster/v1alpha1"
+	"muti-kube/pkg/authorization"
+	clusterlisters "muti-kube/pkg/client/cluster/listers/cluster/v1alpha1"
+	baseController "muti-kube/pkg/controller"
+	baseService "muti-kube/pkg/service"
+	clusterService "muti-kube/pkg/service/cluster"
+	policyService "muti-kube/pkg/service/policy"
+	"muti-kube/pkg/util/logger"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/client-go/util/workqueue"
+)
+
+const (
+	reasonSynced          = "Synced"
+	reasonSyncFailed      = "SyncFailed"
+	reasonRescheduled     = "ReplicasRescheduled"
+	reasonScheduleFailed  = "ScheduleFailed"
+	propagationController = "propagation-controller"
+)
+
+type Controller interface {
+	// Run sync the propagation policies until the context is done
+	Run(ctx context.Context)
+}
+
+// controller propagates the template deployment of every PropagationPolicy to the clusters selected
+// by its placement with the OverridePolicies of the cluster applied, removes it from the clusters
+// no longer selected and reports the result on the policy
+type controller struct {
+	bs             baseService.BaseInterface
+	cs             clusterService.Interface
+	policyLister   clusterlisters.PropagationPolicyLister
+	overrideLister clusterlisters.OverridePolicyLister
+	queue          workqueue.RateLimitingInterface
+	recorder       record.EventRecorder
+	// authorizer checks the users recorded on the policies, nil without authentication
+	authorizer authorization.Authorizer
+	options    *Options
+}
+
+func NewPropagationController(options *Options) (Controller, error) {
+	cluster, err := clusterService.NewClusterService()
+	if err != nil {
+		return nil, err
+	}
+	base, err := baseService.NewBase()
+	if err != nil {
+		return nil, err
+	}
+	authorizer, err := policyService.NewAuthorizer(base)
+	if err != nil {
+		return nil, err
+	}
+	c := &controller{
+		bs:             base,
+		cs:             cluster,
+		policyLister:   base.GetPropagationPolicyInformer().Lister(),
+		overrideLister: base.GetOverridePolicyInformer().Lister(),
+		queue:          workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "propagation"),
+		recorder:       baseController.NewEventRecorder(base.GetHostClient(), propagationController),
+		authorizer:     authorizer,
+		options:        options,
+	}
+	base.GetPropagationPolicyInformer().Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    c.enqueue,
+		UpdateFunc: func(oldObj, newObj interface{}) { c.enqueue(newObj) },
+		DeleteFunc: c.enqueue,
+	})
+	// both the policies overridden before and after the change are synced again
+	base.GetOverridePolicyInformer().Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: c.enqueueOverridden,
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			c.enqueueOverridden(oldObj)
+			c.enqueueOverridden(newObj)
+		},
+		DeleteFunc: c.enqueueOverridden,
+	})
+	// the placement of every policy is evaluated again when clusters come, go or change their labels
+	base.GetClusterInformer().Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) { c.enqueueAll() },
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			oldCluster, ok := oldObj.(*v1alpha1.Cluster)
+			newCluster, ok2 := newObj.(*v1alpha1.Cluster)
+			// the replicas are scheduled again when a cluster turns ready or not ready and when it fails over
+			if ok && ok2 && labels.Equals(oldCluster.Labels, newCluster.Labels) &&
+				policyService.ClusterReady(oldCluster) == policyService.ClusterReady(newCluster) &&
+				policyService.ClusterFailedOver(oldCluster) == policyService.ClusterFailedOver(newCluster) {
+				return
+			}
+			c.enqueueAll()
+		},
+		DeleteFunc: func(obj interface{}) { c.enqueueAll() },
+	})
+	return c, nil
+}
+
+func (c *controller) Run(ctx context.Context) {
+	defer c.queue.ShutDown()
+	for i := 0; i < c.options.Workers; i++ {
+		go wait.UntilWithContext(ctx, c.worker, time.Second)
+	}
+	wait.UntilWithContext(ctx, func(ctx context.Context) { c.enqueueAll() }, c.options.ResyncPeriod)
+}
+
+func (c *controller) enqueue(obj interface{}) {
+	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+	if err != nil {
+		logger.Warn(err)
+		return
+	}
+	c.queue.Add(key)
+}
+
+func (c *controller) enqueueAll() {
+	policies, err := c.policyLister.List(labels.Everything())
+	if err != nil {
+		logger.Warn(err)
+		return
+	}
+	for _, policy := range policies {
+		c.enqueue(policy)
+	}
+}
+
+// enqueueOverridden enqueue the propagation policies the override policy applies to
+func (c *controller) enqueueOverridden(obj interface{}) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	override, ok := obj.(*v1alpha1.OverridePolicy)
+	if !ok {
+		return
+	}
+	policies, err := c.policyLister.PropagationPolicies(override.Namespace).List(labels.Everything())
+	if err != nil {
+		logger.Warn(err)
+		return
+	}
+	for _, policy := range policies {
+		if len(policyService.OverridesFor(policy, []*v1alpha1.OverridePolicy{override})) > 0 {
+			c.enqueue(policy)
+		}
+	}
+}
+
+func (c *controller) worker(ctx context.Context) {
+	for c.processNextItem(ctx) {
+	}
+}
+
+func (c *controller) processNextItem(ctx context.Context) bool {
+	item, quit := c.queue.Get()
+	if quit {
+		return false
+	}
+	defer c.queue.Done(item)
+	key := item.(string)
+	if err := c.sync(ctx, key); err != nil {
+		logger.Warn(fmt.Sprintf("propagation policy: %s sync failed ", key), err)
+		c.queue.AddRateLimited(key)
+		return true
+	}
+	c.queue.Forget(key)
+	return true
+}
+
+// sync propagate the deployment of the policy to the selected clusters and remove it from the others
+func (c *controller) sync(ctx context.Context, key string) error {
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return err
+	}
+	cached, err := c.policyLister.PropagationPolicies(namespace).Get(name)
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	policy := cached.DeepCopy()
+	if policy.DeletionTimestamp != nil {
+		return c.cleanup(ctx, policy)
+	}
+	if policy, err = c.ensureFinalizer(ctx, policy); err != nil {
+		return err
+	}
+	clusters, err := c.bs.GetClusterLister().List(labels.Everything())
+	if err != nil {
+		return err
+	}
+	selected, err := policyService.MatchClusters(policy.Spec.Placement.ClusterAffinity, clusters)
+	if err != nil {
+		return err
+	}
+	overrides, err := c.overrideLister.OverridePolicies(namespace).List(labels.Everything())
+	if err != nil {
+		return err
+	}
+	clustersByName := make(map[string]*v1alpha1.Cluster, len(clusters))
+	selectedClusters := make([]*v1alpha1.Cluster, 0, len(selected))
+	for _, cluster := range clusters {
+		clustersByName[cluster.Name] = cluster
+	}
+	for _, cluster := range selected {
+		selectedClusters = append(selectedClusters, clustersByName[cluster])
+	}
+	schedule, scheduleErr := policyService.ScheduleReplicas(policy, selectedClusters)
+	if scheduleErr != nil {
+		c.recorder.Eventf(policy, v1.EventTypeWarning, reasonScheduleFailed, "schedule replicas: %v", scheduleErr)
+	} else if changes := scheduleChanges(policy, schedule); changes != "" {
+		c.recorder.Eventf(policy, v1.EventTypeNormal, reasonRescheduled, "replicas scheduled: %s", changes)
+	}
+	statuses := c.forEachCluster(selected, func(cluster string) v1alpha1.ClusterPropagationStatus {
+		if scheduleErr != nil {
+			return v1alpha1.ClusterPropagationStatus{
+				Cluster:    cluster,
+				Deployment: propagatedDeployment(policy, cluster),
+				Phase:      v1alpha1.PropagationFailed,
+				Message:    fmt.Sprintf("schedule replicas: %v", scheduleErr),
+			}
+		}
+		return c.syncCluster(ctx, clustersByName[cluster], policy, overrides, schedule.ReplicasOf())
+	})
+	statuses = append(statuses, c.forEachCluster(staleClusters(policy, selected, clusters),
+		func(cluster string) v1alpha1.ClusterPropagationStatus {
+			return c.removeFromCluster(ctx, cluster, policy, propagatedDeployment(policy, cluster))
+		})...)
+	// clusters the deployment was removed from are no longer reported
+	reported := make([]v1alpha1.ClusterPropagationStatus, 0, len(statuses))
+	for _, status := range statuses {
+		if status.Phase != "" {
+			reported = append(reported, status)
+		}
+	}
+	unavailable := policy.Status.UnavailableClusters
+	if scheduleErr == nil {
+		unavailable = nil
+		if schedule != nil && len(schedule.UnavailableClusters) > 0 {
+			unavailable = schedule.UnavailableClusters
+		}
+	}
+	return c.updateStatus(ctx, policy, reported, unavailable)
+}
+
+// scheduleChanges describe the replicas moved by the schedule from the schedule recorded in the status,
+// empty when nothing moved
+func scheduleChanges(policy *v1alpha1.PropagationPolicy, schedule *policyService.ReplicaSchedule) string {
+	if schedule == nil {
+		return ""
+	}
+	recorded := make(map[string]int32, len(policy.Status.Clusters))
+	for _, status := range policy.Status.Clusters {
+		if status.ScheduledReplicas != nil {
+			recorded[status.Cluster] = *status.ScheduledReplicas
+		}
+	}
+	unavailable := make(map[string]struct{}, len(schedule.UnavailableClusters))
+	for _, cluster := range schedule.UnavailableClusters {
+		unavailable[cluster] = struct{}{}
+	}
+	clusters := make([]string, 0, len(schedule.Replicas))
+	for cluster := range schedule.Replicas {
+		clusters = append(clusters, cluster)
+	}
+	sort.Strings(clusters)
+	changes := make([]string, 0)
+	for _, cluster := range clusters {
+		previous, replicas := recorded[cluster], schedule.Replicas[cluster]
+		if _, ok := recorded[cluster]; ok && previous == replicas {
+			continue
+		}
+		change := fmt.Sprintf("%s %d -> %d", cluster, previous, replicas)
+		if _, ok := unavailable[cluster]; ok {
+			change += " (unavailable)"
+		}
+		changes = append(changes, change)
+	}
+	return strings.Join(changes, ", ")
+}
+
+// staleClusters the clusters still holding the deployment of the policy but no longer selected,
+// clusters removed from muti-kube can not be cleaned up and are forgotten
+func staleClusters(policy *v1alpha1.PropagationPolicy, selected []string, clusters []*v1alpha1.Cluster) []string {
+	existing := make(map[string]struct{}, len(clusters))
+	for _, cluster := range clusters {
+		existing[cluster.Name] = struct{}{}
+	}
+	selectedSet := make(map[string]struct{}, len(selected))
+	for _, cluster := range selected {
+		selectedSet[cluster] = struct{}{}
+	}
+	stale := make([]string, 0)
+	for _, status := range policy.Status.Clusters {
+		_, isSelected := selectedSet[status.Cluster]
+		_, exists := existing[status.Cluster]
+		if !isSelected && exists {
+			stale = append(stale, status.Cluster)
+		}
+	}
+	return stale
+}
+
+// propagatedDeployment the name of the deployment propagated to the cluster recorded in the status,
+// the name of the template for the statuses recorded before the names were
+func propagatedDeployment(policy *v1alpha1.PropagationPolicy, cluster string) string {
+	for _, status := range policy.Status.Clusters {
+		if status.Cluster == cluster && status.Deployment != "" {
+			return status.Deployment
+		}
+	}
+	return policyService.NewDeployment(policy).Name
+}
+
+// forEachCluster run fn on the clusters with bounded concurrency, the statuses are sorted by cluster
+func (c *controller) forEachCluster(clusters []string,
+	fn func(cluster string) v1alpha1.ClusterPropagationStatus) []v1alpha1.ClusterPropagationStatus {
+	statuses := make([]v1alpha1.ClusterPropagationStatus, len(clusters))
+	semaphore := make(chan struct{}, c.options.Concurrency)
+	var wg sync.WaitGroup
+	for i, cluster := range clusters {
+		wg.Add(1)
+		semaphore <- struct{}{}
+		go func(i int, cluster string) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+			statuses[i] = fn(cluster)
+		}(i, cluster)
+	}
+	wg.Wait()
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Cluster < statuses[j].Cluster })
+	return statuses
+}
+
+// syncCluster create or update the deployment rendered with the scheduled replicas and the overrides of the
+// cluster in the cluster, deployments not propagated by the policy are left untouched. After the template is
+// renamed, the deployment of the previous name is removed once the renamed one is applied. The deployment is
+// only written while the user recorded on the policy may write it, with the client impersonating the user
+// when impersonation is enabled, and the users recorded on the overrides changing it may write it too
+func (c *controller) syncCluster(ctx context.Context, cluster *v1alpha1.Cluster, policy *v1alpha1.PropagationPolicy,
+	overrides []*v1alpha1.OverridePolicy, schedule map[string]int32) v1alpha1.ClusterPropagationStatus {
+	ctx, cancel := context.WithTimeout(ctx, c.options.Timeout)
+	defer cancel()
+	previous := propagatedDeployment(policy, cluster.Name)
+	status := v1alpha1.ClusterPropagationStatus{Cluster: cluster.Name, Deployment: previous}
+	if schedule != nil {
+		scheduled := schedule[cluster.Name]
+		status.ScheduledReplicas = &scheduled
+	}
+	if err := policyService.AuthorizePropagation(c.authorizer, policy, cluster.Name); err != nil {
+		status.Phase = v1alpha1.PropagationFailed
+		status.Message = err.Error()
+		return status
+	}
+	if err := policyService.AuthorizeOverrides(c.authorizer, policy, overrides, cluster); err != nil {
+		status.Phase = v1alpha1.PropagationFailed
+		status.Message = err.Error()
+		return status
+	}
+	desired, err := policyService.RenderDeployment(policy, overrides, cluster, schedule)
+	if err != nil {
+		status.Phase = v1alpha1.PropagationFailed
+		status.Message = err.Error()
+		return status
+	}
+	deployment, err := c.applyDeployment(ctx, cluster.Name, policy, desired)
+	if err != nil {
+		status.Phase = v1alpha1.PropagationFailed
+		status.Message = err.Error()
+		return status
+	}
+	if previous != desired.Name {
+		if err := c.deleteDeployment(ctx, cluster.Name, policy, previous); err != nil {
+			status.Phase = v1alpha1.PropagationFailed
+			status.Message = fmt.Sprintf("remove deployment %s of the previous template name: %v", previous, err)
+			return status
+		}
+	}
+	status.Deployment = desired.Name
+	status.Phase = v1alpha1.PropagationSynced
+	status.Replicas = deployment.Status.Replicas
+	status.ReadyReplicas = deployment.Status.ReadyReplicas
+	status.AvailableReplicas = deployment.Status.AvailableReplicas
+	return status
+}
+
+func (c *controller) applyDeployment(ctx context.Context, cluster string, policy *v1alpha1.PropagationPolicy,
+	desired *appsv1.Deployment) (*appsv1.Deployment, error) {
+	user, err := policyService.PolicyUser(policy)
+	if err != nil {
+		return nil, err
+	}
+	clientSet, err := c.cs.GetKubernetesClientSet(cluster, baseService.WithUser(user))
+	if err != nil {
+		return nil, err
+	}
+	deployments := clientSet.Kubernetes().AppsV1().Deployments(desired.Namespace)
+	current, err := deployments.Get(ctx, desired.Name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		namespaces := clientSet.Kubernetes().CoreV1().Namespaces()
+		_, err = namespaces.Create(ctx, &v1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{Name: desired.Namespace},
+		}, metav1.CreateOptions{})
+		// an impersonated user without access to the namespaces of the cluster is denied even when the
+		// namespace exists, creating the deployment tells whether it is missing
+		if err != nil && !apierrors.IsAlreadyExists(err) && !apierrors.IsForbidden(err) {
+			return nil, err
+		}
+		return deployments.Create(ctx, desired.DeepCopy(), metav1.CreateOptions{})
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !policyService.IsPropagatedBy(current, policy) {
+		return nil, fmt.Errorf("deployment %s/%s exists and is not propagated by the policy", desired.Namespace, desired.Name)
+	}
+	if UpToDate(current, desired) {
+		return current, nil
+	}
+	var deployment *appsv1.Deployment
+	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		current, err := deployments.Get(ctx, desired.Name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		MergeDeployment(current, desired)
+		deployment, err = deployments.Update(ctx, current, metav1.UpdateOptions{})
+		return err
+	})
+	return deployment, err
+}
+
+// UpToDate whether the deployment of the member cluster carries the desired labels, annotations and spec,
+// fields left empty in the desired spec are defaulted by the cluster and ignored
+func UpToDate(current *appsv1.Deployment, desired *appsv1.Deployment) bool {
+	for key, value := range desired.Labels {
+		if current.Labels[key] != value {
+			return false
+		}
+	}
+	for key, value := range desired.Annotations {
+		if current.Annotations[key] != value {
+			return false
+		}
+	}
+	return apiequality.Semantic.DeepDerivative(desired.Spec, current.Spec)
+}
+
+// MergeDeployment replace the spec of the deployment of the member cluster with the desired one,
+// the labels and annotations added by the cluster are kept
+func MergeDeployment(current *appsv1.Deployment, desired *appsv1.Deployment) {
+	if current.Labels == nil {
+		current.Labels = make(map[string]string, len(desired.Labels))
+	}
+	for key, value := range desired.Labels {
+		current.Labels[key] = value
+	}
+	if current.Annotations == nil && len(desired.Annotations) > 0 {
+		current.Annotations = make(map[string]string, len(desired.Annotations))
+	}
+	for key, value := range desired.Annotations {
+		current.Annotations[key] = value
+	}
+	current.Spec = *desired.Spec.DeepCopy()
+}
+
+// removeFromCluster delete the deployment of the policy from the cluster, the returned status has
+// no phase once the deployment is gone
+func (c *controller) removeFromCluster(ctx context.Context, cluster string, policy *v1alpha1.PropagationPolicy,
+	name string) v1alpha1.ClusterPropagationStatus {
+	ctx, cancel := context.WithTimeout(ctx, c.options.Timeout)
+	defer cancel()
+	status := v1alpha1.ClusterPropagationStatus{Cluster: cluster, Deployment: name}
+	if err := c.deleteDeployment(ctx, cluster, policy, name); err != nil {
+		status.Phase = v1alpha1.PropagationFailed
+		status.Message = fmt.Sprintf("remove deployment: %v", err)
+	}
+	return status
+}
+
+// deleteDeployment the deployments are removed with the client of muti-kube, only the deployments labelled
+// as propagated by the policy are removed and the policy must be released even once its user lost access
+func (c *controller) deleteDeployment(ctx context.Context, cluster string, policy *v1alpha1.PropagationPolicy,
+	name string) error {
+	clientSet, err := c.cs.GetKubernetesClientSet(cluster)
+	if err != nil {
+		return err
+	}
+	deployments := clientSet.Kubernetes().AppsV1().Deployments(policy.Namespace)
+	current, err := deployments.Get(ctx, name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if !policyService.IsPropagatedBy(current, policy) {
+		return nil
+	}
+	err = deployments.Delete(ctx, name, metav1.DeleteOptions{
+		Preconditions: &metav1.Preconditions{UID: &current.UID},
+	})
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+// cleanup remove the deployment from every cluster it was propagated to, then release the policy
+func (c *controller) cleanup(ctx context.Context, policy *v1alpha1.PropagationPolicy) error {
+	if !hasFinalizer(policy) {
+		return nil
+	}
+	clusters, err := c.bs.GetClusterLister().List(labels.Everything())
+	if err != nil {
+		return err
+	}
+	statuses := c.forEachCluster(staleClusters(policy, nil, clusters),
+		func(cluster string) v1alpha1.ClusterPropagationStatus {
+			return c.removeFromCluster(ctx, cluster, policy, propagatedDeployment(policy, cluster))
+		})
+	failed := make([]string, 0)
+	for _, status := range statuses {
+		if status.Phase == v1alpha1.PropagationFailed {
+			failed = append(failed, fmt.Sprintf("%s: %s", status.Cluster, status.Message))
+		}
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("remove deployment of deleted policy: %s", strings.Join(failed, "; "))
+	}
+	policies := c.bs.GetCrdClient().PropagationPolicies(policy.Namespace)
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		current, err := policies.Get(ctx, policy.Name, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		finalizers := make([]string, 0, len(current.Finalizers))
+		for _, finalizer := range current.Finalizers {
+			if finalizer != v1alpha1.PropagationPolicyFinalizer {
+				finalizers = append(finalizers, finalizer)
+			}
+		}
+		current.Finalizers = finalizers
+		_, err = policies.Update(ctx, current, metav1.UpdateOptions{})
+		return err
+	})
+}
+
+func hasFinalizer(policy *v1alpha1.PropagationPolicy) bool {
+	for _, finalizer := range policy.Finalizers {
+		if finalizer == v1alpha1.PropagationPolicyFinalizer {
+			return true
+		}
+	}
+	return false
+}
+
+// ensureFinalizer add the finalizer to policies created without the api of muti-kube
+func (c *controller) ensureFinalizer(ctx context.Context,
+	policy *v1alpha1.PropagationPolicy) (*v1alpha1.PropagationPolicy, error) {
+	if hasFinalizer(policy) {
+		return policy, nil
+	}
+	policy.Finalizers = append(policy.Finalizers, v1alpha1.PropagationPolicyFinalizer)
+	return c.bs.GetCrdClient().PropagationPolicies(policy.Namespace).Update(ctx, policy, metav1.UpdateOptions{})
+}
+
+// updateStatus write the statuses of the clusters and the Synced condition, the status is left alone when
+// nothing changed but the sync time. Failed clusters are returned as an error so the policy is retried
+func (c *controller) updateStatus(ctx context.Context, policy *v1alpha1.PropagationPolicy,
+	statuses []v1alpha1.ClusterPropagationStatus, unavailable []string) error {
+	now := metav1.Now()
+	previous := make(map[string]v1alpha1.ClusterPropagationStatus, len(policy.Status.Clusters))
+	for _, status := range policy.Status.Clusters {
+		previous[status.Cluster] = status
+	}
+	failed := make([]string, 0)
+	for i := range statuses {
+		statuses[i].LastSyncTime = &now
+		if last, ok := previous[statuses[i].Cluster]; ok {
+			lastSyncTime := last.LastSyncTime
+			last.LastSyncTime = &now
+			if apiequality.Semantic.DeepEqual(last, statuses[i]) {
+				statuses[i].LastSyncTime = lastSyncTime
+			}
+		}
+		if statuses[i].Phase == v1alpha1.PropagationFailed {
+			failed = append(failed, fmt.Sprintf("%s: %s", statuses[i].Cluster, statuses[i].Message))
+		}
+	}
+	status := policy.Status.DeepCopy()
+	status.ObservedGeneration = policy.Generation
+	status.Clusters = statuses
+	status.UnavailableClusters = unavailable
+	condition := metav1.Condition{
+		Type:               v1alpha1.PropagationSynced,
+		Status:             metav1.ConditionTrue,
+		Reason:             reasonSynced,
+		Message:            fmt.Sprintf("deployment propagated to %d clusters", len(statuses)),
+		ObservedGeneration: policy.Generation,
+	}
+	if len(failed) > 0 {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = reasonSyncFailed
+		condition.Message = strings.Join(failed, "; ")
+	}
+	meta.SetStatusCondition(&status.Conditions, condition)
+	if !apiequality.Semantic.DeepEqual(*status, policy.Status) {
+		policy.Status = *status
+		if _, err := c.bs.GetCrdClient().PropagationPolicies(policy.Namespace).
+			UpdateStatus(ctx, policy, metav1.UpdateOptions{}); err != nil {
+			return err
+		}
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("sync failed in clusters %s", strings.Join(failed, "; "))
+	}
+	return nil
+}
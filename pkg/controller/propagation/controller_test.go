@@ -0,0 +1,35 @@
+package propagation
+
+import (
+	"muti-kube/pkg/api/cluster/v1alpha1"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestPropagatedDeployment(t *testing.T) {
+	policy := &v1alpha1.PropagationPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "nginx", Namespace: "default"},
+		Spec: v1alpha1.PropagationPolicySpec{
+			Template: v1alpha1.DeploymentTemplate{Metadata: v1alpha1.TemplateMeta{Name: "nginx-v2"}},
+		},
+		Status: v1alpha1.PropagationPolicyStatus{
+			Clusters: []v1alpha1.ClusterPropagationStatus{
+				{Cluster: "cluster-a", Deployment: "nginx-v1"},
+				{Cluster: "cluster-b"},
+			},
+		},
+	}
+	cases := map[string]string{
+		// the recorded name is removed after the rename
+		"cluster-a": "nginx-v1",
+		// statuses without a name fall back to the template
+		"cluster-b": "nginx-v2",
+		"cluster-c": "nginx-v2",
+	}
+	for cluster, expected := range cases {
+		if name := propagatedDeployment(policy, cluster); name != expected {
+			t.Errorf("cluster %s: got %s, expected %s", cluster, name, expected)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package propagation
+
+import (
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+type Options struct {
+	// Workers number of policies synced at the same time
+	Workers int `json:"workers" yaml:"workers"`
+	// Concurrency max number of member clusters a policy is synced to at the same time
+	Concurrency int `json:"concurrency" yaml:"concurrency"`
+	// Timeout of syncing the deployment of a policy to one cluster
+	Timeout time.Duration `json:"timeout" yaml:"timeout"`
+	// ResyncPeriod every policy is synced again after the period, refreshing the replicas in its status
+	// and repairing deployments changed in the member clusters
+	ResyncPeriod time.Duration `json:"resyncPeriod" yaml:"resyncPeriod"`
+}
+
+func NewPropagationOptions() *Options {
+	return &Options{
+		Workers:      2,
+		Concurrency:  10,
+		Timeout:      30 * time.Second,
+		ResyncPeriod: time.Minute,
+	}
+}
+
+// NewPropagationOptionsFromConfig read the options from settings.propagation of the config file,
+// missing values keep their defaults
+func NewPropagationOptionsFromConfig() *Options {
+	options := NewPropagationOptions()
+	if viper.IsSet("settings.propagation.workers") {
+		options.Workers = viper.GetInt("settings.propagation.workers")
+	}
+	if viper.IsSet("settings.propagation.concurrency") {
+		options.Concurrency = viper.GetInt("settings.propagation.concurrency")
+	}
+	if viper.IsSet("settings.propagation.timeout") {
+		options.Timeout = viper.GetDuration("settings.propagation.timeout")
+	}
+	if viper.IsSet("settings.propagation.resyncPeriod") {
+		options.ResyncPeriod = viper.GetDuration("settings.propagation.resyncPeriod")
+	}
+	if options.Workers < 1 {
+		options.Workers = 1
+	}
+	if options.Concurrency < 1 {
+		options.Concurrency = 1
+	}
+	return options
+}
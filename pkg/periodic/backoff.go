@@ -0,0 +1,75 @@
+package periodic
+
+import (
+	"sync"
+	"time"
+)
+
+// clusterBackoff tracks clusters that repeatedly fail to be probed, a failing cluster
+// is skipped for an exponentially growing delay capped at maxBackoff
+type clusterBackoff struct {
+	mu         sync.Mutex
+	base       time.Duration
+	maxBackoff time.Duration
+	entries    map[string]*backoffEntry
+}
+
+type backoffEntry struct {
+	failures  int
+	nextProbe time.Time
+}
+
+func newClusterBackoff(base, maxBackoff time.Duration) *clusterBackoff {
+	return &clusterBackoff{
+		base:       base,
+		maxBackoff: maxBackoff,
+		entries:    make(map[string]*backoffEntry),
+	}
+}
+
+// ShouldProbe whether the backoff of the cluster has expired
+func (b *clusterBackoff) ShouldProbe(clusterName string, now time.Time) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	entry, ok := b.entries[clusterName]
+	return !ok || !now.Before(entry.nextProbe)
+}
+
+// Failure record a failed probe and return the delay before the cluster is probed again
+func (b *clusterBackoff) Failure(clusterName string, now time.Time) time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	entry, ok := b.entries[clusterName]
+	if !ok {
+		entry = &backoffEntry{}
+		b.entries[clusterName] = entry
+	}
+	entry.failures++
+	delay := b.base
+	for i := 1; i < entry.failures && delay < b.maxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > b.maxBackoff {
+		delay = b.maxBackoff
+	}
+	entry.nextProbe = now.Add(delay)
+	return delay
+}
+
+// Success reset the backoff of the cluster
+func (b *clusterBackoff) Success(clusterName string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	delete(b.entries, clusterName)
+}
+
+// Prune drop the backoff of clusters that no longer exist
+func (b *clusterBackoff) Prune(clusterNames map[string]struct{}) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for name := range b.entries {
+		if _, ok := clusterNames[name]; !ok {
+			delete(b.entries, name)
+		}
+	}
+}
@@ -0,0 +1,30 @@
+package periodic
+
+import (
+	"testing"
+	"time"
+)
+
+func TestClusterBackoff(t *testing.T) {
+	backoff := newClusterBackoff(time.Minute, 5*time.Minute)
+	now := time.Now()
+	for i, want := range []time.Duration{time.Minute, 2 * time.Minute, 4 * time.Minute, 5 * time.Minute, 5 * time.Minute} {
+		if got := backoff.Failure("cluster-1", now); got != want {
+			t.Errorf("failure %d: delay = %s, want %s", i+1, got, want)
+		}
+	}
+	if backoff.ShouldProbe("cluster-1", now.Add(4*time.Minute)) {
+		t.Error("cluster-1 should still be backing off")
+	}
+	if !backoff.ShouldProbe("cluster-1", now.Add(5*time.Minute)) {
+		t.Error("cluster-1 should be probed once the backoff expired")
+	}
+	backoff.Success("cluster-1")
+	if got := backoff.Failure("cluster-1", now); got != time.Minute {
+		t.Errorf("delay after success = %s, want %s", got, time.Minute)
+	}
+	backoff.Prune(map[string]struct{}{})
+	if !backoff.ShouldProbe("cluster-1", now) {
+		t.Error("pruned cluster should be probed")
+	}
+}
@@ -2,32 +2,45 @@ package periodic
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
-	"muti-kube/models/common"
+	clusterModels "muti-kube/models/cluster"
+	"muti-kube/pkg/api/cluster/v1alpha1"
 	clusterv1alpha1 "muti-kube/pkg/client/cluster/clientset/versioned/typed/cluster/v1alpha1"
-	"muti-kube/pkg/consts"
 	baseService "muti-kube/pkg/service"
 	clusterService "muti-kube/pkg/service/cluster"
 	"muti-kube/pkg/util/logger"
+	"sync"
 	"time"
 
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apimachinery/pkg/version"
+)
+
+const (
+	reasonProbeSucceeded = "ProbeSucceeded"
+	reasonProbeFailed    = "ProbeFailed"
+	reasonNotConfigured  = "NotConfigured"
 )
 
 type ClusterPeriodic interface {
-	Start()
+	// Run probe the clusters until the context is done, in-flight probes are cancelled on return
+	Run(ctx context.Context)
 }
 
 type clusterPeriodic struct {
 	bs             baseService.BaseInterface
 	cs             clusterService.Interface
 	clustersClient clusterv1alpha1.ClusterInterface
+	options        *Options
+	backoff        *clusterBackoff
 }
 
-func NewTicketPeriodic() (ClusterPeriodic, error) {
+func NewTicketPeriodic(options *Options) (ClusterPeriodic, error) {
 	cluster, err := clusterService.NewClusterService()
 	if err != nil {
 		return nil, err
@@ -37,66 +50,157 @@ func NewTicketPeriodic() (ClusterPeriodic, error) {
 		return nil, err
 	}
 	return &clusterPeriodic{
-		cs: cluster,
-		bs: base,
+		cs:             cluster,
+		bs:             base,
+		clustersClient: base.GetClusterClient(),
+		options:        options,
+		backoff:        newClusterBackoff(options.Interval, options.MaxBackoff),
 	}, nil
 }
 
-func (cp *clusterPeriodic) Start() {
-	go wait.Forever(cp.updateClusterResourceStatus, time.Minute)
+func (cp *clusterPeriodic) Run(ctx context.Context) {
+	wait.JitterUntilWithContext(ctx, cp.updateClusterStatus, cp.options.Interval, cp.options.JitterFactor, true)
 }
 
-func (cp *clusterPeriodic) updateClusterResourceStatus() {
-	clusters, _, err := cp.cs.GetClusters(baseService.WithPagination(&common.Pagination{
-		Page:     consts.DEFAULT_PAGE,
-		PageSize: consts.DEFAULT_PAGE_SIZE,
-	}))
+// updateClusterStatus probe the clusters with a bounded pool of workers and write the result
+// through the status subresource, clusters in backoff are skipped
+func (cp *clusterPeriodic) updateClusterStatus(ctx context.Context) {
+	clusters, err := cp.bs.GetClusterLister().List(labels.Everything())
 	if err != nil {
 		logger.Error(err)
 		return
 	}
-	var cpuCapacity int64
-	var cpuUsage int64
-	var memoryCapacity int64
-	var memoryUsage int64
-	clusterClient := cp.bs.GetClusterClient()
-	for _, cluster := range clusters {
-		nodeList, err := cp.cs.GetNodesByClusterID(cluster.Name)
-		if err != nil {
-			continue
-		}
-		clientSet, err := cp.cs.GetKubernetesClientSet(cluster.Name)
-		if err != nil {
-			continue
+	clusterNames := make(map[string]struct{}, len(clusters))
+	queue := make(chan *v1alpha1.Cluster, len(clusters))
+	now := time.Now()
+	for _, item := range clusters {
+		clusterNames[item.Name] = struct{}{}
+		if cp.backoff.ShouldProbe(item.Name, now) {
+			queue <- item.DeepCopy()
 		}
-		for _, node := range nodeList.Items {
-			nodeUsage, err := cp.cs.GetNodeUsage(clientSet, node.Name)
-			if err != nil {
-				logger.Warn(fmt.Sprintf("cluster: %s ", cluster.Name), err)
-				continue
+	}
+	close(queue)
+	cp.backoff.Prune(clusterNames)
+	var wg sync.WaitGroup
+	for i := 0; i < cp.options.Concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for cluster := range queue {
+				if ctx.Err() != nil {
+					return
+				}
+				cp.syncClusterStatus(ctx, cluster)
 			}
-			cpuCapacity += node.Status.Capacity.Cpu().ScaledValue(resource.Milli)
-			cpuUsage += nodeUsage.Cpu().ScaledValue(resource.Milli)
-			// unit M
-			memoryUsage += nodeUsage.Memory().ScaledValue(resource.Mega)
-			memoryCapacity += node.Status.Capacity.Memory().ScaledValue(resource.Mega)
-		}
-		patchData := []byte(
-			fmt.Sprintf(
-				`{"status": {
-					"cpu_usage": %d,
-					"memory_usage":%d,
-					"cpu_capacity":%d,
-					"memory_capacity":%d}}`,
-				cpuUsage, memoryUsage, cpuCapacity, memoryCapacity))
-		_, err = clusterClient.Patch(
-			context.Background(),
-			cluster.Name,
-			types.MergePatchType,
-			patchData, metav1.PatchOptions{},
-		)
-		if err != nil {
-			logger.Warn(err)
+		}()
+	}
+	wg.Wait()
+}
+
+// syncClusterStatus probe one cluster within the per-cluster timeout and store its status
+func (cp *clusterPeriodic) syncClusterStatus(ctx context.Context, cluster *v1alpha1.Cluster) {
+	probeCtx, cancel := context.WithTimeout(ctx, cp.options.Timeout)
+	defer cancel()
+	if err := cp.probeCluster(probeCtx, cluster); err != nil {
+		delay := cp.backoff.Failure(cluster.Name, time.Now())
+		logger.Warn(fmt.Sprintf("cluster: %s probe failed, retry in %s ", cluster.Name, delay), err)
+	} else {
+		cp.backoff.Success(cluster.Name)
+	}
+	// the probe context may already be expired, the status is written with the parent context
+	_, err := cp.clustersClient.UpdateStatus(ctx, cluster, metav1.UpdateOptions{})
+	if err != nil {
+		logger.Warn(fmt.Sprintf("cluster: %s ", cluster.Name), err)
+	}
+}
+
+// probeCluster probe the member cluster and record the result into the status of the cluster
+func (cp *clusterPeriodic) probeCluster(ctx context.Context, cluster *v1alpha1.Cluster) error {
+	now := metav1.Now()
+	status := &cluster.Status
+	status.LastProbeTime = &now
+	err := cp.probeAPIServer(ctx, cluster)
+	setCondition(status, v1alpha1.ClusterAPIServerReachable, err)
+	setCondition(status, v1alpha1.ClusterReady, err)
+	if err != nil {
+		setCondition(status, v1alpha1.ClusterMetricsAPIAvailable, err)
+		status.LastError = err.Error()
+	} else {
+		status.LastError = ""
+		status.LastSuccessfulProbeTime = &now
+	}
+	if cluster.Spec.PrometheusURL == "" {
+		meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+			Type:    v1alpha1.ClusterPrometheusReachable,
+			Status:  metav1.ConditionUnknown,
+			Reason:  reasonNotConfigured,
+			Message: "prometheus url is not configured",
+		})
+		return err
+	}
+	setCondition(status, v1alpha1.ClusterPrometheusReachable, baseService.ProbePrometheus(ctx, cluster.Spec.PrometheusURL))
+	return err
+}
+
+// probeAPIServer read the version, nodes and resource rollup of the cluster
+func (cp *clusterPeriodic) probeAPIServer(ctx context.Context, cluster *v1alpha1.Cluster) error {
+	clientSet, err := cp.cs.GetKubernetesClientSet(cluster.Name)
+	if err != nil {
+		return err
+	}
+	body, err := clientSet.Kubernetes().Discovery().RESTClient().Get().AbsPath("/version").Do(ctx).Raw()
+	if err != nil {
+		return err
+	}
+	var versionInfo version.Info
+	if err = json.Unmarshal(body, &versionInfo); err != nil {
+		return err
+	}
+	cluster.Status.KubernetesVersion = versionInfo.GitVersion
+	nodes, _, err := cp.cs.GetNodeResources(cluster.Name, baseService.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	cp.updateClusterResourceStatus(nodes, &cluster.Status)
+	return nil
+}
+
+// updateClusterResourceStatus record the resource rollup of the nodes of one cluster
+func (cp *clusterPeriodic) updateClusterResourceStatus(nodes []*clusterModels.NodeResource,
+	status *v1alpha1.ClusterStatus) {
+	resources := clusterService.SummarizeNodeResources(nodes)
+	status.Resources = resources
+	status.NodeCount = resources.NodeCount
+	status.ReadyNodeCount = 0
+	for _, node := range nodes {
+		if node.Ready {
+			status.ReadyNodeCount++
 		}
 	}
+	status.CPUCapacity = resources.Capacity.Cpu().ScaledValue(resource.Milli)
+	status.CPUUsage = resources.Usage.Cpu().ScaledValue(resource.Milli)
+	// unit M
+	status.MemoryCapacity = resources.Capacity.Memory().ScaledValue(resource.Mega)
+	status.MemoryUsage = resources.Usage.Memory().ScaledValue(resource.Mega)
+	var err error
+	if resources.MetricsNodeCount < resources.NodeCount {
+		err = fmt.Errorf("metrics of %d/%d nodes are missing",
+			resources.NodeCount-resources.MetricsNodeCount, resources.NodeCount)
+	}
+	setCondition(status, v1alpha1.ClusterMetricsAPIAvailable, err)
+}
+
+// setCondition set the condition to true when the probe succeeded, otherwise false with the error as message
+func setCondition(status *v1alpha1.ClusterStatus, conditionType string, err error) {
+	condition := metav1.Condition{
+		Type:   conditionType,
+		Status: metav1.ConditionTrue,
+		Reason: reasonProbeSucceeded,
+	}
+	if err != nil {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = reasonProbeFailed
+		condition.Message = err.Error()
+	}
+	meta.SetStatusCondition(&status.Conditions, condition)
 }
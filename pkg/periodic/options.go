@@ -0,0 +1,55 @@
+package periodic
+
+import (
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+type Options struct {
+	// Interval between two rounds of probing all clusters
+	Interval time.Duration `json:"interval" yaml:"interval"`
+	// JitterFactor the interval is randomly extended by up to Interval*JitterFactor
+	JitterFactor float64 `json:"jitterFactor" yaml:"jitterFactor"`
+	// Concurrency max number of clusters probed at the same time
+	Concurrency int `json:"concurrency" yaml:"concurrency"`
+	// Timeout of probing one cluster
+	Timeout time.Duration `json:"timeout" yaml:"timeout"`
+	// MaxBackoff upper limit of the delay before a repeatedly failing cluster is probed again
+	MaxBackoff time.Duration `json:"maxBackoff" yaml:"maxBackoff"`
+}
+
+func NewPeriodicOptions() *Options {
+	return &Options{
+		Interval:     time.Minute,
+		JitterFactor: 0.1,
+		Concurrency:  10,
+		Timeout:      30 * time.Second,
+		MaxBackoff:   10 * time.Minute,
+	}
+}
+
+// NewPeriodicOptionsFromConfig read the options from settings.periodic of the config file,
+// missing values keep their defaults
+func NewPeriodicOptionsFromConfig() *Options {
+	options := NewPeriodicOptions()
+	if viper.IsSet("settings.periodic.interval") {
+		options.Interval = viper.GetDuration("settings.periodic.interval")
+	}
+	if viper.IsSet("settings.periodic.jitterFactor") {
+		options.JitterFactor = viper.GetFloat64("settings.periodic.jitterFactor")
+	}
+	if viper.IsSet("settings.periodic.concurrency") {
+		options.Concurrency = viper.GetInt("settings.periodic.concurrency")
+	}
+	if viper.IsSet("settings.periodic.timeout") {
+		options.Timeout = viper.GetDuration("settings.periodic.timeout")
+	}
+	if viper.IsSet("settings.periodic.maxBackoff") {
+		options.MaxBackoff = viper.GetDuration("settings.periodic.maxBackoff")
+	}
+	if options.Concurrency < 1 {
+		options.Concurrency = 1
+	}
+	return options
+}
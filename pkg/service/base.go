@@ -1,18 +1,31 @@
 package service
 
 import (
+	"context"
 	"errors"
 	"flag"
 	"fmt"
+	"muti-kube/pkg/client/cluster/clientset/versioned"
 	clusterv1alpha1 "muti-kube/pkg/client/cluster/clientset/versioned/typed/cluster/v1alpha1"
+	"muti-kube/pkg/client/cluster/informers/externalversions"
+	clusterinformers "muti-kube/pkg/client/cluster/informers/externalversions/cluster/v1alpha1"
+	clusterlisters "muti-kube/pkg/client/cluster/listers/cluster/v1alpha1"
+	"muti-kube/pkg/client/k8s"
+	"muti-kube/pkg/consts"
 	"muti-kube/pkg/simple/client/monitoring"
 	"muti-kube/pkg/simple/client/monitoring/prometheus"
+	"muti-kube/pkg/util/encryption"
 	"net/http"
 	"path/filepath"
 	"reflect"
+	"strings"
 	"sync"
 
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/client-go/util/homedir"
 )
@@ -23,12 +36,32 @@ var (
 )
 
 type base struct {
-	ClustersClient clusterv1alpha1.ClusterInterface
+	HostClient                kubernetes.Interface
+	CrdClient                 clusterv1alpha1.CrdV1alpha1Interface
+	ClustersClient            clusterv1alpha1.ClusterInterface
+	ClusterInformer           clusterinformers.ClusterInformer
+	ClusterLister             clusterlisters.ClusterLister
+	ClientRegistry            k8s.ClientRegistry
+	KubeConfigStore           k8s.KubeConfigStore
+	RoleLister                clusterlisters.RoleLister
+	RoleBindingLister         clusterlisters.RoleBindingLister
+	PropagationPolicyInformer clusterinformers.PropagationPolicyInformer
+	OverridePolicyInformer    clusterinformers.OverridePolicyInformer
 }
 
 type BaseInterface interface {
 	GetPrometheusClient(prometheusURL string) (monitoring.Interface, error)
 	GetClusterClient() clusterv1alpha1.ClusterInterface
+	GetClusterLister() clusterlisters.ClusterLister
+	GetClientRegistry() k8s.ClientRegistry
+	GetKubeConfigStore() k8s.KubeConfigStore
+	GetHostClient() kubernetes.Interface
+	GetRoleLister() clusterlisters.RoleLister
+	GetRoleBindingLister() clusterlisters.RoleBindingLister
+	GetCrdClient() clusterv1alpha1.CrdV1alpha1Interface
+	GetClusterInformer() clusterinformers.ClusterInformer
+	GetPropagationPolicyInformer() clusterinformers.PropagationPolicyInformer
+	GetOverridePolicyInformer() clusterinformers.OverridePolicyInformer
 }
 
 func NewBase() (BaseInterface, error) {
@@ -36,7 +69,6 @@ func NewBase() (BaseInterface, error) {
 		baseService, err := newBase()
 		if err != nil {
 			panic(err)
-			return
 		}
 		bs = baseService
 	})
@@ -56,12 +88,54 @@ func newBase() (*base, error) {
 	if config, err = clientcmd.BuildConfigFromFlags("", *kubeConfig); err != nil {
 		panic(err.Error())
 	}
-	clustersClientSet, err := clusterv1alpha1.NewForConfig(config)
+	hostClient, err := kubernetes.NewForConfig(config)
 	if err != nil {
 		return nil, err
 	}
+	clustersClientSet, err := versioned.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	clustersClient := clustersClientSet.CrdV1alpha1().Clusters()
+	informerFactory := externalversions.NewSharedInformerFactory(clustersClientSet, ClusterResyncPeriod)
+	clusterInformer := informerFactory.Crd().V1alpha1().Clusters()
+	roleInformer := informerFactory.Crd().V1alpha1().Roles()
+	roleBindingInformer := informerFactory.Crd().V1alpha1().RoleBindings()
+	propagationPolicyInformer := informerFactory.Crd().V1alpha1().PropagationPolicies()
+	overridePolicyInformer := informerFactory.Crd().V1alpha1().OverridePolicies()
+	encryptor, err := encryption.NewEncryptorFromConfig()
+	if err != nil {
+		return nil, err
+	}
+	// only the secrets in the namespace of muti-kube hold kubeconfigs of member clusters
+	hostInformerFactory := informers.NewSharedInformerFactoryWithOptions(hostClient, ClusterResyncPeriod,
+		informers.WithNamespace(consts.KubeCloudNamespace))
+	secretInformer := hostInformerFactory.Core().V1().Secrets()
+	kubeConfigStore := k8s.NewKubeConfigStore(consts.KubeCloudNamespace, hostClient, secretInformer.Lister(), encryptor)
+	clientRegistry := k8s.NewClientRegistry(clustersClient, clusterInformer, kubeConfigStore)
+	informerFactory.Start(wait.NeverStop)
+	hostInformerFactory.Start(wait.NeverStop)
+	if !cache.WaitForCacheSync(wait.NeverStop,
+		clusterInformer.Informer().HasSynced,
+		secretInformer.Informer().HasSynced,
+		roleInformer.Informer().HasSynced,
+		roleBindingInformer.Informer().HasSynced,
+		propagationPolicyInformer.Informer().HasSynced,
+		overridePolicyInformer.Informer().HasSynced) {
+		return nil, errors.New("failed to sync informer cache")
+	}
 	return &base{
-		ClustersClient: clustersClientSet.Clusters(),
+		HostClient:                hostClient,
+		CrdClient:                 clustersClientSet.CrdV1alpha1(),
+		ClustersClient:            clustersClient,
+		ClusterInformer:           clusterInformer,
+		ClusterLister:             clusterInformer.Lister(),
+		ClientRegistry:            clientRegistry,
+		KubeConfigStore:           kubeConfigStore,
+		RoleLister:                roleInformer.Lister(),
+		RoleBindingLister:         roleBindingInformer.Lister(),
+		PropagationPolicyInformer: propagationPolicyInformer,
+		OverridePolicyInformer:    overridePolicyInformer,
 	}, nil
 }
 
@@ -78,6 +152,24 @@ func CommonPaginate(x interface{}, offset int, limit int) (int, int) {
 	return offset, end
 }
 
+// ProbePrometheus Make sure the prometheus behind the address is healthy
+func ProbePrometheus(ctx context.Context, prometheusURL string) error {
+	client := http.Client{Timeout: ClusterConnectTimeout}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(prometheusURL, "/")+"/-/healthy", nil)
+	if err != nil {
+		return err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+	return nil
+}
+
 func (bs *base) GetPrometheusClient(prometheusURL string) (monitoring.Interface, error) {
 	resp, err := http.Get(prometheusURL)
 	if err != nil {
@@ -98,3 +190,44 @@ func (bs *base) GetPrometheusClient(prometheusURL string) (monitoring.Interface,
 func (bs *base) GetClusterClient() clusterv1alpha1.ClusterInterface {
 	return bs.ClustersClient
 }
+
+func (bs *base) GetClusterLister() clusterlisters.ClusterLister {
+	return bs.ClusterLister
+}
+
+func (bs *base) GetClientRegistry() k8s.ClientRegistry {
+	return bs.ClientRegistry
+}
+
+func (bs *base) GetKubeConfigStore() k8s.KubeConfigStore {
+	return bs.KubeConfigStore
+}
+
+// GetHostClient the kubernetes client of the cluster muti-kube stores its resources in
+func (bs *base) GetHostClient() kubernetes.Interface {
+	return bs.HostClient
+}
+
+func (bs *base) GetRoleLister() clusterlisters.RoleLister {
+	return bs.RoleLister
+}
+
+func (bs *base) GetRoleBindingLister() clusterlisters.RoleBindingLister {
+	return bs.RoleBindingLister
+}
+
+func (bs *base) GetCrdClient() clusterv1alpha1.CrdV1alpha1Interface {
+	return bs.CrdClient
+}
+
+func (bs *base) GetClusterInformer() clusterinformers.ClusterInformer {
+	return bs.ClusterInformer
+}
+
+func (bs *base) GetPropagationPolicyInformer() clusterinformers.PropagationPolicyInformer {
+	return bs.PropagationPolicyInformer
+}
+
+func (bs *base) GetOverridePolicyInformer() clusterinformers.OverridePolicyInformer {
+	return bs.OverridePolicyInformer
+}
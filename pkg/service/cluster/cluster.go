@@ -2,26 +2,23 @@ package cluster
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"io/ioutil"
 	"muti-kube/models/cluster"
 	"muti-kube/pkg/api/cluster/v1alpha1"
+	"muti-kube/pkg/authentication"
 	clusterv1alpha1 "muti-kube/pkg/client/cluster/clientset/versioned/typed/cluster/v1alpha1"
 	"muti-kube/pkg/client/k8s"
 	baseService "muti-kube/pkg/service"
 	"muti-kube/pkg/simple/client/monitoring"
 	"muti-kube/pkg/util"
 	"muti-kube/pkg/util/logger"
-	"os"
 	"sync"
 	"time"
 
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/rand"
-	"k8s.io/client-go/tools/clientcmd"
 )
 
 var (
@@ -33,16 +30,33 @@ type service struct {
 	baseService.BaseInterface
 	clustersClient clusterv1alpha1.ClusterInterface
 	ctx            context.Context
+	// impersonate the user of the op on member clusters
+	impersonate bool
+	// drains of the nodes started by this server
+	drains *drainTracker
 }
 
 type Interface interface {
 	GetNodesByClusterID(clusterID string) (*v1.NodeList, error)
-	GetKubernetesClientSet(clusterID string) (k8s.Client, error)
+	GetKubernetesClientSet(clusterID string, opts ...baseService.OpOption) (k8s.Client, error)
 	CreateCluster(clusterPost *cluster.Post) (*cluster.Cluster, error)
-	GetClusters(opts ...baseService.OpOption) ([]*cluster.Cluster, *int64, error)
+	PreflightCluster(clusterPost *cluster.Post) *cluster.PreflightReport
+	UpdateCluster(clusterID string, clusterPost *cluster.Post) (*cluster.Cluster, error)
+	PatchCluster(clusterID string, clusterPatch *cluster.Patch) (*cluster.Cluster, error)
+	DeleteCluster(clusterID string) error
+	MigrateKubeConfigs() error
+	GetClusters(clusterQuery cluster.Query, opts ...baseService.OpOption) ([]*cluster.Cluster, *int64, error)
 	GetNodeUsage(client k8s.Client, nodeName string) (usage v1.ResourceList, err error)
+	GetNodeResources(clusterID string, opts ...baseService.OpOption) ([]*cluster.NodeResource, *int64, error)
 	GetCluster(clusterID string, opts ...baseService.OpOption) (*cluster.Cluster, error)
 	GetNodeMetric(metrics []string, clusterID string, nodeName string, start, end time.Time, step time.Duration) ([]monitoring.Metric, error)
+	GetNamespaceMetric(metrics []string, clusterID string, namespace string, ts time.Time) ([]monitoring.Metric, error)
+	GetNode(clusterID string, nodeName string, opts ...baseService.OpOption) (*v1.Node, error)
+	CordonNode(clusterID string, nodeName string, unschedulable bool, opts ...baseService.OpOption) (*v1.Node, error)
+	UpdateNodeLabels(clusterID string, nodeName string, nodeLabels cluster.NodeLabels, opts ...baseService.OpOption) (*v1.Node, error)
+	UpdateNodeTaints(clusterID string, nodeName string, nodeTaints cluster.NodeTaints, opts ...baseService.OpOption) (*v1.Node, error)
+	DrainNode(clusterID string, nodeName string, nodeDrain cluster.NodeDrain, opts ...baseService.OpOption) (*cluster.NodeDrainStatus, error)
+	GetNodeDrain(clusterID string, nodeName string) (*cluster.NodeDrainStatus, error)
 }
 
 func NewClusterService() (Interface, error) {
@@ -61,18 +75,25 @@ func newService() (*service, error) {
 	if err != nil {
 		return nil, err
 	}
+	authenticationOptions, err := authentication.NewAuthenticationOptionsFromConfig()
+	if err != nil {
+		return nil, err
+	}
 	return &service{
 		clustersClient: base.GetClusterClient(),
 		ctx:            context.Background(),
 		BaseInterface:  base,
+		impersonate:    authenticationOptions.Impersonation,
+		drains:         newDrainTracker(),
 	}, nil
 }
 
-// GetClusters Obtain the cluster list and brief information about the nodes in the cluster
-func (s *service) GetClusters(opts ...baseService.OpOption) ([]*cluster.Cluster, *int64, error) {
+// GetClusters Obtain the cluster list filtered by the label selector, the health and version are read
+// from the status written by the periodic probe instead of requesting every cluster
+func (s *service) GetClusters(clusterQuery cluster.Query, opts ...baseService.OpOption) ([]*cluster.Cluster, *int64, error) {
 	op := baseService.OpGet(opts...)
 	clusterSlice := make([]*cluster.Cluster, 0)
-	list, err := s.clustersClient.List(s.ctx, metav1.ListOptions{})
+	list, err := s.clustersClient.List(s.ctx, metav1.ListOptions{LabelSelector: clusterQuery.LabelSelector})
 	if err != nil {
 		return nil, nil, err
 	}
@@ -82,28 +103,7 @@ func (s *service) GetClusters(opts ...baseService.OpOption) ([]*cluster.Cluster,
 		op.Pagination.PageSize)
 	listItem := list.Items[offset:end]
 	for _, item := range listItem {
-		clientSet, err := s.GetKubernetesClientSet(item.Name)
-		if err != nil {
-			continue
-		}
-		_, err = s.getClusterNodeInfo(clientSet)
-		if err != nil {
-			logger.Warn(err)
-			clusterSlice = append(clusterSlice, &cluster.Cluster{
-				Cluster:      item,
-				HealthStatus: baseService.Abnormal,
-			})
-			continue
-		}
-		versionInfo, err := clientSet.Kubernetes().Discovery().ServerVersion()
-		if err != nil {
-			continue
-		}
-		clusterSlice = append(clusterSlice, &cluster.Cluster{
-			Cluster:      item,
-			Version:      versionInfo.GitVersion,
-			HealthStatus: baseService.Normal,
-		})
+		clusterSlice = append(clusterSlice, newCluster(item))
 	}
 	return clusterSlice, count, nil
 }
@@ -113,7 +113,7 @@ func (s *service) GetCluster(clusterID string, opts ...baseService.OpOption) (*c
 	if err != nil {
 		return nil, err
 	}
-	clientSet, err := s.GetKubernetesClientSet(clusterData.Name)
+	clientSet, err := s.GetKubernetesClientSet(clusterData.Name, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -121,26 +121,49 @@ func (s *service) GetCluster(clusterID string, opts ...baseService.OpOption) (*c
 	if err != nil {
 		return nil, err
 	}
-	return &cluster.Cluster{
-		Cluster:  *clusterData,
-		NodeList: nodes,
-	}, nil
+	result := newCluster(*clusterData)
+	result.NodeList = nodes
+	return result, nil
 }
 
+// CreateCluster Import the cluster, the cluster is only persisted when the required preflight checks pass
 func (s *service) CreateCluster(clusterPost *cluster.Post) (*cluster.Cluster, error) {
+	spec := v1alpha1.ClusterSpec{
+		DisplayName:   clusterPost.DisplayName,
+		PrometheusURL: clusterPost.PrometheusURL,
+		Region:        clusterPost.Region,
+		Zone:          clusterPost.Zone,
+		Provider:      clusterPost.Provider,
+	}
+	clusterLabels, err := ClusterLabels(clusterPost.Labels, spec, clusterPost.ClusterSet)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkClusterSet(clusterPost.ClusterSet); err != nil {
+		return nil, err
+	}
+	report := s.PreflightCluster(clusterPost)
+	if !report.Passed {
+		return nil, &PreflightError{Report: report}
+	}
 	randomStr := rand.String(6)
 	clusterName := fmt.Sprintf("cluster-%s", randomStr)
+	secretRef, err := s.GetKubeConfigStore().Save(s.ctx, clusterName, []byte(clusterPost.KubeConfig))
+	if err != nil {
+		return nil, err
+	}
+	spec.KubeConfigSecretRef = secretRef
 	clusterData, err := s.clustersClient.Create(s.ctx, &v1alpha1.Cluster{
 		ObjectMeta: metav1.ObjectMeta{
-			Name: clusterName,
-		},
-		Spec: v1alpha1.ClusterSpec{
-			DisplayName:   clusterPost.DisplayName,
-			KubeConfig:    clusterPost.KubeConfig,
-			PrometheusURL: clusterPost.PrometheusURL,
+			Name:   clusterName,
+			Labels: clusterLabels,
 		},
+		Spec: spec,
 	}, metav1.CreateOptions{})
 	if err != nil {
+		if deleteErr := s.GetKubeConfigStore().Delete(s.ctx, clusterName); deleteErr != nil {
+			logger.Warn(fmt.Sprintf("cluster: %s ", clusterName), deleteErr)
+		}
 		return nil, err
 	}
 	clientSet, err := s.GetKubernetesClientSet(clusterName)
@@ -151,68 +174,155 @@ func (s *service) CreateCluster(clusterPost *cluster.Post) (*cluster.Cluster, er
 	if err != nil {
 		return nil, err
 	}
+	result := newCluster(*clusterData)
+	result.Version = report.ServerVersion
+	result.NodeList = nodes
+	return result, nil
+}
+
+// newCluster Build the api model of the cluster from its stored status,
+// the kubeconfig is never returned to the client
+func newCluster(item v1alpha1.Cluster) *cluster.Cluster {
+	item.Spec.KubeConfig = ""
+	healthStatus := baseService.Abnormal
+	if meta.IsStatusConditionTrue(item.Status.Conditions, v1alpha1.ClusterReady) {
+		healthStatus = baseService.Normal
+	}
 	return &cluster.Cluster{
-		Cluster:  *clusterData,
-		NodeList: nodes,
-	}, nil
+		Cluster:      item,
+		Version:      item.Status.KubernetesVersion,
+		HealthStatus: healthStatus,
+	}
 }
 
-// GetKubernetesClientSet Get the kubernetes native clientSet
-func (s *service) GetKubernetesClientSet(clusterID string) (k8s.Client, error) {
-	_, err := s.clustersClient.List(s.ctx, metav1.ListOptions{})
-	if err != nil {
-		return nil, err
+// UpdateCluster Replace the display name, kubeconfig, prometheus address, labels and location of the cluster
+func (s *service) UpdateCluster(clusterID string, clusterPost *cluster.Post) (*cluster.Cluster, error) {
+	clusterLabels := clusterPost.Labels
+	if clusterLabels == nil {
+		clusterLabels = map[string]string{}
 	}
-	clusterItem, err := s.clustersClient.Get(s.ctx, clusterID, metav1.GetOptions{})
+	return s.PatchCluster(clusterID, &cluster.Patch{
+		DisplayName:   &clusterPost.DisplayName,
+		KubeConfig:    &clusterPost.KubeConfig,
+		PrometheusURL: &clusterPost.PrometheusURL,
+		Labels:        clusterLabels,
+		Region:        &clusterPost.Region,
+		Zone:          &clusterPost.Zone,
+		Provider:      &clusterPost.Provider,
+		ClusterSet:    &clusterPost.ClusterSet,
+	})
+}
+
+// PatchCluster Update the given fields of the cluster, a new kubeconfig is only accepted
+// when the cluster can be reached with it
+func (s *service) PatchCluster(clusterID string, clusterPatch *cluster.Patch) (*cluster.Cluster, error) {
+	clusterData, err := s.clustersClient.Get(s.ctx, clusterID, metav1.GetOptions{})
 	if err != nil {
 		return nil, err
 	}
-	tmpFile, err := ioutil.TempFile(os.TempDir(), baseService.TempClusterFilePrefix)
-	if err != nil {
-		return nil, err
+	userLabels := UserLabels(clusterData.Labels)
+	if clusterPatch.Labels != nil {
+		userLabels = clusterPatch.Labels
 	}
-	_, err = tmpFile.Write([]byte(clusterItem.Spec.KubeConfig))
-	if err != nil {
+	clusterSet := clusterData.Labels[v1alpha1.ClusterSetLabel]
+	if clusterPatch.ClusterSet != nil && *clusterPatch.ClusterSet != clusterSet {
+		if err := s.checkClusterSet(*clusterPatch.ClusterSet); err != nil {
+			return nil, err
+		}
+		clusterSet = *clusterPatch.ClusterSet
+	}
+	if clusterPatch.Region != nil {
+		clusterData.Spec.Region = *clusterPatch.Region
+	}
+	if clusterPatch.Zone != nil {
+		clusterData.Spec.Zone = *clusterPatch.Zone
+	}
+	if clusterPatch.Provider != nil {
+		clusterData.Spec.Provider = *clusterPatch.Provider
+	}
+	if clusterData.Labels, err = ClusterLabels(userLabels, clusterData.Spec, clusterSet); err != nil {
 		return nil, err
 	}
-	config, err := clientcmd.BuildConfigFromFlags("", tmpFile.Name())
+	if clusterPatch.KubeConfig != nil {
+		kubeConfig, err := s.GetKubeConfigStore().Get(clusterData)
+		if err != nil || *clusterPatch.KubeConfig != string(kubeConfig) {
+			report := &cluster.PreflightReport{Passed: true}
+			if _, ok := s.preflightAPIServer(report, *clusterPatch.KubeConfig); !ok {
+				return nil, &PreflightError{Report: report}
+			}
+			secretRef, err := s.GetKubeConfigStore().Save(s.ctx, clusterData.Name, []byte(*clusterPatch.KubeConfig))
+			if err != nil {
+				return nil, err
+			}
+			clusterData.Spec.KubeConfigSecretRef = secretRef
+			clusterData.Spec.KubeConfig = ""
+		}
+	}
+	if clusterPatch.DisplayName != nil {
+		clusterData.Spec.DisplayName = *clusterPatch.DisplayName
+	}
+	if clusterPatch.PrometheusURL != nil {
+		clusterData.Spec.PrometheusURL = *clusterPatch.PrometheusURL
+	}
+	clusterData, err = s.clustersClient.Update(s.ctx, clusterData, metav1.UpdateOptions{})
 	if err != nil {
 		return nil, err
 	}
-	return k8s.NewKubernetesClientWithConfig(config)
+	return newCluster(*clusterData), nil
 }
 
-// GetNodesByClusterID If the cluster ID is passed in, information about the nodes in the current cluster is returned
-func (s *service) GetNodesByClusterID(clusterID string) (*v1.NodeList, error) {
-	clientSet, err := s.GetKubernetesClientSet(clusterID)
+// DeleteCluster Remove the cluster and release the cached client of the cluster
+func (s *service) DeleteCluster(clusterID string) error {
+	err := s.clustersClient.Delete(s.ctx, clusterID, metav1.DeleteOptions{})
 	if err != nil {
-		return nil, err
+		return err
 	}
-	return s.getClusterNodeInfo(clientSet)
+	s.GetClientRegistry().Remove(clusterID)
+	return s.GetKubeConfigStore().Delete(s.ctx, clusterID)
 }
 
-func (s *service) CordonNodeBy(clusterID string, nodeName string) error {
-	payload := []cluster.PatchStringValue{{
-		Op:    "replace",
-		Path:  "/spec/unschedulable",
-		Value: true,
-	},
-	}
-	clientSet, err := s.GetKubernetesClientSet(clusterID)
+// MigrateKubeConfigs Move the kubeconfigs still stored inline in the cluster spec into secrets
+func (s *service) MigrateKubeConfigs() error {
+	list, err := s.clustersClient.List(s.ctx, metav1.ListOptions{})
 	if err != nil {
 		return err
 	}
-	payloadBytes, err := json.Marshal(payload)
+	for i := range list.Items {
+		item := &list.Items[i]
+		if item.Spec.KubeConfigSecretRef != nil || item.Spec.KubeConfig == "" {
+			continue
+		}
+		secretRef, err := s.GetKubeConfigStore().Save(s.ctx, item.Name, []byte(item.Spec.KubeConfig))
+		if err != nil {
+			return fmt.Errorf("migrate kubeconfig of cluster %s: %w", item.Name, err)
+		}
+		item.Spec.KubeConfigSecretRef = secretRef
+		item.Spec.KubeConfig = ""
+		if _, err = s.clustersClient.Update(s.ctx, item, metav1.UpdateOptions{}); err != nil {
+			return fmt.Errorf("migrate kubeconfig of cluster %s: %w", item.Name, err)
+		}
+		logger.Info(fmt.Sprintf("cluster: %s kubeconfig migrated to secret %s", item.Name, secretRef.Name))
+	}
+	return nil
+}
+
+// GetKubernetesClientSet Get the kubernetes native clientSet from the client registry,
+// with impersonation enabled the clientSet acts as the user of the op
+func (s *service) GetKubernetesClientSet(clusterID string, opts ...baseService.OpOption) (k8s.Client, error) {
+	op := baseService.OpGet(opts...)
+	if !s.impersonate || op.User == nil {
+		return s.GetClientRegistry().Get(clusterID)
+	}
+	return s.GetClientRegistry().Get(clusterID, k8s.WithImpersonation(op.User.Name, op.User.Groups))
+}
+
+// GetNodesByClusterID If the cluster ID is passed in, information about the nodes in the current cluster is returned
+func (s *service) GetNodesByClusterID(clusterID string) (*v1.NodeList, error) {
+	clientSet, err := s.GetKubernetesClientSet(clusterID)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	_, err = clientSet.Kubernetes().
-		CoreV1().Nodes().Patch(
-		s.ctx, nodeName,
-		types.MergePatchType,
-		payloadBytes, metav1.PatchOptions{},
-	)
-	return err
+	return s.getClusterNodeInfo(clientSet)
 }
 
 // getClusterNodeInfo Gets the cluster node where the incoming client resides
@@ -255,3 +365,17 @@ func (s *service) GetNodeMetric(metrics []string, clusterID string,
 	)
 	return metricsValue, nil
 }
+
+// GetNamespaceMetric Pass in the cluster ID, namespace, and namespace_* monitoring indicators to obtain their values at ts
+func (s *service) GetNamespaceMetric(metrics []string, clusterID string,
+	namespace string, ts time.Time) ([]monitoring.Metric, error) {
+	clusterData, err := s.GetCluster(clusterID)
+	if err != nil {
+		return nil, err
+	}
+	prometheusClient, err := s.BaseInterface.GetPrometheusClient(clusterData.Spec.PrometheusURL)
+	if err != nil {
+		return nil, err
+	}
+	return prometheusClient.GetNamedMetrics(metrics, ts, monitoring.NamespaceOption{NamespaceName: namespace}), nil
+}
@@ -3,6 +3,7 @@ package cluster
 import (
 	"context"
 	"errors"
+	"muti-kube/models/cluster"
 	"testing"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -14,7 +15,7 @@ func TestService_GetClusters(t *testing.T) {
 		t.Error(err)
 		return
 	}
-	clusters, _, err := clusterService.GetClusters()
+	clusters, _, err := clusterService.GetClusters(cluster.Query{})
 	if err != nil {
 		t.Error(err)
 		return
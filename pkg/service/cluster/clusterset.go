@@ -0,0 +1,160 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"muti-kube/models/cluster"
+	"muti-kube/pkg/api/cluster/v1alpha1"
+	baseService "muti-kube/pkg/service"
+	"muti-kube/pkg/util"
+	"sort"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+)
+
+type clusterSetService struct {
+	ctx context.Context
+	bs  baseService.BaseInterface
+}
+
+// ClusterSetInterface manages the cluster sets, the clusters join a set with the ClusterSetLabel label
+// and are selected by the clusterSets of a cluster affinity
+type ClusterSetInterface interface {
+	GetClusterSets(opts ...baseService.OpOption) ([]*cluster.ClusterSet, *int64, error)
+	GetClusterSet(name string, opts ...baseService.OpOption) (*cluster.ClusterSet, error)
+	CreateClusterSet(clusterSetPost cluster.ClusterSetPost, opts ...baseService.OpOption) (*cluster.ClusterSet, error)
+	UpdateClusterSet(name string, clusterSetUpdate cluster.ClusterSetUpdate,
+		opts ...baseService.OpOption) (*cluster.ClusterSet, error)
+	DeleteClusterSet(name string, opts ...baseService.OpOption) error
+}
+
+func NewClusterSet() (ClusterSetInterface, error) {
+	base, err := baseService.NewBase()
+	if err != nil {
+		return nil, err
+	}
+	return &clusterSetService{
+		ctx: context.Background(),
+		bs:  base,
+	}, nil
+}
+
+// GetClusterSets Obtain the cluster sets with their clusters, the clusters are listed once for the page
+func (css *clusterSetService) GetClusterSets(opts ...baseService.OpOption) ([]*cluster.ClusterSet, *int64, error) {
+	op := baseService.OpGet(opts...)
+	ctx := op.ContextOr(css.ctx)
+	list, err := css.bs.GetCrdClient().ClusterSets().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, nil, err
+	}
+	count := util.ConvertToInt64Ptr(len(list.Items))
+	offset, end := baseService.CommonPaginate(list.Items,
+		(op.Pagination.Page-1)*op.Pagination.PageSize,
+		op.Pagination.PageSize)
+	members, err := css.members(ctx, v1alpha1.ClusterSetLabel)
+	if err != nil {
+		return nil, nil, err
+	}
+	clusterSets := make([]*cluster.ClusterSet, 0, end-offset)
+	for _, item := range list.Items[offset:end] {
+		clusterSets = append(clusterSets, newClusterSet(item, members[item.Name]))
+	}
+	return clusterSets, count, nil
+}
+
+func (css *clusterSetService) GetClusterSet(name string, opts ...baseService.OpOption) (*cluster.ClusterSet, error) {
+	op := baseService.OpGet(opts...)
+	ctx := op.ContextOr(css.ctx)
+	clusterSet, err := css.bs.GetCrdClient().ClusterSets().Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	members, err := css.members(ctx, fmt.Sprintf("%s=%s", v1alpha1.ClusterSetLabel, name))
+	if err != nil {
+		return nil, err
+	}
+	return newClusterSet(*clusterSet, members[name]), nil
+}
+
+func (css *clusterSetService) CreateClusterSet(clusterSetPost cluster.ClusterSetPost,
+	opts ...baseService.OpOption) (*cluster.ClusterSet, error) {
+	op := baseService.OpGet(opts...)
+	clusterSet, err := css.bs.GetCrdClient().ClusterSets().Create(op.ContextOr(css.ctx), &v1alpha1.ClusterSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: clusterSetPost.Name,
+		},
+		Spec: v1alpha1.ClusterSetSpec{
+			DisplayName: clusterSetPost.DisplayName,
+			Description: clusterSetPost.Description,
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return newClusterSet(*clusterSet, nil), nil
+}
+
+// UpdateClusterSet Replace the display name and description of the set, retried on conflicts
+func (css *clusterSetService) UpdateClusterSet(name string, clusterSetUpdate cluster.ClusterSetUpdate,
+	opts ...baseService.OpOption) (*cluster.ClusterSet, error) {
+	op := baseService.OpGet(opts...)
+	ctx := op.ContextOr(css.ctx)
+	clusterSets := css.bs.GetCrdClient().ClusterSets()
+	var clusterSet *v1alpha1.ClusterSet
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		current, err := clusterSets.Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		current.Spec.DisplayName = clusterSetUpdate.DisplayName
+		current.Spec.Description = clusterSetUpdate.Description
+		clusterSet, err = clusterSets.Update(ctx, current, metav1.UpdateOptions{})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return css.GetClusterSet(clusterSet.Name, opts...)
+}
+
+// DeleteClusterSet Remove the set, a set is only removed when no cluster belongs to it any more
+func (css *clusterSetService) DeleteClusterSet(name string, opts ...baseService.OpOption) error {
+	op := baseService.OpGet(opts...)
+	ctx := op.ContextOr(css.ctx)
+	members, err := css.members(ctx, fmt.Sprintf("%s=%s", v1alpha1.ClusterSetLabel, name))
+	if err != nil {
+		return err
+	}
+	if len(members[name]) > 0 {
+		return fmt.Errorf("cluster set %s still has the clusters %v", name, members[name])
+	}
+	return css.bs.GetCrdClient().ClusterSets().Delete(ctx, name, metav1.DeleteOptions{})
+}
+
+// members the names of the clusters matching the selector by the set they belong to
+func (css *clusterSetService) members(ctx context.Context, labelSelector string) (map[string][]string, error) {
+	list, err := css.bs.GetClusterClient().List(ctx, metav1.ListOptions{LabelSelector: labelSelector})
+	if err != nil {
+		return nil, err
+	}
+	members := make(map[string][]string)
+	for _, item := range list.Items {
+		clusterSet := item.Labels[v1alpha1.ClusterSetLabel]
+		members[clusterSet] = append(members[clusterSet], item.Name)
+	}
+	for _, names := range members {
+		sort.Strings(names)
+	}
+	return members, nil
+}
+
+func newClusterSet(clusterSet v1alpha1.ClusterSet, members []string) *cluster.ClusterSet {
+	if members == nil {
+		members = make([]string, 0)
+	}
+	return &cluster.ClusterSet{
+		ClusterSet: clusterSet,
+		Clusters:   members,
+	}
+}
@@ -0,0 +1,305 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"muti-kube/models/cluster"
+	"muti-kube/pkg/client/k8s"
+	baseService "muti-kube/pkg/service"
+	"muti-kube/pkg/util/logger"
+	"strings"
+	"sync"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+const (
+	// defaultDrainTimeout the timeout of a drain without one
+	defaultDrainTimeout = 300 * time.Second
+	// evictionRetryInterval the interval of retrying evictions refused by a PodDisruptionBudget
+	evictionRetryInterval = 5 * time.Second
+	// mirrorPodAnnotation static pods are mirrored into the api server, they can not be evicted
+	mirrorPodAnnotation = "kubernetes.io/config.mirror"
+)
+
+// drainTracker the drains started on the nodes of the clusters, the last one of each node is kept
+type drainTracker struct {
+	lock     sync.Mutex
+	statuses map[string]*cluster.NodeDrainStatus
+}
+
+func newDrainTracker() *drainTracker {
+	return &drainTracker{statuses: make(map[string]*cluster.NodeDrainStatus)}
+}
+
+func drainKey(clusterID string, nodeName string) string {
+	return clusterID + "/" + nodeName
+}
+
+// start record a new drain of the node, refused while the last one is running
+func (t *drainTracker) start(clusterID string, nodeName string, status *cluster.NodeDrainStatus) error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	key := drainKey(clusterID, nodeName)
+	if last, ok := t.statuses[key]; ok && last.Phase == cluster.DrainRunning {
+		return fmt.Errorf("node %s is being drained", nodeName)
+	}
+	t.statuses[key] = status
+	return nil
+}
+
+// update change the status of the drain under the lock
+func (t *drainTracker) update(status *cluster.NodeDrainStatus, update func(status *cluster.NodeDrainStatus)) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	update(status)
+}
+
+// get a copy of the status of the last drain of the node
+func (t *drainTracker) get(clusterID string, nodeName string) (*cluster.NodeDrainStatus, bool) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	status, ok := t.statuses[drainKey(clusterID, nodeName)]
+	if !ok {
+		return nil, false
+	}
+	return copyDrainStatus(status), true
+}
+
+func copyDrainStatus(status *cluster.NodeDrainStatus) *cluster.NodeDrainStatus {
+	copied := *status
+	copied.Pending = append([]string{}, status.Pending...)
+	copied.Skipped = append([]string{}, status.Skipped...)
+	return &copied
+}
+
+// DrainNode Cordon the node and evict its pods in the background, the progress is read with GetNodeDrain;
+// the drain is refused before evicting anything when pods would be lost without the options allowing it
+func (s *service) DrainNode(clusterID string, nodeName string,
+	nodeDrain cluster.NodeDrain, opts ...baseService.OpOption) (*cluster.NodeDrainStatus, error) {
+	op := baseService.OpGet(opts...)
+	ctx := op.ContextOr(s.ctx)
+	clientSet, err := s.GetKubernetesClientSet(clusterID, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.CordonNode(clusterID, nodeName, true, opts...); err != nil {
+		return nil, err
+	}
+	list, err := clientSet.Kubernetes().CoreV1().Pods(metav1.NamespaceAll).List(ctx, metav1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector("spec.nodeName", nodeName).String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	pods, skipped, blocked := FilterDrainPods(list.Items, nodeDrain)
+	if len(blocked) > 0 {
+		return nil, fmt.Errorf("cannot drain node %s: %s", nodeName, strings.Join(blocked, "; "))
+	}
+	evictionVersion, err := evictionVersionOf(clientSet)
+	if err != nil {
+		return nil, err
+	}
+	status := &cluster.NodeDrainStatus{
+		Node:      nodeName,
+		Phase:     cluster.DrainRunning,
+		Total:     len(pods),
+		Pending:   make([]string, 0, len(pods)),
+		Skipped:   skipped,
+		StartTime: metav1.Now(),
+	}
+	for i := range pods {
+		status.Pending = append(status.Pending, podKey(&pods[i]))
+	}
+	if err := s.drains.start(clusterID, nodeName, status); err != nil {
+		return nil, err
+	}
+	timeout := defaultDrainTimeout
+	if nodeDrain.TimeoutSeconds > 0 {
+		timeout = time.Duration(nodeDrain.TimeoutSeconds) * time.Second
+	}
+	d := &drainer{
+		client:             clientSet,
+		tracker:            s.drains,
+		status:             status,
+		gracePeriodSeconds: nodeDrain.GracePeriodSeconds,
+		evictionVersion:    evictionVersion,
+	}
+	go d.run(timeout, pods)
+	return s.GetNodeDrain(clusterID, nodeName)
+}
+
+// GetNodeDrain Obtain the progress of the last drain of the node started by this server
+func (s *service) GetNodeDrain(clusterID string, nodeName string) (*cluster.NodeDrainStatus, error) {
+	status, ok := s.drains.get(clusterID, nodeName)
+	if !ok {
+		return nil, fmt.Errorf("node %s has not been drained", nodeName)
+	}
+	return status, nil
+}
+
+// FilterDrainPods split the pods of the node into those to evict, those skipped and the reasons blocking the drain,
+// as kubectl drain mirror pods are skipped and finished pods are always evicted
+func FilterDrainPods(pods []v1.Pod, nodeDrain cluster.NodeDrain) ([]v1.Pod, []string, []string) {
+	evict := make([]v1.Pod, 0, len(pods))
+	skipped := make([]string, 0)
+	blocked := make([]string, 0)
+	for i := range pods {
+		pod := &pods[i]
+		if _, ok := pod.Annotations[mirrorPodAnnotation]; ok {
+			skipped = append(skipped, podKey(pod))
+			continue
+		}
+		finished := pod.Status.Phase == v1.PodSucceeded || pod.Status.Phase == v1.PodFailed
+		controller := metav1.GetControllerOf(pod)
+		switch {
+		case controller != nil && controller.Kind == "DaemonSet":
+			if !nodeDrain.IgnoreDaemonSets {
+				blocked = append(blocked, fmt.Sprintf("pod %s is managed by a daemonset, set ignore_daemonsets", podKey(pod)))
+			} else {
+				skipped = append(skipped, podKey(pod))
+			}
+			continue
+		case finished:
+		case controller == nil && !nodeDrain.Force:
+			blocked = append(blocked, fmt.Sprintf("pod %s is not managed by a controller, set force", podKey(pod)))
+			continue
+		case hasEmptyDir(pod) && !nodeDrain.DeleteEmptyDirData:
+			blocked = append(blocked, fmt.Sprintf("pod %s uses emptyDir volumes, set delete_emptydir_data", podKey(pod)))
+			continue
+		}
+		evict = append(evict, *pod)
+	}
+	return evict, skipped, blocked
+}
+
+func hasEmptyDir(pod *v1.Pod) bool {
+	for _, volume := range pod.Spec.Volumes {
+		if volume.EmptyDir != nil {
+			return true
+		}
+	}
+	return false
+}
+
+func podKey(pod *v1.Pod) string {
+	return pod.Namespace + "/" + pod.Name
+}
+
+// evictionVersionOf the version of the eviction api of the cluster, policy/v1 is served since kubernetes 1.22
+func evictionVersionOf(client k8s.Client) (string, error) {
+	resources, err := client.Kubernetes().Discovery().ServerResourcesForGroupVersion("v1")
+	if err != nil {
+		return "", err
+	}
+	for _, resource := range resources.APIResources {
+		if resource.Name == "pods/eviction" && resource.Kind == "Eviction" && resource.Group == "policy" {
+			return resource.Version, nil
+		}
+	}
+	return "", fmt.Errorf("the cluster does not support evicting pods")
+}
+
+// drainer evicts the pods of a node and reports the progress into the status
+type drainer struct {
+	client             k8s.Client
+	tracker            *drainTracker
+	status             *cluster.NodeDrainStatus
+	gracePeriodSeconds *int64
+	evictionVersion    string
+}
+
+func (d *drainer) run(timeout time.Duration, pods []v1.Pod) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	var wg sync.WaitGroup
+	errs := make([]string, 0)
+	var errsLock sync.Mutex
+	for i := range pods {
+		wg.Add(1)
+		go func(pod *v1.Pod) {
+			defer wg.Done()
+			if err := d.evictPod(ctx, pod); err != nil {
+				errsLock.Lock()
+				errs = append(errs, fmt.Sprintf("evict pod %s: %v", podKey(pod), err))
+				errsLock.Unlock()
+				return
+			}
+			d.tracker.update(d.status, func(status *cluster.NodeDrainStatus) {
+				status.Evicted++
+				status.Pending = removeString(status.Pending, podKey(pod))
+			})
+		}(&pods[i])
+	}
+	wg.Wait()
+	d.tracker.update(d.status, func(status *cluster.NodeDrainStatus) {
+		now := metav1.Now()
+		status.CompletionTime = &now
+		if len(errs) > 0 {
+			status.Phase = cluster.DrainFailed
+			status.Message = strings.Join(errs, "; ")
+			return
+		}
+		status.Phase = cluster.DrainSucceeded
+	})
+	if len(errs) > 0 {
+		logger.Warn("drain node ", d.status.Node, " failed: ", strings.Join(errs, "; "))
+	}
+}
+
+// evictPod evict the pod and wait for it to be deleted, evictions refused by a PodDisruptionBudget are retried
+func (d *drainer) evictPod(ctx context.Context, pod *v1.Pod) error {
+	for {
+		err := d.evict(ctx, pod)
+		if err == nil || apierrors.IsNotFound(err) {
+			break
+		}
+		if !apierrors.IsTooManyRequests(err) {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("blocked by a PodDisruptionBudget until the timeout: %v", err)
+		case <-time.After(evictionRetryInterval):
+		}
+	}
+	pods := d.client.Kubernetes().CoreV1().Pods(pod.Namespace)
+	return wait.PollImmediateUntil(time.Second, func() (bool, error) {
+		current, err := pods.Get(ctx, pod.Name, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			return true, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		// a pod of the same name recreated by a statefulset is a different pod
+		return current.UID != pod.UID, nil
+	}, ctx.Done())
+}
+
+func (d *drainer) evict(ctx context.Context, pod *v1.Pod) error {
+	objectMeta := metav1.ObjectMeta{Name: pod.Name, Namespace: pod.Namespace}
+	deleteOptions := &metav1.DeleteOptions{GracePeriodSeconds: d.gracePeriodSeconds}
+	pods := d.client.Kubernetes().CoreV1().Pods(pod.Namespace)
+	if d.evictionVersion == "v1" {
+		return pods.EvictV1(ctx, &policyv1.Eviction{ObjectMeta: objectMeta, DeleteOptions: deleteOptions})
+	}
+	return pods.EvictV1beta1(ctx, &policyv1beta1.Eviction{ObjectMeta: objectMeta, DeleteOptions: deleteOptions})
+}
+
+func removeString(values []string, value string) []string {
+	result := values[:0]
+	for _, v := range values {
+		if v != value {
+			result = append(result, v)
+		}
+	}
+	return result
+}
@@ -0,0 +1,65 @@
+package cluster
+
+import (
+	"muti-kube/models/cluster"
+	"reflect"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newDrainPod(name string, ownerKind string, phase v1.PodPhase, emptyDir bool) v1.Pod {
+	pod := v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Status:     v1.PodStatus{Phase: phase},
+	}
+	if ownerKind != "" {
+		controller := true
+		pod.OwnerReferences = []metav1.OwnerReference{{Kind: ownerKind, Name: "owner", Controller: &controller}}
+	}
+	if emptyDir {
+		pod.Spec.Volumes = []v1.Volume{{
+			Name:         "cache",
+			VolumeSource: v1.VolumeSource{EmptyDir: &v1.EmptyDirVolumeSource{}},
+		}}
+	}
+	return pod
+}
+
+func TestFilterDrainPods(t *testing.T) {
+	mirror := newDrainPod("kube-apiserver", "", v1.PodRunning, false)
+	mirror.Annotations = map[string]string{mirrorPodAnnotation: "hash"}
+	pods := []v1.Pod{
+		mirror,
+		newDrainPod("web", "ReplicaSet", v1.PodRunning, false),
+		newDrainPod("fluentd", "DaemonSet", v1.PodRunning, false),
+		newDrainPod("bare", "", v1.PodRunning, false),
+		newDrainPod("bare-done", "", v1.PodSucceeded, false),
+		newDrainPod("cache", "ReplicaSet", v1.PodRunning, true),
+	}
+
+	_, _, blocked := FilterDrainPods(pods, cluster.NodeDrain{})
+	if len(blocked) != 3 {
+		t.Errorf("daemonset, unmanaged and emptyDir pods should block the drain, got %v", blocked)
+	}
+
+	evict, skipped, blocked := FilterDrainPods(pods, cluster.NodeDrain{
+		IgnoreDaemonSets:   true,
+		DeleteEmptyDirData: true,
+		Force:              true,
+	})
+	if len(blocked) != 0 {
+		t.Errorf("nothing should block the drain, got %v", blocked)
+	}
+	if !reflect.DeepEqual(skipped, []string{"default/kube-apiserver", "default/fluentd"}) {
+		t.Errorf("unexpected skipped pods %v", skipped)
+	}
+	names := make([]string, 0, len(evict))
+	for _, pod := range evict {
+		names = append(names, pod.Name)
+	}
+	if !reflect.DeepEqual(names, []string{"web", "bare", "bare-done", "cache"}) {
+		t.Errorf("unexpected evicted pods %v", names)
+	}
+}
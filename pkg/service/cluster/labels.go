@@ -0,0 +1,65 @@
+package cluster
+
+import (
+	"fmt"
+	"muti-kube/pkg/api/cluster/v1alpha1"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// managedLabelPrefix the prefix of the labels of a cluster managed by muti-kube
+const managedLabelPrefix = "cluster.crd.muti-kube.com/"
+
+// UserLabels the labels of the cluster not managed by muti-kube
+func UserLabels(clusterLabels map[string]string) map[string]string {
+	userLabels := make(map[string]string, len(clusterLabels))
+	for key, value := range clusterLabels {
+		if !strings.HasPrefix(key, managedLabelPrefix) {
+			userLabels[key] = value
+		}
+	}
+	return userLabels
+}
+
+// ClusterLabels the user labels with the region, zone and provider of the spec and the cluster set mirrored
+// into the labels managed by muti-kube, the user labels can not set the managed labels
+func ClusterLabels(userLabels map[string]string, spec v1alpha1.ClusterSpec, clusterSet string) (map[string]string, error) {
+	clusterLabels := make(map[string]string, len(userLabels)+4)
+	for key, value := range userLabels {
+		if strings.HasPrefix(key, managedLabelPrefix) {
+			return nil, fmt.Errorf("labels: the %s labels are set by region, zone, provider and clusterset",
+				managedLabelPrefix)
+		}
+		clusterLabels[key] = value
+	}
+	for key, value := range map[string]string{
+		v1alpha1.ClusterRegionLabel:   spec.Region,
+		v1alpha1.ClusterZoneLabel:     spec.Zone,
+		v1alpha1.ClusterProviderLabel: spec.Provider,
+		v1alpha1.ClusterSetLabel:      clusterSet,
+	} {
+		if value != "" {
+			clusterLabels[key] = value
+		}
+	}
+	if errs := metav1validation.ValidateLabels(clusterLabels, field.NewPath("labels")); len(errs) > 0 {
+		return nil, errs.ToAggregate()
+	}
+	return clusterLabels, nil
+}
+
+// checkClusterSet the cluster set must exist before clusters join it
+func (s *service) checkClusterSet(clusterSet string) error {
+	if clusterSet == "" {
+		return nil
+	}
+	_, err := s.GetCrdClient().ClusterSets().Get(s.ctx, clusterSet, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return fmt.Errorf("cluster set %s does not exist", clusterSet)
+	}
+	return err
+}
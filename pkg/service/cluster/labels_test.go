@@ -0,0 +1,33 @@
+package cluster
+
+import (
+	"muti-kube/pkg/api/cluster/v1alpha1"
+	"reflect"
+	"testing"
+)
+
+func TestClusterLabels(t *testing.T) {
+	spec := v1alpha1.ClusterSpec{Region: "cn-hangzhou", Provider: "aliyun"}
+	clusterLabels, err := ClusterLabels(map[string]string{"env": "prod"}, spec, "hangzhou")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]string{
+		"env":                         "prod",
+		v1alpha1.ClusterRegionLabel:   "cn-hangzhou",
+		v1alpha1.ClusterProviderLabel: "aliyun",
+		v1alpha1.ClusterSetLabel:      "hangzhou",
+	}
+	if !reflect.DeepEqual(clusterLabels, expected) {
+		t.Errorf("unexpected labels %v", clusterLabels)
+	}
+	if userLabels := UserLabels(clusterLabels); !reflect.DeepEqual(userLabels, map[string]string{"env": "prod"}) {
+		t.Errorf("unexpected user labels %v", userLabels)
+	}
+	if _, err := ClusterLabels(map[string]string{v1alpha1.ClusterRegionLabel: "cn-beijing"}, spec, ""); err == nil {
+		t.Error("the managed labels are set by the user labels")
+	}
+	if _, err := ClusterLabels(nil, v1alpha1.ClusterSpec{Zone: "cn hangzhou b"}, ""); err == nil {
+		t.Error("an invalid zone is accepted as a label value")
+	}
+}
@@ -0,0 +1,83 @@
+package cluster
+
+import (
+	"encoding/json"
+	"muti-kube/models/cluster"
+	baseService "muti-kube/pkg/service"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+)
+
+func (s *service) GetNode(clusterID string, nodeName string, opts ...baseService.OpOption) (*v1.Node, error) {
+	op := baseService.OpGet(opts...)
+	clientSet, err := s.GetKubernetesClientSet(clusterID, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return clientSet.Kubernetes().CoreV1().Nodes().Get(op.ContextOr(s.ctx), nodeName, metav1.GetOptions{})
+}
+
+// CordonNode Mark the node unschedulable, or schedulable again
+func (s *service) CordonNode(clusterID string, nodeName string,
+	unschedulable bool, opts ...baseService.OpOption) (*v1.Node, error) {
+	return s.patchNode(clusterID, nodeName, map[string]interface{}{
+		"spec": map[string]interface{}{
+			"unschedulable": unschedulable,
+		},
+	}, opts...)
+}
+
+// UpdateNodeLabels Merge the labels into the labels of the node, null values remove the label
+func (s *service) UpdateNodeLabels(clusterID string, nodeName string,
+	nodeLabels cluster.NodeLabels, opts ...baseService.OpOption) (*v1.Node, error) {
+	return s.patchNode(clusterID, nodeName, map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"labels": nodeLabels.Labels,
+		},
+	}, opts...)
+}
+
+// UpdateNodeTaints Replace the taints of the node, the update is retried on conflicts with the latest version
+func (s *service) UpdateNodeTaints(clusterID string, nodeName string,
+	nodeTaints cluster.NodeTaints, opts ...baseService.OpOption) (*v1.Node, error) {
+	op := baseService.OpGet(opts...)
+	ctx := op.ContextOr(s.ctx)
+	clientSet, err := s.GetKubernetesClientSet(clusterID, opts...)
+	if err != nil {
+		return nil, err
+	}
+	nodes := clientSet.Kubernetes().CoreV1().Nodes()
+	var node *v1.Node
+	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		current, err := nodes.Get(ctx, nodeName, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		current.Spec.Taints = nodeTaints.Taints
+		node, err = nodes.Update(ctx, current, metav1.UpdateOptions{})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return node, nil
+}
+
+// patchNode apply the json merge patch to the node
+func (s *service) patchNode(clusterID string, nodeName string,
+	patch map[string]interface{}, opts ...baseService.OpOption) (*v1.Node, error) {
+	op := baseService.OpGet(opts...)
+	clientSet, err := s.GetKubernetesClientSet(clusterID, opts...)
+	if err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(patch)
+	if err != nil {
+		return nil, err
+	}
+	return clientSet.Kubernetes().CoreV1().Nodes().Patch(op.ContextOr(s.ctx), nodeName,
+		types.MergePatchType, data, metav1.PatchOptions{})
+}
@@ -0,0 +1,113 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"muti-kube/models/cluster"
+	"muti-kube/pkg/client/k8s"
+	baseService "muti-kube/pkg/service"
+	"strings"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+const (
+	checkKubeConfig    = "kubeconfig"
+	checkAPIServer     = "apiserver"
+	checkPrometheusURL = "prometheus"
+)
+
+// requiredPermissions the permissions muti-kube needs on a member cluster
+var requiredPermissions = []authorizationv1.ResourceAttributes{
+	{Verb: "list", Resource: "nodes"},
+	{Verb: "patch", Resource: "nodes"},
+	{Verb: "list", Resource: "pods"},
+	{Verb: "list", Group: "apps", Resource: "deployments"},
+	{Verb: "create", Group: "apps", Resource: "deployments"},
+	{Verb: "update", Group: "apps", Resource: "deployments"},
+	{Verb: "delete", Group: "apps", Resource: "deployments"},
+	{Verb: "list", Group: "metrics.k8s.io", Resource: "nodes"},
+}
+
+// impersonatePermissions the permissions needed to act as the muti-kube users on a member cluster
+var impersonatePermissions = []authorizationv1.ResourceAttributes{
+	{Verb: "impersonate", Resource: "users"},
+	{Verb: "impersonate", Resource: "groups"},
+}
+
+// PreflightError is returned when a required preflight check of a cluster fails
+type PreflightError struct {
+	Report *cluster.PreflightReport
+}
+
+func (e *PreflightError) Error() string {
+	var failed []string
+	for _, check := range e.Report.Checks {
+		if check.Required && !check.Passed {
+			failed = append(failed, fmt.Sprintf("%s: %s", check.Name, check.Message))
+		}
+	}
+	return fmt.Sprintf("cluster preflight check failed, %s", strings.Join(failed, "; "))
+}
+
+// PreflightCluster Check that the cluster can be managed by muti-kube before it is imported
+func (s *service) PreflightCluster(clusterPost *cluster.Post) *cluster.PreflightReport {
+	report := &cluster.PreflightReport{Passed: true}
+	client, ok := s.preflightAPIServer(report, clusterPost.KubeConfig)
+	if ok {
+		s.preflightPermissions(report, client)
+	}
+	if clusterPost.PrometheusURL != "" {
+		report.AddCheck(checkPrometheusURL, false, baseService.ProbePrometheus(s.ctx, clusterPost.PrometheusURL))
+	}
+	return report
+}
+
+// preflightAPIServer parse the kubeconfig and read the server version of the cluster
+func (s *service) preflightAPIServer(report *cluster.PreflightReport, kubeConfig string) (k8s.Client, bool) {
+	config, err := clientcmd.RESTConfigFromKubeConfig([]byte(kubeConfig))
+	if !report.AddCheck(checkKubeConfig, true, err) {
+		return nil, false
+	}
+	config.Timeout = baseService.ClusterConnectTimeout
+	client, err := k8s.NewKubernetesClientWithConfig(config)
+	if err != nil {
+		report.AddCheck(checkAPIServer, true, err)
+		return nil, false
+	}
+	versionInfo, err := client.Kubernetes().Discovery().ServerVersion()
+	if !report.AddCheck(checkAPIServer, true, err) {
+		return nil, false
+	}
+	report.ServerVersion = versionInfo.GitVersion
+	return client, true
+}
+
+// preflightPermissions run a SelfSubjectAccessReview for every permission muti-kube needs
+func (s *service) preflightPermissions(report *cluster.PreflightReport, client k8s.Client) {
+	ctx, cancel := context.WithTimeout(s.ctx, baseService.ClusterConnectTimeout)
+	defer cancel()
+	permissions := requiredPermissions
+	if s.impersonate {
+		permissions = append(append([]authorizationv1.ResourceAttributes(nil), permissions...), impersonatePermissions...)
+	}
+	for i := range permissions {
+		attributes := permissions[i]
+		name := fmt.Sprintf("%s %s", attributes.Verb, attributes.Resource)
+		if attributes.Group != "" {
+			name = fmt.Sprintf("%s %s.%s", attributes.Verb, attributes.Resource, attributes.Group)
+		}
+		review, err := client.Kubernetes().AuthorizationV1().SelfSubjectAccessReviews().Create(ctx,
+			&authorizationv1.SelfSubjectAccessReview{
+				Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+					ResourceAttributes: &attributes,
+				},
+			}, metav1.CreateOptions{})
+		if err == nil && !review.Status.Allowed {
+			err = fmt.Errorf("permission denied %s", review.Status.Reason)
+		}
+		report.AddCheck(name, true, err)
+	}
+}
@@ -0,0 +1,186 @@
+package cluster
+
+import (
+	"fmt"
+	"muti-kube/models/cluster"
+	"muti-kube/pkg/api/cluster/v1alpha1"
+	baseService "muti-kube/pkg/service"
+	"muti-kube/pkg/util"
+	"muti-kube/pkg/util/logger"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// nonTerminatedPodSelector selects the pods that still hold resources on their node
+const nonTerminatedPodSelector = "status.phase!=Succeeded,status.phase!=Failed"
+
+// summaryResources the resources summed up for nodes and clusters
+var summaryResources = []v1.ResourceName{
+	v1.ResourceCPU,
+	v1.ResourceMemory,
+	v1.ResourcePods,
+	v1.ResourceEphemeralStorage,
+}
+
+// GetNodeResources Obtain the capacity, allocatable, requests, limits and usage of every node of the cluster,
+// nodes without metrics are still returned with has_metrics false
+func (s *service) GetNodeResources(clusterID string, opts ...baseService.OpOption) ([]*cluster.NodeResource, *int64, error) {
+	op := baseService.OpGet(opts...)
+	ctx := op.ContextOr(s.ctx)
+	clientSet, err := s.GetKubernetesClientSet(clusterID, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+	nodeList, err := clientSet.Kubernetes().CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, nil, err
+	}
+	podList, err := clientSet.Kubernetes().CoreV1().Pods(metav1.NamespaceAll).List(ctx, metav1.ListOptions{
+		FieldSelector: nonTerminatedPodSelector,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	nodeUsages := make(map[string]v1.ResourceList)
+	metricsList, err := clientSet.Metrics().MetricsV1beta1().NodeMetricses().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		logger.Warn(fmt.Sprintf("cluster: %s ", clusterID), err)
+	} else {
+		for _, metrics := range metricsList.Items {
+			nodeUsages[metrics.Name] = metrics.Usage
+		}
+	}
+	nodePods := make(map[string][]v1.Pod)
+	for _, pod := range podList.Items {
+		if pod.Spec.NodeName != "" {
+			nodePods[pod.Spec.NodeName] = append(nodePods[pod.Spec.NodeName], pod)
+		}
+	}
+	items := nodeList.Items
+	count := util.ConvertToInt64Ptr(len(items))
+	if op.Pagination != nil {
+		offset, end := baseService.CommonPaginate(items,
+			(op.Pagination.Page-1)*op.Pagination.PageSize,
+			op.Pagination.PageSize)
+		items = items[offset:end]
+	}
+	nodes := make([]*cluster.NodeResource, 0, len(items))
+	for i := range items {
+		usage, hasMetrics := nodeUsages[items[i].Name]
+		nodes = append(nodes, NewNodeResource(&items[i], nodePods[items[i].Name], usage, hasMetrics))
+	}
+	return nodes, count, nil
+}
+
+// NewNodeResource Build the resource breakdown of the node from the pods scheduled to it and its metrics
+func NewNodeResource(node *v1.Node, pods []v1.Pod, usage v1.ResourceList, hasMetrics bool) *cluster.NodeResource {
+	nodeResource := &cluster.NodeResource{
+		Name:          node.Name,
+		Ready:         IsNodeReady(node),
+		Unschedulable: node.Spec.Unschedulable,
+		HasMetrics:    hasMetrics,
+		ResourceSummary: v1alpha1.ResourceSummary{
+			Capacity:    filterResources(node.Status.Capacity),
+			Allocatable: filterResources(node.Status.Allocatable),
+			Requests:    v1.ResourceList{},
+			Limits:      v1.ResourceList{},
+			Usage:       v1.ResourceList{},
+		},
+	}
+	for i := range pods {
+		requests, limits := podRequestsAndLimits(&pods[i])
+		addResources(nodeResource.Requests, requests)
+		addResources(nodeResource.Limits, limits)
+	}
+	if hasMetrics {
+		addResources(nodeResource.Usage, filterResources(usage))
+	}
+	nodeResource.Usage[v1.ResourcePods] = *resource.NewQuantity(int64(len(pods)), resource.DecimalSI)
+	return nodeResource
+}
+
+// SummarizeNodeResources Sum up the resource breakdown of the nodes of one cluster
+func SummarizeNodeResources(nodes []*cluster.NodeResource) *v1alpha1.ClusterResources {
+	summary := &v1alpha1.ClusterResources{
+		ResourceSummary: v1alpha1.ResourceSummary{
+			Capacity:    v1.ResourceList{},
+			Allocatable: v1.ResourceList{},
+			Requests:    v1.ResourceList{},
+			Limits:      v1.ResourceList{},
+			Usage:       v1.ResourceList{},
+		},
+	}
+	for _, node := range nodes {
+		summary.NodeCount++
+		addResources(summary.Capacity, node.Capacity)
+		addResources(summary.Allocatable, node.Allocatable)
+		addResources(summary.Requests, node.Requests)
+		addResources(summary.Limits, node.Limits)
+		addResources(summary.Usage, node.Usage)
+		if node.HasMetrics {
+			summary.MetricsNodeCount++
+		}
+	}
+	return summary
+}
+
+// IsNodeReady Whether the Ready condition of the node is true
+func IsNodeReady(node *v1.Node) bool {
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == v1.NodeReady {
+			return condition.Status == v1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// podRequestsAndLimits the effective requests and limits of the pod, an init container
+// only counts when it asks for more than all app containers together
+func podRequestsAndLimits(pod *v1.Pod) (requests, limits v1.ResourceList) {
+	requests, limits = v1.ResourceList{}, v1.ResourceList{}
+	for _, container := range pod.Spec.Containers {
+		addResources(requests, filterResources(container.Resources.Requests))
+		addResources(limits, filterResources(container.Resources.Limits))
+	}
+	for _, container := range pod.Spec.InitContainers {
+		maxResources(requests, filterResources(container.Resources.Requests))
+		maxResources(limits, filterResources(container.Resources.Limits))
+	}
+	if pod.Spec.Overhead != nil {
+		addResources(requests, filterResources(pod.Spec.Overhead))
+		addResources(limits, filterResources(pod.Spec.Overhead))
+	}
+	return requests, limits
+}
+
+func filterResources(list v1.ResourceList) v1.ResourceList {
+	filtered := v1.ResourceList{}
+	for _, name := range summaryResources {
+		if quantity, ok := list[name]; ok {
+			filtered[name] = quantity.DeepCopy()
+		}
+	}
+	return filtered
+}
+
+func addResources(total v1.ResourceList, list v1.ResourceList) {
+	for name, quantity := range list {
+		value, ok := total[name]
+		if !ok {
+			total[name] = quantity.DeepCopy()
+			continue
+		}
+		value.Add(quantity)
+		total[name] = value
+	}
+}
+
+func maxResources(total v1.ResourceList, list v1.ResourceList) {
+	for name, quantity := range list {
+		if value, ok := total[name]; !ok || quantity.Cmp(value) > 0 {
+			total[name] = quantity.DeepCopy()
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package cluster
+
+import (
+	"muti-kube/models/cluster"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newTestNode(name string, cpu, memory string) *v1.Node {
+	capacity := v1.ResourceList{
+		v1.ResourceCPU:    resource.MustParse(cpu),
+		v1.ResourceMemory: resource.MustParse(memory),
+		v1.ResourcePods:   resource.MustParse("110"),
+	}
+	return &v1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Status: v1.NodeStatus{
+			Capacity:    capacity,
+			Allocatable: capacity,
+			Conditions:  []v1.NodeCondition{{Type: v1.NodeReady, Status: v1.ConditionTrue}},
+		},
+	}
+}
+
+func newTestPod(request string, initRequest string) v1.Pod {
+	pod := v1.Pod{
+		Spec: v1.PodSpec{
+			Containers: []v1.Container{{
+				Resources: v1.ResourceRequirements{
+					Requests: v1.ResourceList{v1.ResourceCPU: resource.MustParse(request)},
+				},
+			}},
+		},
+	}
+	if initRequest != "" {
+		pod.Spec.InitContainers = []v1.Container{{
+			Resources: v1.ResourceRequirements{
+				Requests: v1.ResourceList{v1.ResourceCPU: resource.MustParse(initRequest)},
+			},
+		}}
+	}
+	return pod
+}
+
+func TestNewNodeResource(t *testing.T) {
+	node := newTestNode("node-1", "4", "8Gi")
+	pods := []v1.Pod{newTestPod("500m", ""), newTestPod("250m", "1")}
+	usage := v1.ResourceList{
+		v1.ResourceCPU:    resource.MustParse("1200m"),
+		v1.ResourceMemory: resource.MustParse("2Gi"),
+	}
+	nodeResource := NewNodeResource(node, pods, usage, true)
+	if !nodeResource.Ready {
+		t.Error("node should be ready")
+	}
+	if got := nodeResource.Requests.Cpu().MilliValue(); got != 1500 {
+		t.Errorf("cpu requests = %d, want 1500", got)
+	}
+	if got := nodeResource.Usage.Pods().Value(); got != 2 {
+		t.Errorf("pods usage = %d, want 2", got)
+	}
+	if got := nodeResource.Usage.Cpu().MilliValue(); got != 1200 {
+		t.Errorf("cpu usage = %d, want 1200", got)
+	}
+}
+
+func TestSummarizeNodeResources(t *testing.T) {
+	withMetrics := NewNodeResource(newTestNode("node-1", "4", "8Gi"), nil,
+		v1.ResourceList{v1.ResourceCPU: resource.MustParse("1")}, true)
+	withoutMetrics := NewNodeResource(newTestNode("node-2", "2", "4Gi"),
+		[]v1.Pod{newTestPod("100m", "")}, nil, false)
+	summary := SummarizeNodeResources([]*cluster.NodeResource{withMetrics, withoutMetrics})
+	if summary.NodeCount != 2 || summary.MetricsNodeCount != 1 {
+		t.Errorf("node count = %d/%d, want 2/1", summary.MetricsNodeCount, summary.NodeCount)
+	}
+	if got := summary.Capacity.Cpu().MilliValue(); got != 6000 {
+		t.Errorf("cpu capacity = %d, want 6000", got)
+	}
+	if got := summary.Capacity.Memory().Value(); got != 12*1024*1024*1024 {
+		t.Errorf("memory capacity = %d, want 12Gi", got)
+	}
+	if got := summary.Usage.Cpu().MilliValue(); got != 1000 {
+		t.Errorf("cpu usage = %d, want 1000", got)
+	}
+	if got := summary.Requests.Cpu().MilliValue(); got != 100 {
+		t.Errorf("cpu requests = %d, want 100", got)
+	}
+
+	again := SummarizeNodeResources([]*cluster.NodeResource{withoutMetrics})
+	if got := again.Capacity.Cpu().MilliValue(); got != 2000 {
+		t.Errorf("cpu capacity of a second cluster = %d, want 2000", got)
+	}
+}
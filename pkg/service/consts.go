@@ -1,8 +1,13 @@
 package service
 
+import "time"
+
 const (
-	TempClusterFilePrefix = "kube-config"
-	DefaultConfigPath     = "/Users/wudianqiu/.kube/config"
+	DefaultConfigPath = "/Users/wudianqiu/.kube/config"
+	// ClusterResyncPeriod resync period of the cluster informer
+	ClusterResyncPeriod = 10 * time.Minute
+	// ClusterConnectTimeout timeout of the connectivity check against a member cluster
+	ClusterConnectTimeout = 10 * time.Second
 )
 
 const (
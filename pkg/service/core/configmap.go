@@ -0,0 +1,161 @@
+package core
+
+import (
+	"context"
+	coreModels "muti-kube/models/core"
+	baseService "muti-kube/pkg/service"
+	"muti-kube/pkg/service/cluster"
+	"muti-kube/pkg/util"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+)
+
+type configMapService struct {
+	ctx context.Context
+	cs  cluster.Interface
+}
+
+type ConfigMapInterface interface {
+	GetConfigMaps(clusterID string, namespace string, opts ...baseService.OpOption) ([]v1.ConfigMap, *int64, error)
+	GetConfigMap(clusterID string, namespace string, configMapID string, opts ...baseService.OpOption) (*v1.ConfigMap, error)
+	CreateConfigMap(clusterID string, namespace string, configMapPost coreModels.ConfigMapPost, opts ...baseService.OpOption) (*v1.ConfigMap, error)
+	DryRunConfigMap(clusterID string, namespace string, configMapPost coreModels.ConfigMapPost, opts ...baseService.OpOption) (*v1.ConfigMap, error)
+	UpdateConfigMap(clusterID string, namespace string, configMapID string, configMapPost coreModels.ConfigMapPost, opts ...baseService.OpOption) (*coreModels.ConfigMapUpdate, error)
+	DeleteConfigMap(clusterID string, namespace string, configMapID string, opts ...baseService.OpOption) error
+}
+
+func NewConfigMap() (ConfigMapInterface, error) {
+	clusterService, err := cluster.NewClusterService()
+	if err != nil {
+		return nil, err
+	}
+	return &configMapService{
+		ctx: context.Background(),
+		cs:  clusterService,
+	}, nil
+}
+
+func (cs *configMapService) GetConfigMaps(clusterID string,
+	namespace string, opts ...baseService.OpOption) ([]v1.ConfigMap, *int64, error) {
+	op := baseService.OpGet(opts...)
+	clientSet, err := cs.cs.GetKubernetesClientSet(clusterID, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+	list, err := clientSet.Kubernetes().CoreV1().ConfigMaps(namespace).List(op.ContextOr(cs.ctx), metav1.ListOptions{})
+	if err != nil {
+		return nil, nil, err
+	}
+	count := util.ConvertToInt64Ptr(len(list.Items))
+	offset, end := baseService.CommonPaginate(list.Items,
+		(op.Pagination.Page-1)*op.Pagination.PageSize,
+		op.Pagination.PageSize)
+	return list.Items[offset:end], count, nil
+}
+
+func (cs *configMapService) GetConfigMap(clusterID string, namespace string,
+	configMapID string, opts ...baseService.OpOption) (*v1.ConfigMap, error) {
+	op := baseService.OpGet(opts...)
+	clientSet, err := cs.cs.GetKubernetesClientSet(clusterID, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return clientSet.Kubernetes().CoreV1().ConfigMaps(namespace).Get(op.ContextOr(cs.ctx), configMapID, metav1.GetOptions{})
+}
+
+func (cs *configMapService) CreateConfigMap(clusterID string, namespace string,
+	configMapPost coreModels.ConfigMapPost, opts ...baseService.OpOption) (*v1.ConfigMap, error) {
+	return cs.createConfigMap(clusterID, namespace, configMapPost, metav1.CreateOptions{}, opts...)
+}
+
+func (cs *configMapService) DryRunConfigMap(clusterID string, namespace string,
+	configMapPost coreModels.ConfigMapPost, opts ...baseService.OpOption) (*v1.ConfigMap, error) {
+	return cs.createConfigMap(clusterID, namespace, configMapPost, metav1.CreateOptions{
+		DryRun: []string{metav1.DryRunAll},
+	}, opts...)
+}
+
+func (cs *configMapService) createConfigMap(clusterID string, namespace string,
+	configMapPost coreModels.ConfigMapPost, createOptions metav1.CreateOptions,
+	opts ...baseService.OpOption) (*v1.ConfigMap, error) {
+	op := baseService.OpGet(opts...)
+	clientSet, err := cs.cs.GetKubernetesClientSet(clusterID, opts...)
+	if err != nil {
+		return nil, err
+	}
+	configMap := &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        configMapPost.Name,
+			Namespace:   namespace,
+			Labels:      configMapPost.Labels,
+			Annotations: configMapPost.Annotations,
+		},
+		Data:       configMapPost.Data,
+		BinaryData: configMapPost.BinaryData,
+		Immutable:  configMapPost.Immutable,
+	}
+	return clientSet.Kubernetes().CoreV1().ConfigMaps(namespace).Create(op.ContextOr(cs.ctx), configMap, createOptions)
+}
+
+// UpdateConfigMap Replace the data of the configmap and return the changed keys with their values,
+// the labels and annotations are only replaced when given
+func (cs *configMapService) UpdateConfigMap(clusterID string, namespace string, configMapID string,
+	configMapPost coreModels.ConfigMapPost, opts ...baseService.OpOption) (*coreModels.ConfigMapUpdate, error) {
+	op := baseService.OpGet(opts...)
+	ctx := op.ContextOr(cs.ctx)
+	clientSet, err := cs.cs.GetKubernetesClientSet(clusterID, opts...)
+	if err != nil {
+		return nil, err
+	}
+	configMaps := clientSet.Kubernetes().CoreV1().ConfigMaps(namespace)
+	update := &coreModels.ConfigMapUpdate{}
+	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		current, err := configMaps.Get(ctx, configMapID, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		old := configMapData(current)
+		current.Data = configMapPost.Data
+		current.BinaryData = configMapPost.BinaryData
+		if configMapPost.Labels != nil {
+			current.Labels = configMapPost.Labels
+		}
+		if configMapPost.Annotations != nil {
+			current.Annotations = configMapPost.Annotations
+		}
+		update.ConfigMap, err = configMaps.Update(ctx, current, metav1.UpdateOptions{})
+		if err != nil {
+			return err
+		}
+		update.Changes = DiffData(old, configMapData(update.ConfigMap), true)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return update, nil
+}
+
+func (cs *configMapService) DeleteConfigMap(clusterID string, namespace string,
+	configMapID string, opts ...baseService.OpOption) error {
+	op := baseService.OpGet(opts...)
+	clientSet, err := cs.cs.GetKubernetesClientSet(clusterID, opts...)
+	if err != nil {
+		return err
+	}
+	return clientSet.Kubernetes().CoreV1().ConfigMaps(namespace).Delete(op.ContextOr(cs.ctx), configMapID, metav1.DeleteOptions{})
+}
+
+// configMapData the data and binary data of the configmap, the keys of both never overlap
+func configMapData(configMap *v1.ConfigMap) map[string][]byte {
+	data := make(map[string][]byte, len(configMap.Data)+len(configMap.BinaryData))
+	for key, value := range configMap.Data {
+		data[key] = []byte(value)
+	}
+	for key, value := range configMap.BinaryData {
+		data[key] = value
+	}
+	return data
+}
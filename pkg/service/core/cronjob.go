@@ -0,0 +1,207 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	coreModels "muti-kube/models/core"
+	baseService "muti-kube/pkg/service"
+	"muti-kube/pkg/service/cluster"
+	"muti-kube/pkg/util"
+
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/util/retry"
+)
+
+// instantiateAnnotation marks jobs created from a cronjob by hand, as kubectl create job --from does
+const instantiateAnnotation = "cronjob.kubernetes.io/instantiate"
+
+type cronJobService struct {
+	ctx context.Context
+	cs  cluster.Interface
+}
+
+type CronJobInterface interface {
+	GetCronJobs(clusterID string, namespace string, opts ...baseService.OpOption) ([]batchv1.CronJob, *int64, error)
+	GetCronJob(clusterID string, namespace string, cronJobID string, opts ...baseService.OpOption) (*batchv1.CronJob, error)
+	CreateCronJob(clusterID string, namespace string, cronJobPost coreModels.CronJobPost, opts ...baseService.OpOption) (*batchv1.CronJob, error)
+	DryRunCronJob(clusterID string, namespace string, cronJobPost coreModels.CronJobPost, opts ...baseService.OpOption) (*batchv1.CronJob, error)
+	UpdateCronJob(clusterID string, namespace string, cronJobID string, cronJobPost coreModels.CronJobPost, opts ...baseService.OpOption) (*batchv1.CronJob, error)
+	DeleteCronJob(clusterID string, namespace string, cronJobID string, opts ...baseService.OpOption) error
+	SuspendCronJob(clusterID string, namespace string, cronJobID string, suspend bool, opts ...baseService.OpOption) (*batchv1.CronJob, error)
+	TriggerCronJob(clusterID string, namespace string, cronJobID string, opts ...baseService.OpOption) (*batchv1.Job, error)
+}
+
+func NewCronJob() (CronJobInterface, error) {
+	clusterService, err := cluster.NewClusterService()
+	if err != nil {
+		return nil, err
+	}
+	return &cronJobService{
+		ctx: context.Background(),
+		cs:  clusterService,
+	}, nil
+}
+
+func (cs *cronJobService) GetCronJobs(clusterID string,
+	namespace string, opts ...baseService.OpOption) ([]batchv1.CronJob, *int64, error) {
+	op := baseService.OpGet(opts...)
+	clientSet, err := cs.cs.GetKubernetesClientSet(clusterID, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+	list, err := clientSet.Kubernetes().BatchV1().CronJobs(namespace).List(op.ContextOr(cs.ctx), metav1.ListOptions{})
+	if err != nil {
+		return nil, nil, err
+	}
+	count := util.ConvertToInt64Ptr(len(list.Items))
+	offset, end := baseService.CommonPaginate(list.Items,
+		(op.Pagination.Page-1)*op.Pagination.PageSize,
+		op.Pagination.PageSize)
+	return list.Items[offset:end], count, nil
+}
+
+func (cs *cronJobService) GetCronJob(clusterID string, namespace string,
+	cronJobID string, opts ...baseService.OpOption) (*batchv1.CronJob, error) {
+	op := baseService.OpGet(opts...)
+	clientSet, err := cs.cs.GetKubernetesClientSet(clusterID, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return clientSet.Kubernetes().BatchV1().CronJobs(namespace).Get(op.ContextOr(cs.ctx), cronJobID, metav1.GetOptions{})
+}
+
+func (cs *cronJobService) CreateCronJob(clusterID string, namespace string,
+	cronJobPost coreModels.CronJobPost, opts ...baseService.OpOption) (*batchv1.CronJob, error) {
+	return cs.createCronJob(clusterID, namespace, cronJobPost, metav1.CreateOptions{}, opts...)
+}
+
+func (cs *cronJobService) DryRunCronJob(clusterID string, namespace string,
+	cronJobPost coreModels.CronJobPost, opts ...baseService.OpOption) (*batchv1.CronJob, error) {
+	return cs.createCronJob(clusterID, namespace, cronJobPost, metav1.CreateOptions{
+		DryRun: []string{metav1.DryRunAll},
+	}, opts...)
+}
+
+func (cs *cronJobService) createCronJob(clusterID string, namespace string,
+	cronJobPost coreModels.CronJobPost, createOptions metav1.CreateOptions,
+	opts ...baseService.OpOption) (*batchv1.CronJob, error) {
+	op := baseService.OpGet(opts...)
+	clientSet, err := cs.cs.GetKubernetesClientSet(clusterID, opts...)
+	if err != nil {
+		return nil, err
+	}
+	cronJob := &batchv1.CronJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        cronJobPost.Name,
+			Namespace:   namespace,
+			Labels:      cronJobPost.Labels,
+			Annotations: cronJobPost.Annotations,
+		},
+		Spec: cronJobPost.Spec,
+	}
+	return clientSet.Kubernetes().BatchV1().CronJobs(namespace).Create(op.ContextOr(cs.ctx), cronJob, createOptions)
+}
+
+// UpdateCronJob Replace the spec of the cronjob, the labels and annotations are only
+// replaced when given, the update is retried on conflicts with the latest version
+func (cs *cronJobService) UpdateCronJob(clusterID string, namespace string, cronJobID string,
+	cronJobPost coreModels.CronJobPost, opts ...baseService.OpOption) (*batchv1.CronJob, error) {
+	op := baseService.OpGet(opts...)
+	ctx := op.ContextOr(cs.ctx)
+	clientSet, err := cs.cs.GetKubernetesClientSet(clusterID, opts...)
+	if err != nil {
+		return nil, err
+	}
+	cronJobs := clientSet.Kubernetes().BatchV1().CronJobs(namespace)
+	var cronJob *batchv1.CronJob
+	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		current, err := cronJobs.Get(ctx, cronJobID, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		current.Spec = cronJobPost.Spec
+		if cronJobPost.Labels != nil {
+			current.Labels = cronJobPost.Labels
+		}
+		if cronJobPost.Annotations != nil {
+			current.Annotations = cronJobPost.Annotations
+		}
+		cronJob, err = cronJobs.Update(ctx, current, metav1.UpdateOptions{})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return cronJob, nil
+}
+
+// DeleteCronJob Delete the cronjob together with the jobs it created
+func (cs *cronJobService) DeleteCronJob(clusterID string, namespace string,
+	cronJobID string, opts ...baseService.OpOption) error {
+	op := baseService.OpGet(opts...)
+	clientSet, err := cs.cs.GetKubernetesClientSet(clusterID, opts...)
+	if err != nil {
+		return err
+	}
+	return clientSet.Kubernetes().BatchV1().CronJobs(namespace).Delete(op.ContextOr(cs.ctx), cronJobID, metav1.DeleteOptions{
+		PropagationPolicy: &backgroundPropagation,
+	})
+}
+
+// SuspendCronJob Suspend or resume the scheduling of the cronjob, running jobs are not affected
+func (cs *cronJobService) SuspendCronJob(clusterID string, namespace string,
+	cronJobID string, suspend bool, opts ...baseService.OpOption) (*batchv1.CronJob, error) {
+	op := baseService.OpGet(opts...)
+	clientSet, err := cs.cs.GetKubernetesClientSet(clusterID, opts...)
+	if err != nil {
+		return nil, err
+	}
+	data := fmt.Sprintf(`{"spec":{"suspend":%t}}`, suspend)
+	return clientSet.Kubernetes().BatchV1().CronJobs(namespace).Patch(op.ContextOr(cs.ctx), cronJobID,
+		types.StrategicMergePatchType, []byte(data), metav1.PatchOptions{})
+}
+
+// TriggerCronJob Run the cronjob now by creating a job from its job template
+func (cs *cronJobService) TriggerCronJob(clusterID string, namespace string,
+	cronJobID string, opts ...baseService.OpOption) (*batchv1.Job, error) {
+	op := baseService.OpGet(opts...)
+	ctx := op.ContextOr(cs.ctx)
+	clientSet, err := cs.cs.GetKubernetesClientSet(clusterID, opts...)
+	if err != nil {
+		return nil, err
+	}
+	cronJob, err := clientSet.Kubernetes().BatchV1().CronJobs(namespace).Get(ctx, cronJobID, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return clientSet.Kubernetes().BatchV1().Jobs(namespace).Create(ctx, NewJobFromCronJob(cronJob), metav1.CreateOptions{})
+}
+
+// NewJobFromCronJob Build a job from the job template of the cronjob, owned by the cronjob
+func NewJobFromCronJob(cronJob *batchv1.CronJob) *batchv1.Job {
+	annotations := map[string]string{instantiateAnnotation: "manual"}
+	for key, value := range cronJob.Spec.JobTemplate.Annotations {
+		annotations[key] = value
+	}
+	suffix := fmt.Sprintf("-manual-%s", rand.String(5))
+	prefix := cronJob.Name
+	if len(prefix)+len(suffix) > validation.DNS1123LabelMaxLength {
+		prefix = prefix[:validation.DNS1123LabelMaxLength-len(suffix)]
+	}
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        prefix + suffix,
+			Namespace:   cronJob.Namespace,
+			Labels:      cronJob.Spec.JobTemplate.Labels,
+			Annotations: annotations,
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(cronJob, batchv1.SchemeGroupVersion.WithKind("CronJob")),
+			},
+		},
+		Spec: *cronJob.Spec.JobTemplate.Spec.DeepCopy(),
+	}
+}
@@ -0,0 +1,35 @@
+package core
+
+import (
+	"strings"
+	"testing"
+
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestNewJobFromCronJob(t *testing.T) {
+	cronJob := &batchv1.CronJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      strings.Repeat("a", 52),
+			Namespace: "default",
+			UID:       types.UID("cronjob-uid"),
+		},
+		Spec: batchv1.CronJobSpec{
+			JobTemplate: batchv1.JobTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "backup"}},
+			},
+		},
+	}
+	job := NewJobFromCronJob(cronJob)
+	if len(job.Name) > 63 {
+		t.Errorf("job name %s is longer than 63", job.Name)
+	}
+	if job.Annotations[instantiateAnnotation] != "manual" || job.Labels["app"] != "backup" {
+		t.Errorf("unexpected metadata %v %v", job.Annotations, job.Labels)
+	}
+	if !metav1.IsControlledBy(job, cronJob) {
+		t.Error("job should be controlled by the cronjob")
+	}
+}
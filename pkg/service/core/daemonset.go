@@ -0,0 +1,141 @@
+package core
+
+import (
+	"context"
+	coreModels "muti-kube/models/core"
+	baseService "muti-kube/pkg/service"
+	"muti-kube/pkg/service/cluster"
+	"muti-kube/pkg/util"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+)
+
+type daemonSetService struct {
+	ctx context.Context
+	cs  cluster.Interface
+}
+
+type DaemonSetInterface interface {
+	GetDaemonSets(clusterID string, namespace string, opts ...baseService.OpOption) ([]appsv1.DaemonSet, *int64, error)
+	GetDaemonSet(clusterID string, namespace string, daemonSetID string, opts ...baseService.OpOption) (*appsv1.DaemonSet, error)
+	CreateDaemonSet(clusterID string, namespace string, daemonSetPost coreModels.DaemonSetPost, opts ...baseService.OpOption) (*appsv1.DaemonSet, error)
+	DryRunDaemonSet(clusterID string, namespace string, daemonSetPost coreModels.DaemonSetPost, opts ...baseService.OpOption) (*appsv1.DaemonSet, error)
+	UpdateDaemonSet(clusterID string, namespace string, daemonSetID string, daemonSetPost coreModels.DaemonSetPost, opts ...baseService.OpOption) (*appsv1.DaemonSet, error)
+	DeleteDaemonSet(clusterID string, namespace string, daemonSetID string, opts ...baseService.OpOption) error
+}
+
+func NewDaemonSet() (DaemonSetInterface, error) {
+	clusterService, err := cluster.NewClusterService()
+	if err != nil {
+		return nil, err
+	}
+	return &daemonSetService{
+		ctx: context.Background(),
+		cs:  clusterService,
+	}, nil
+}
+
+func (ds *daemonSetService) GetDaemonSets(clusterID string,
+	namespace string, opts ...baseService.OpOption) ([]appsv1.DaemonSet, *int64, error) {
+	op := baseService.OpGet(opts...)
+	clientSet, err := ds.cs.GetKubernetesClientSet(clusterID, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+	list, err := clientSet.Kubernetes().AppsV1().DaemonSets(namespace).List(op.ContextOr(ds.ctx), metav1.ListOptions{})
+	if err != nil {
+		return nil, nil, err
+	}
+	count := util.ConvertToInt64Ptr(len(list.Items))
+	offset, end := baseService.CommonPaginate(list.Items,
+		(op.Pagination.Page-1)*op.Pagination.PageSize,
+		op.Pagination.PageSize)
+	return list.Items[offset:end], count, nil
+}
+
+func (ds *daemonSetService) GetDaemonSet(clusterID string, namespace string,
+	daemonSetID string, opts ...baseService.OpOption) (*appsv1.DaemonSet, error) {
+	op := baseService.OpGet(opts...)
+	clientSet, err := ds.cs.GetKubernetesClientSet(clusterID, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return clientSet.Kubernetes().AppsV1().DaemonSets(namespace).Get(op.ContextOr(ds.ctx), daemonSetID, metav1.GetOptions{})
+}
+
+func (ds *daemonSetService) CreateDaemonSet(clusterID string, namespace string,
+	daemonSetPost coreModels.DaemonSetPost, opts ...baseService.OpOption) (*appsv1.DaemonSet, error) {
+	return ds.createDaemonSet(clusterID, namespace, daemonSetPost, metav1.CreateOptions{}, opts...)
+}
+
+func (ds *daemonSetService) DryRunDaemonSet(clusterID string, namespace string,
+	daemonSetPost coreModels.DaemonSetPost, opts ...baseService.OpOption) (*appsv1.DaemonSet, error) {
+	return ds.createDaemonSet(clusterID, namespace, daemonSetPost, metav1.CreateOptions{
+		DryRun: []string{metav1.DryRunAll},
+	}, opts...)
+}
+
+func (ds *daemonSetService) createDaemonSet(clusterID string, namespace string,
+	daemonSetPost coreModels.DaemonSetPost, createOptions metav1.CreateOptions,
+	opts ...baseService.OpOption) (*appsv1.DaemonSet, error) {
+	op := baseService.OpGet(opts...)
+	clientSet, err := ds.cs.GetKubernetesClientSet(clusterID, opts...)
+	if err != nil {
+		return nil, err
+	}
+	daemonSet := &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        daemonSetPost.Name,
+			Namespace:   namespace,
+			Labels:      daemonSetPost.Labels,
+			Annotations: daemonSetPost.Annotations,
+		},
+		Spec: daemonSetPost.Spec,
+	}
+	return clientSet.Kubernetes().AppsV1().DaemonSets(namespace).Create(op.ContextOr(ds.ctx), daemonSet, createOptions)
+}
+
+// UpdateDaemonSet Replace the spec of the daemonset, the labels and annotations are only
+// replaced when given, the update is retried on conflicts with the latest version
+func (ds *daemonSetService) UpdateDaemonSet(clusterID string, namespace string, daemonSetID string,
+	daemonSetPost coreModels.DaemonSetPost, opts ...baseService.OpOption) (*appsv1.DaemonSet, error) {
+	op := baseService.OpGet(opts...)
+	ctx := op.ContextOr(ds.ctx)
+	clientSet, err := ds.cs.GetKubernetesClientSet(clusterID, opts...)
+	if err != nil {
+		return nil, err
+	}
+	daemonSets := clientSet.Kubernetes().AppsV1().DaemonSets(namespace)
+	var daemonSet *appsv1.DaemonSet
+	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		current, err := daemonSets.Get(ctx, daemonSetID, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		current.Spec = daemonSetPost.Spec
+		if daemonSetPost.Labels != nil {
+			current.Labels = daemonSetPost.Labels
+		}
+		if daemonSetPost.Annotations != nil {
+			current.Annotations = daemonSetPost.Annotations
+		}
+		daemonSet, err = daemonSets.Update(ctx, current, metav1.UpdateOptions{})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return daemonSet, nil
+}
+
+func (ds *daemonSetService) DeleteDaemonSet(clusterID string, namespace string,
+	daemonSetID string, opts ...baseService.OpOption) error {
+	op := baseService.OpGet(opts...)
+	clientSet, err := ds.cs.GetKubernetesClientSet(clusterID, opts...)
+	if err != nil {
+		return err
+	}
+	return clientSet.Kubernetes().AppsV1().DaemonSets(namespace).Delete(op.ContextOr(ds.ctx), daemonSetID, metav1.DeleteOptions{})
+}
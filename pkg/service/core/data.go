@@ -0,0 +1,46 @@
+package core
+
+import (
+	"bytes"
+	coreModels "muti-kube/models/core"
+	"sort"
+)
+
+// DiffData the keys added, removed or changed from old to new sorted by key,
+// the old and new values are only filled withValues, secrets are diffed without them
+func DiffData(old map[string][]byte, new map[string][]byte, withValues bool) []coreModels.DataChange {
+	changes := make([]coreModels.DataChange, 0)
+	for key, oldValue := range old {
+		newValue, ok := new[key]
+		change := coreModels.DataChange{Key: key}
+		switch {
+		case !ok:
+			change.Operation = coreModels.DataRemoved
+		case !bytes.Equal(oldValue, newValue):
+			change.Operation = coreModels.DataChanged
+		default:
+			continue
+		}
+		if withValues {
+			change.Old = string(oldValue)
+			if ok {
+				change.New = string(newValue)
+			}
+		}
+		changes = append(changes, change)
+	}
+	for key, newValue := range new {
+		if _, ok := old[key]; ok {
+			continue
+		}
+		change := coreModels.DataChange{Key: key, Operation: coreModels.DataAdded}
+		if withValues {
+			change.New = string(newValue)
+		}
+		changes = append(changes, change)
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Key < changes[j].Key
+	})
+	return changes
+}
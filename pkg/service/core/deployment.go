@@ -2,15 +2,22 @@ package core
 
 import (
 	"context"
+	"fmt"
 	coreModels "muti-kube/models/core"
 	baseService "muti-kube/pkg/service"
 	"muti-kube/pkg/service/cluster"
 	"muti-kube/pkg/util"
+	"time"
 
 	appsv1 "k8s.io/api/apps/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
 )
 
+// restartedAtAnnotation changing the annotation of the pod template rolls out new pods, as kubectl rollout restart does
+const restartedAtAnnotation = "kubectl.kubernetes.io/restartedAt"
+
 type deploymentService struct {
 	baseService.BaseInterface
 	ctx context.Context
@@ -18,12 +25,19 @@ type deploymentService struct {
 }
 
 type DeploymentInterface interface {
-	DeleteDeployment(clusterID string, namespace string, deploymentID string) error
-	ScaleDeployment(clusterID string, namespace string, deploymentID string,replicas int32) error
+	DeleteDeployment(clusterID string, namespace string, deploymentID string, opts ...baseService.OpOption) error
+	ScaleDeployment(clusterID string, namespace string, deploymentID string, replicas int32, opts ...baseService.OpOption) error
 	GetDeployments(clusterID string, namespace string, opts ...baseService.OpOption) ([]appsv1.Deployment, *int64, error)
 	GetDeployment(clusterID string, namespace string, deploymentID string, opts ...baseService.OpOption) (*appsv1.Deployment, error)
-	CreateDeployment(clusterID string, namespace string, deploymentPost coreModels.DeploymentPost, opts ...baseService.OpOption) (*appsv1.Deployment,error)
+	CreateDeployment(clusterID string, namespace string, deploymentPost coreModels.DeploymentPost, opts ...baseService.OpOption) (*appsv1.Deployment, error)
 	DryRunDeployment(clusterID string, namespace string, deploymentPost coreModels.DeploymentPost, opts ...baseService.OpOption) (*appsv1.Deployment, error)
+	UpdateDeployment(clusterID string, namespace string, deploymentID string, deploymentPost coreModels.DeploymentPost, opts ...baseService.OpOption) (*appsv1.Deployment, error)
+	PatchDeployment(clusterID string, namespace string, deploymentID string, patchType types.PatchType, data []byte, opts ...baseService.OpOption) (*appsv1.Deployment, error)
+	RestartDeployment(clusterID string, namespace string, deploymentID string, opts ...baseService.OpOption) (*appsv1.Deployment, error)
+	PauseDeployment(clusterID string, namespace string, deploymentID string, paused bool, opts ...baseService.OpOption) (*appsv1.Deployment, error)
+	GetDeploymentRolloutStatus(clusterID string, namespace string, deploymentID string, opts ...baseService.OpOption) (*coreModels.DeploymentRolloutStatus, error)
+	GetDeploymentRevisions(clusterID string, namespace string, deploymentID string, opts ...baseService.OpOption) ([]*coreModels.DeploymentRevision, error)
+	RollbackDeployment(clusterID string, namespace string, deploymentID string, revision int64, opts ...baseService.OpOption) (*appsv1.Deployment, error)
 }
 
 func NewDeployment() (DeploymentInterface, error) {
@@ -49,11 +63,11 @@ func newDeployment() (*deploymentService, error) {
 func (ds *deploymentService) GetDeployments(clusterID string,
 	namespace string, opts ...baseService.OpOption) ([]appsv1.Deployment, *int64, error) {
 	op := baseService.OpGet(opts...)
-	clientSet, err := ds.cs.GetKubernetesClientSet(clusterID)
+	clientSet, err := ds.cs.GetKubernetesClientSet(clusterID, opts...)
 	if err != nil {
 		return nil, nil, err
 	}
-	list, err := clientSet.Kubernetes().AppsV1().Deployments(namespace).List(ds.ctx, metav1.ListOptions{})
+	list, err := clientSet.Kubernetes().AppsV1().Deployments(namespace).List(op.ContextOr(ds.ctx), metav1.ListOptions{})
 	if err != nil {
 		return nil, nil, err
 	}
@@ -71,11 +85,12 @@ func (ds *deploymentService) GetDeployment(
 	deploymentID string,
 	opts ...baseService.OpOption,
 ) (*appsv1.Deployment, error) {
-	clientSet, err := ds.cs.GetKubernetesClientSet(clusterID)
+	op := baseService.OpGet(opts...)
+	clientSet, err := ds.cs.GetKubernetesClientSet(clusterID, opts...)
 	if err != nil {
 		return nil, err
 	}
-	return clientSet.Kubernetes().AppsV1().Deployments(namespace).Get(ds.ctx, deploymentID, metav1.GetOptions{})
+	return clientSet.Kubernetes().AppsV1().Deployments(namespace).Get(op.ContextOr(ds.ctx), deploymentID, metav1.GetOptions{})
 }
 
 func (ds *deploymentService) DryRunDeployment(
@@ -84,19 +99,20 @@ func (ds *deploymentService) DryRunDeployment(
 	deploymentPost coreModels.DeploymentPost,
 	opts ...baseService.OpOption,
 ) (*appsv1.Deployment, error) {
-	clientSet, err := ds.cs.GetKubernetesClientSet(clusterID)
+	op := baseService.OpGet(opts...)
+	clientSet, err := ds.cs.GetKubernetesClientSet(clusterID, opts...)
 	if err != nil {
-		return nil, nil
+		return nil, err
 	}
 	createDeployment := &appsv1.Deployment{
 		ObjectMeta: metav1.ObjectMeta{
-			Name: deploymentPost.Name,
+			Name:      deploymentPost.Name,
 			Namespace: namespace,
 		},
 		Spec: deploymentPost.Spec,
 	}
-	return clientSet.Kubernetes().AppsV1().Deployments("default").Create(
-		ds.ctx,
+	return clientSet.Kubernetes().AppsV1().Deployments(namespace).Create(
+		op.ContextOr(ds.ctx),
 		createDeployment,
 		metav1.CreateOptions{
 			DryRun: []string{metav1.DryRunAll},
@@ -108,10 +124,11 @@ func (ds *deploymentService) CreateDeployment(
 	clusterID string,
 	namespace string,
 	deploymentPost coreModels.DeploymentPost,
-	opts ...baseService.OpOption) (*appsv1.Deployment,error) {
-	clientSet, err := ds.cs.GetKubernetesClientSet(clusterID)
+	opts ...baseService.OpOption) (*appsv1.Deployment, error) {
+	op := baseService.OpGet(opts...)
+	clientSet, err := ds.cs.GetKubernetesClientSet(clusterID, opts...)
 	if err != nil {
-		return nil,err
+		return nil, err
 	}
 	createDeployment := &appsv1.Deployment{
 		ObjectMeta: metav1.ObjectMeta{
@@ -119,69 +136,132 @@ func (ds *deploymentService) CreateDeployment(
 		},
 		Spec: deploymentPost.Spec,
 	}
-	deployment, err := clientSet.Kubernetes().AppsV1().Deployments(namespace).Create(ds.ctx, createDeployment, metav1.CreateOptions{})
+	deployment, err := clientSet.Kubernetes().AppsV1().Deployments(namespace).Create(op.ContextOr(ds.ctx), createDeployment, metav1.CreateOptions{})
 	if err != nil {
-		return nil,err
+		return nil, err
 	}
-	return deployment,nil
+	return deployment, nil
 }
 
+// ScaleDeployment Set the replicas of the deployment through the scale subresource
 func (ds *deploymentService) ScaleDeployment(
 	clusterID string,
 	namespace string,
 	deploymentID string,
-	replicas int32) error {
-	clientSet, err := ds.cs.GetKubernetesClientSet(clusterID)
-	if err != nil {
-		return err
-	}
-	scale,err := clientSet.Kubernetes().AppsV1().Deployments(namespace).GetScale(context.TODO(),deploymentID,metav1.GetOptions{})
+	replicas int32,
+	opts ...baseService.OpOption) error {
+	op := baseService.OpGet(opts...)
+	ctx := op.ContextOr(ds.ctx)
+	clientSet, err := ds.cs.GetKubernetesClientSet(clusterID, opts...)
 	if err != nil {
 		return err
 	}
-	scale.Spec.Replicas = replicas
-	_, err = clientSet.Kubernetes().AppsV1().Deployments(namespace).UpdateScale(ds.ctx, deploymentID, scale, metav1.UpdateOptions{})
-	if err != nil {
+	deployments := clientSet.Kubernetes().AppsV1().Deployments(namespace)
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		scale, err := deployments.GetScale(ctx, deploymentID, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		scale.Spec.Replicas = replicas
+		_, err = deployments.UpdateScale(ctx, deploymentID, scale, metav1.UpdateOptions{})
 		return err
-	}
-	return nil
+	})
 }
 
 func (ds *deploymentService) DeleteDeployment(
 	clusterID string,
 	namespace string,
 	deploymentID string,
-	) error {
-	clientSet, err := ds.cs.GetKubernetesClientSet(clusterID)
+	opts ...baseService.OpOption,
+) error {
+	op := baseService.OpGet(opts...)
+	clientSet, err := ds.cs.GetKubernetesClientSet(clusterID, opts...)
 	if err != nil {
 		return err
 	}
-	err = clientSet.Kubernetes().AppsV1().Deployments(namespace).Delete(ds.ctx,deploymentID,metav1.DeleteOptions{})
+	err = clientSet.Kubernetes().AppsV1().Deployments(namespace).Delete(op.ContextOr(ds.ctx), deploymentID, metav1.DeleteOptions{})
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
+// UpdateDeployment Replace the spec of the deployment, the labels and annotations are only
+// replaced when given, the update is retried on conflicts with the latest version
 func (ds *deploymentService) UpdateDeployment(
 	clusterID string,
 	namespace string,
 	deploymentID string,
 	deploymentPost coreModels.DeploymentPost,
-)(*appsv1.Deployment,error){
-	clientSet, err := ds.cs.GetKubernetesClientSet(clusterID)
+	opts ...baseService.OpOption,
+) (*appsv1.Deployment, error) {
+	op := baseService.OpGet(opts...)
+	ctx := op.ContextOr(ds.ctx)
+	clientSet, err := ds.cs.GetKubernetesClientSet(clusterID, opts...)
 	if err != nil {
-		return nil,err
+		return nil, err
 	}
-	updateDeployment := &appsv1.Deployment{
-		ObjectMeta: metav1.ObjectMeta{
-			Name: deploymentID,
-		},
-		Spec: deploymentPost.Spec,
+	deployments := clientSet.Kubernetes().AppsV1().Deployments(namespace)
+	var deployment *appsv1.Deployment
+	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		current, err := deployments.Get(ctx, deploymentID, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		current.Spec = deploymentPost.Spec
+		if deploymentPost.Labels != nil {
+			current.Labels = deploymentPost.Labels
+		}
+		if deploymentPost.Annotations != nil {
+			current.Annotations = deploymentPost.Annotations
+		}
+		deployment, err = deployments.Update(ctx, current, metav1.UpdateOptions{})
+		return err
+	})
+	if err != nil {
+		return nil, err
 	}
-	deployment,err := clientSet.Kubernetes().AppsV1().Deployments(namespace).Update(ds.ctx,updateDeployment,metav1.UpdateOptions{})
+	return deployment, nil
+}
+
+// PatchDeployment Patch the deployment with a strategic merge, merge or json patch
+func (ds *deploymentService) PatchDeployment(
+	clusterID string,
+	namespace string,
+	deploymentID string,
+	patchType types.PatchType,
+	data []byte,
+	opts ...baseService.OpOption,
+) (*appsv1.Deployment, error) {
+	op := baseService.OpGet(opts...)
+	clientSet, err := ds.cs.GetKubernetesClientSet(clusterID, opts...)
 	if err != nil {
-		return nil,err
+		return nil, err
 	}
-	return deployment,nil
+	return clientSet.Kubernetes().AppsV1().Deployments(namespace).Patch(op.ContextOr(ds.ctx),
+		deploymentID, patchType, data, metav1.PatchOptions{})
+}
+
+// RestartDeployment Roll out new pods of the deployment by stamping the pod template with the restart time
+func (ds *deploymentService) RestartDeployment(
+	clusterID string,
+	namespace string,
+	deploymentID string,
+	opts ...baseService.OpOption,
+) (*appsv1.Deployment, error) {
+	data := fmt.Sprintf(`{"spec":{"template":{"metadata":{"annotations":{%q:%q}}}}}`,
+		restartedAtAnnotation, time.Now().Format(time.RFC3339))
+	return ds.PatchDeployment(clusterID, namespace, deploymentID, types.StrategicMergePatchType, []byte(data), opts...)
+}
+
+// PauseDeployment Pause or resume the rollout of the deployment
+func (ds *deploymentService) PauseDeployment(
+	clusterID string,
+	namespace string,
+	deploymentID string,
+	paused bool,
+	opts ...baseService.OpOption,
+) (*appsv1.Deployment, error) {
+	data := fmt.Sprintf(`{"spec":{"paused":%t}}`, paused)
+	return ds.PatchDeployment(clusterID, namespace, deploymentID, types.StrategicMergePatchType, []byte(data), opts...)
 }
@@ -0,0 +1,200 @@
+package core
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	coreModels "muti-kube/models/core"
+	baseService "muti-kube/pkg/service"
+	"sort"
+	"strconv"
+
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	// revisionAnnotation the revision the deployment controller records on deployments and replica sets
+	revisionAnnotation = "deployment.kubernetes.io/revision"
+	// changeCauseAnnotation the reason of the change recorded by the user
+	changeCauseAnnotation = "kubernetes.io/change-cause"
+	// timedOutReason the reason of the Progressing condition once the progress deadline is exceeded
+	timedOutReason = "ProgressDeadlineExceeded"
+)
+
+// GetDeploymentRolloutStatus Obtain the progress of the latest rollout of the deployment
+func (ds *deploymentService) GetDeploymentRolloutStatus(
+	clusterID string,
+	namespace string,
+	deploymentID string,
+	opts ...baseService.OpOption,
+) (*coreModels.DeploymentRolloutStatus, error) {
+	deployment, err := ds.GetDeployment(clusterID, namespace, deploymentID, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return NewDeploymentRolloutStatus(deployment), nil
+}
+
+// GetDeploymentRevisions Obtain the revisions of the deployment from the replica sets it owns, latest first
+func (ds *deploymentService) GetDeploymentRevisions(
+	clusterID string,
+	namespace string,
+	deploymentID string,
+	opts ...baseService.OpOption,
+) ([]*coreModels.DeploymentRevision, error) {
+	op := baseService.OpGet(opts...)
+	clientSet, err := ds.cs.GetKubernetesClientSet(clusterID, opts...)
+	if err != nil {
+		return nil, err
+	}
+	ctx := op.ContextOr(ds.ctx)
+	deployment, err := clientSet.Kubernetes().AppsV1().Deployments(namespace).Get(ctx, deploymentID, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	selector, err := metav1.LabelSelectorAsSelector(deployment.Spec.Selector)
+	if err != nil {
+		return nil, err
+	}
+	replicaSets, err := clientSet.Kubernetes().AppsV1().ReplicaSets(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: selector.String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return NewDeploymentRevisions(deployment, replicaSets.Items), nil
+}
+
+// RollbackDeployment Roll the pod template of the deployment back to the one of the revision,
+// revision 0 rolls back to the revision before the current one
+func (ds *deploymentService) RollbackDeployment(
+	clusterID string,
+	namespace string,
+	deploymentID string,
+	revision int64,
+	opts ...baseService.OpOption,
+) (*appsv1.Deployment, error) {
+	deployment, err := ds.GetDeployment(clusterID, namespace, deploymentID, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if deployment.Spec.Paused {
+		return nil, errors.New("cannot rollback a paused deployment, resume it first")
+	}
+	revisions, err := ds.GetDeploymentRevisions(clusterID, namespace, deploymentID, opts...)
+	if err != nil {
+		return nil, err
+	}
+	target, err := findRollbackRevision(revisions, revision)
+	if err != nil {
+		return nil, err
+	}
+	if target.Current {
+		return deployment, nil
+	}
+	data, err := json.Marshal([]map[string]interface{}{{
+		"op":    "replace",
+		"path":  "/spec/template",
+		"value": podTemplateOf(target),
+	}})
+	if err != nil {
+		return nil, err
+	}
+	return ds.PatchDeployment(clusterID, namespace, deploymentID, types.JSONPatchType, data, opts...)
+}
+
+// NewDeploymentRolloutStatus Evaluate the rollout of the deployment the way kubectl rollout status does
+func NewDeploymentRolloutStatus(deployment *appsv1.Deployment) *coreModels.DeploymentRolloutStatus {
+	status := &coreModels.DeploymentRolloutStatus{
+		Revision:          revisionOf(deployment.ObjectMeta),
+		Replicas:          deployment.Status.Replicas,
+		UpdatedReplicas:   deployment.Status.UpdatedReplicas,
+		ReadyReplicas:     deployment.Status.ReadyReplicas,
+		AvailableReplicas: deployment.Status.AvailableReplicas,
+		Paused:            deployment.Spec.Paused,
+	}
+	if deployment.Generation > deployment.Status.ObservedGeneration {
+		status.Message = "waiting for deployment spec update to be observed"
+		return status
+	}
+	for _, condition := range deployment.Status.Conditions {
+		if condition.Type == appsv1.DeploymentProgressing && condition.Reason == timedOutReason {
+			status.Failed = true
+			status.Message = fmt.Sprintf("deployment %q exceeded its progress deadline", deployment.Name)
+			return status
+		}
+	}
+	desired := int32(1)
+	if deployment.Spec.Replicas != nil {
+		desired = *deployment.Spec.Replicas
+	}
+	switch {
+	case status.UpdatedReplicas < desired:
+		status.Message = fmt.Sprintf("waiting for rollout to finish: %d out of %d new replicas have been updated",
+			status.UpdatedReplicas, desired)
+	case status.Replicas > status.UpdatedReplicas:
+		status.Message = fmt.Sprintf("waiting for rollout to finish: %d old replicas are pending termination",
+			status.Replicas-status.UpdatedReplicas)
+	case status.AvailableReplicas < status.UpdatedReplicas:
+		status.Message = fmt.Sprintf("waiting for rollout to finish: %d of %d updated replicas are available",
+			status.AvailableReplicas, status.UpdatedReplicas)
+	default:
+		status.Done = true
+		status.Message = fmt.Sprintf("deployment %q successfully rolled out", deployment.Name)
+	}
+	return status
+}
+
+// NewDeploymentRevisions Build the revisions of the deployment from the replica sets controlled by it, latest first
+func NewDeploymentRevisions(deployment *appsv1.Deployment, replicaSets []appsv1.ReplicaSet) []*coreModels.DeploymentRevision {
+	current := revisionOf(deployment.ObjectMeta)
+	revisions := make([]*coreModels.DeploymentRevision, 0, len(replicaSets))
+	for i := range replicaSets {
+		replicaSet := &replicaSets[i]
+		if !metav1.IsControlledBy(replicaSet, deployment) {
+			continue
+		}
+		revision := revisionOf(replicaSet.ObjectMeta)
+		revisions = append(revisions, &coreModels.DeploymentRevision{
+			Revision:          revision,
+			ReplicaSet:        replicaSet.Name,
+			Replicas:          replicaSet.Status.Replicas,
+			ChangeCause:       replicaSet.Annotations[changeCauseAnnotation],
+			Current:           revision == current,
+			CreationTimestamp: replicaSet.CreationTimestamp,
+			Template:          replicaSet.Spec.Template,
+		})
+	}
+	sort.Slice(revisions, func(i, j int) bool {
+		return revisions[i].Revision > revisions[j].Revision
+	})
+	return revisions
+}
+
+// findRollbackRevision the revision to roll back to, 0 means the latest revision before the current one
+func findRollbackRevision(revisions []*coreModels.DeploymentRevision, revision int64) (*coreModels.DeploymentRevision, error) {
+	for _, item := range revisions {
+		if revision == 0 && !item.Current || revision != 0 && item.Revision == revision {
+			return item, nil
+		}
+	}
+	if revision == 0 {
+		return nil, errors.New("no previous revision to rollback to")
+	}
+	return nil, fmt.Errorf("revision %d not found", revision)
+}
+
+func revisionOf(meta metav1.ObjectMeta) int64 {
+	revision, _ := strconv.ParseInt(meta.Annotations[revisionAnnotation], 10, 64)
+	return revision
+}
+
+// podTemplateOf the pod template of the revision without the label added by the deployment controller
+func podTemplateOf(revision *coreModels.DeploymentRevision) *v1.PodTemplateSpec {
+	template := revision.Template.DeepCopy()
+	delete(template.Labels, appsv1.DefaultDeploymentUniqueLabelKey)
+	return template
+}
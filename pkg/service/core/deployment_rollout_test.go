@@ -0,0 +1,86 @@
+package core
+
+import (
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func newTestReplicaSet(name string, revision string, owner *appsv1.Deployment) appsv1.ReplicaSet {
+	replicaSet := appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Annotations: map[string]string{revisionAnnotation: revision},
+		},
+	}
+	if owner != nil {
+		controller := true
+		replicaSet.OwnerReferences = []metav1.OwnerReference{{
+			Kind: "Deployment", Name: owner.Name, UID: owner.UID, Controller: &controller,
+		}}
+	}
+	return replicaSet
+}
+
+func TestNewDeploymentRolloutStatus(t *testing.T) {
+	replicas := int32(3)
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Generation: 2},
+		Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+		Status: appsv1.DeploymentStatus{
+			ObservedGeneration: 2,
+			Replicas:           4,
+			UpdatedReplicas:    3,
+			AvailableReplicas:  3,
+		},
+	}
+	if status := NewDeploymentRolloutStatus(deployment); status.Done {
+		t.Errorf("rollout with old replicas pending should not be done: %s", status.Message)
+	}
+	deployment.Status.Replicas = 3
+	if status := NewDeploymentRolloutStatus(deployment); !status.Done {
+		t.Errorf("rollout should be done: %s", status.Message)
+	}
+	deployment.Generation = 3
+	if status := NewDeploymentRolloutStatus(deployment); status.Done {
+		t.Error("rollout of an unobserved generation should not be done")
+	}
+	deployment.Status.ObservedGeneration = 3
+	deployment.Status.Conditions = []appsv1.DeploymentCondition{{
+		Type: appsv1.DeploymentProgressing, Reason: timedOutReason,
+	}}
+	if status := NewDeploymentRolloutStatus(deployment); !status.Failed {
+		t.Error("rollout exceeding its progress deadline should fail")
+	}
+}
+
+func TestDeploymentRevisions(t *testing.T) {
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "web",
+			UID:         types.UID("web-uid"),
+			Annotations: map[string]string{revisionAnnotation: "3"},
+		},
+	}
+	revisions := NewDeploymentRevisions(deployment, []appsv1.ReplicaSet{
+		newTestReplicaSet("web-1", "1", deployment),
+		newTestReplicaSet("web-3", "3", deployment),
+		newTestReplicaSet("web-2", "2", deployment),
+		newTestReplicaSet("other", "9", nil),
+	})
+	if len(revisions) != 3 {
+		t.Fatalf("revisions = %d, want 3", len(revisions))
+	}
+	if revisions[0].Revision != 3 || !revisions[0].Current {
+		t.Errorf("latest revision = %d current %t, want 3 current", revisions[0].Revision, revisions[0].Current)
+	}
+	previous, err := findRollbackRevision(revisions, 0)
+	if err != nil || previous.Revision != 2 {
+		t.Errorf("previous revision = %v %v, want 2", previous, err)
+	}
+	if _, err = findRollbackRevision(revisions, 5); err == nil {
+		t.Error("rollback to a missing revision should fail")
+	}
+}
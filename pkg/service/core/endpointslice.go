@@ -0,0 +1,70 @@
+package core
+
+import (
+	"context"
+	coreModels "muti-kube/models/core"
+	baseService "muti-kube/pkg/service"
+	"muti-kube/pkg/service/cluster"
+	"muti-kube/pkg/util"
+
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+type endpointSliceService struct {
+	ctx context.Context
+	cs  cluster.Interface
+}
+
+// EndpointSliceInterface endpointslices are managed by the cluster, they are read only
+type EndpointSliceInterface interface {
+	GetEndpointSlices(clusterID string, namespace string, endpointSliceQuery coreModels.EndpointSliceQuery, opts ...baseService.OpOption) ([]discoveryv1.EndpointSlice, *int64, error)
+	GetEndpointSlice(clusterID string, namespace string, endpointSliceID string, opts ...baseService.OpOption) (*discoveryv1.EndpointSlice, error)
+}
+
+func NewEndpointSlice() (EndpointSliceInterface, error) {
+	clusterService, err := cluster.NewClusterService()
+	if err != nil {
+		return nil, err
+	}
+	return &endpointSliceService{
+		ctx: context.Background(),
+		cs:  clusterService,
+	}, nil
+}
+
+// GetEndpointSlices Obtain the endpointslices of the namespace, only those of the service when given
+func (es *endpointSliceService) GetEndpointSlices(clusterID string, namespace string,
+	endpointSliceQuery coreModels.EndpointSliceQuery, opts ...baseService.OpOption) ([]discoveryv1.EndpointSlice, *int64, error) {
+	op := baseService.OpGet(opts...)
+	clientSet, err := es.cs.GetKubernetesClientSet(clusterID, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+	listOptions := metav1.ListOptions{}
+	if endpointSliceQuery.Service != "" {
+		listOptions.LabelSelector = labels.SelectorFromSet(labels.Set{
+			discoveryv1.LabelServiceName: endpointSliceQuery.Service,
+		}).String()
+	}
+	list, err := clientSet.Kubernetes().DiscoveryV1().EndpointSlices(namespace).List(op.ContextOr(es.ctx), listOptions)
+	if err != nil {
+		return nil, nil, err
+	}
+	count := util.ConvertToInt64Ptr(len(list.Items))
+	offset, end := baseService.CommonPaginate(list.Items,
+		(op.Pagination.Page-1)*op.Pagination.PageSize,
+		op.Pagination.PageSize)
+	return list.Items[offset:end], count, nil
+}
+
+func (es *endpointSliceService) GetEndpointSlice(clusterID string, namespace string,
+	endpointSliceID string, opts ...baseService.OpOption) (*discoveryv1.EndpointSlice, error) {
+	op := baseService.OpGet(opts...)
+	clientSet, err := es.cs.GetKubernetesClientSet(clusterID, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return clientSet.Kubernetes().DiscoveryV1().EndpointSlices(namespace).Get(op.ContextOr(es.ctx), endpointSliceID, metav1.GetOptions{})
+}
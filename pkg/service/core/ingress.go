@@ -0,0 +1,141 @@
+package core
+
+import (
+	"context"
+	coreModels "muti-kube/models/core"
+	baseService "muti-kube/pkg/service"
+	"muti-kube/pkg/service/cluster"
+	"muti-kube/pkg/util"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+)
+
+type ingressService struct {
+	ctx context.Context
+	cs  cluster.Interface
+}
+
+type IngressInterface interface {
+	GetIngresses(clusterID string, namespace string, opts ...baseService.OpOption) ([]networkingv1.Ingress, *int64, error)
+	GetIngress(clusterID string, namespace string, ingressID string, opts ...baseService.OpOption) (*networkingv1.Ingress, error)
+	CreateIngress(clusterID string, namespace string, ingressPost coreModels.IngressPost, opts ...baseService.OpOption) (*networkingv1.Ingress, error)
+	DryRunIngress(clusterID string, namespace string, ingressPost coreModels.IngressPost, opts ...baseService.OpOption) (*networkingv1.Ingress, error)
+	UpdateIngress(clusterID string, namespace string, ingressID string, ingressPost coreModels.IngressPost, opts ...baseService.OpOption) (*networkingv1.Ingress, error)
+	DeleteIngress(clusterID string, namespace string, ingressID string, opts ...baseService.OpOption) error
+}
+
+func NewIngress() (IngressInterface, error) {
+	clusterService, err := cluster.NewClusterService()
+	if err != nil {
+		return nil, err
+	}
+	return &ingressService{
+		ctx: context.Background(),
+		cs:  clusterService,
+	}, nil
+}
+
+func (is *ingressService) GetIngresses(clusterID string,
+	namespace string, opts ...baseService.OpOption) ([]networkingv1.Ingress, *int64, error) {
+	op := baseService.OpGet(opts...)
+	clientSet, err := is.cs.GetKubernetesClientSet(clusterID, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+	list, err := clientSet.Kubernetes().NetworkingV1().Ingresses(namespace).List(op.ContextOr(is.ctx), metav1.ListOptions{})
+	if err != nil {
+		return nil, nil, err
+	}
+	count := util.ConvertToInt64Ptr(len(list.Items))
+	offset, end := baseService.CommonPaginate(list.Items,
+		(op.Pagination.Page-1)*op.Pagination.PageSize,
+		op.Pagination.PageSize)
+	return list.Items[offset:end], count, nil
+}
+
+func (is *ingressService) GetIngress(clusterID string, namespace string,
+	ingressID string, opts ...baseService.OpOption) (*networkingv1.Ingress, error) {
+	op := baseService.OpGet(opts...)
+	clientSet, err := is.cs.GetKubernetesClientSet(clusterID, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return clientSet.Kubernetes().NetworkingV1().Ingresses(namespace).Get(op.ContextOr(is.ctx), ingressID, metav1.GetOptions{})
+}
+
+func (is *ingressService) CreateIngress(clusterID string, namespace string,
+	ingressPost coreModels.IngressPost, opts ...baseService.OpOption) (*networkingv1.Ingress, error) {
+	return is.createIngress(clusterID, namespace, ingressPost, metav1.CreateOptions{}, opts...)
+}
+
+func (is *ingressService) DryRunIngress(clusterID string, namespace string,
+	ingressPost coreModels.IngressPost, opts ...baseService.OpOption) (*networkingv1.Ingress, error) {
+	return is.createIngress(clusterID, namespace, ingressPost, metav1.CreateOptions{
+		DryRun: []string{metav1.DryRunAll},
+	}, opts...)
+}
+
+func (is *ingressService) createIngress(clusterID string, namespace string,
+	ingressPost coreModels.IngressPost, createOptions metav1.CreateOptions,
+	opts ...baseService.OpOption) (*networkingv1.Ingress, error) {
+	op := baseService.OpGet(opts...)
+	clientSet, err := is.cs.GetKubernetesClientSet(clusterID, opts...)
+	if err != nil {
+		return nil, err
+	}
+	ingress := &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        ingressPost.Name,
+			Namespace:   namespace,
+			Labels:      ingressPost.Labels,
+			Annotations: ingressPost.Annotations,
+		},
+		Spec: ingressPost.Spec,
+	}
+	return clientSet.Kubernetes().NetworkingV1().Ingresses(namespace).Create(op.ContextOr(is.ctx), ingress, createOptions)
+}
+
+// UpdateIngress Replace the spec of the ingress, the labels and annotations are only
+// replaced when given, the update is retried on conflicts with the latest version
+func (is *ingressService) UpdateIngress(clusterID string, namespace string, ingressID string,
+	ingressPost coreModels.IngressPost, opts ...baseService.OpOption) (*networkingv1.Ingress, error) {
+	op := baseService.OpGet(opts...)
+	ctx := op.ContextOr(is.ctx)
+	clientSet, err := is.cs.GetKubernetesClientSet(clusterID, opts...)
+	if err != nil {
+		return nil, err
+	}
+	ingresses := clientSet.Kubernetes().NetworkingV1().Ingresses(namespace)
+	var ingress *networkingv1.Ingress
+	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		current, err := ingresses.Get(ctx, ingressID, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		current.Spec = ingressPost.Spec
+		if ingressPost.Labels != nil {
+			current.Labels = ingressPost.Labels
+		}
+		if ingressPost.Annotations != nil {
+			current.Annotations = ingressPost.Annotations
+		}
+		ingress, err = ingresses.Update(ctx, current, metav1.UpdateOptions{})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ingress, nil
+}
+
+func (is *ingressService) DeleteIngress(clusterID string, namespace string,
+	ingressID string, opts ...baseService.OpOption) error {
+	op := baseService.OpGet(opts...)
+	clientSet, err := is.cs.GetKubernetesClientSet(clusterID, opts...)
+	if err != nil {
+		return err
+	}
+	return clientSet.Kubernetes().NetworkingV1().Ingresses(namespace).Delete(op.ContextOr(is.ctx), ingressID, metav1.DeleteOptions{})
+}
@@ -0,0 +1,114 @@
+package core
+
+import (
+	"context"
+	coreModels "muti-kube/models/core"
+	baseService "muti-kube/pkg/service"
+	"muti-kube/pkg/service/cluster"
+	"muti-kube/pkg/util"
+
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// backgroundPropagation jobs orphan their pods unless the deletion is propagated
+var backgroundPropagation = metav1.DeletePropagationBackground
+
+type jobService struct {
+	ctx context.Context
+	cs  cluster.Interface
+}
+
+// JobInterface the pod template of a job is immutable, a job is replaced by deleting and creating it
+type JobInterface interface {
+	GetJobs(clusterID string, namespace string, opts ...baseService.OpOption) ([]batchv1.Job, *int64, error)
+	GetJob(clusterID string, namespace string, jobID string, opts ...baseService.OpOption) (*batchv1.Job, error)
+	CreateJob(clusterID string, namespace string, jobPost coreModels.JobPost, opts ...baseService.OpOption) (*batchv1.Job, error)
+	DryRunJob(clusterID string, namespace string, jobPost coreModels.JobPost, opts ...baseService.OpOption) (*batchv1.Job, error)
+	DeleteJob(clusterID string, namespace string, jobID string, opts ...baseService.OpOption) error
+}
+
+func NewJob() (JobInterface, error) {
+	clusterService, err := cluster.NewClusterService()
+	if err != nil {
+		return nil, err
+	}
+	return &jobService{
+		ctx: context.Background(),
+		cs:  clusterService,
+	}, nil
+}
+
+func (js *jobService) GetJobs(clusterID string,
+	namespace string, opts ...baseService.OpOption) ([]batchv1.Job, *int64, error) {
+	op := baseService.OpGet(opts...)
+	clientSet, err := js.cs.GetKubernetesClientSet(clusterID, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+	list, err := clientSet.Kubernetes().BatchV1().Jobs(namespace).List(op.ContextOr(js.ctx), metav1.ListOptions{})
+	if err != nil {
+		return nil, nil, err
+	}
+	count := util.ConvertToInt64Ptr(len(list.Items))
+	offset, end := baseService.CommonPaginate(list.Items,
+		(op.Pagination.Page-1)*op.Pagination.PageSize,
+		op.Pagination.PageSize)
+	return list.Items[offset:end], count, nil
+}
+
+func (js *jobService) GetJob(clusterID string, namespace string,
+	jobID string, opts ...baseService.OpOption) (*batchv1.Job, error) {
+	op := baseService.OpGet(opts...)
+	clientSet, err := js.cs.GetKubernetesClientSet(clusterID, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return clientSet.Kubernetes().BatchV1().Jobs(namespace).Get(op.ContextOr(js.ctx), jobID, metav1.GetOptions{})
+}
+
+func (js *jobService) CreateJob(clusterID string, namespace string,
+	jobPost coreModels.JobPost, opts ...baseService.OpOption) (*batchv1.Job, error) {
+	return js.createJob(clusterID, namespace, jobPost, metav1.CreateOptions{}, opts...)
+}
+
+func (js *jobService) DryRunJob(clusterID string, namespace string,
+	jobPost coreModels.JobPost, opts ...baseService.OpOption) (*batchv1.Job, error) {
+	return js.createJob(clusterID, namespace, jobPost, metav1.CreateOptions{
+		DryRun: []string{metav1.DryRunAll},
+	}, opts...)
+}
+
+func (js *jobService) createJob(clusterID string, namespace string,
+	jobPost coreModels.JobPost, createOptions metav1.CreateOptions,
+	opts ...baseService.OpOption) (*batchv1.Job, error) {
+	op := baseService.OpGet(opts...)
+	clientSet, err := js.cs.GetKubernetesClientSet(clusterID, opts...)
+	if err != nil {
+		return nil, err
+	}
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:         jobPost.Name,
+			GenerateName: jobPost.GenerateName,
+			Namespace:    namespace,
+			Labels:       jobPost.Labels,
+			Annotations:  jobPost.Annotations,
+		},
+		Spec: jobPost.Spec,
+	}
+	return clientSet.Kubernetes().BatchV1().Jobs(namespace).Create(op.ContextOr(js.ctx), job, createOptions)
+}
+
+// DeleteJob Delete the job together with its pods
+func (js *jobService) DeleteJob(clusterID string, namespace string,
+	jobID string, opts ...baseService.OpOption) error {
+	op := baseService.OpGet(opts...)
+	clientSet, err := js.cs.GetKubernetesClientSet(clusterID, opts...)
+	if err != nil {
+		return err
+	}
+	return clientSet.Kubernetes().BatchV1().Jobs(namespace).Delete(op.ContextOr(js.ctx), jobID, metav1.DeleteOptions{
+		PropagationPolicy: &backgroundPropagation,
+	})
+}
@@ -0,0 +1,261 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	coreModels "muti-kube/models/core"
+	baseService "muti-kube/pkg/service"
+	"muti-kube/pkg/service/cluster"
+	"muti-kube/pkg/util"
+	"sort"
+	"strings"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/util/retry"
+)
+
+const (
+	// namespaceMetricPrefix only the namespace level metrics can be queried for a namespace
+	namespaceMetricPrefix = "namespace_"
+	// namespaceQuotaName the name of the resource quota and limit range created with a namespace
+	namespaceQuotaName = "default"
+)
+
+// defaultNamespaceMetrics the metrics of the namespace summary when none is given
+var defaultNamespaceMetrics = []string{
+	"namespace_cpu_usage",
+	"namespace_memory_usage_wo_cache",
+	"namespace_pod_count",
+	"namespace_pod_abnormal_count",
+	"namespace_net_bytes_transmitted",
+	"namespace_net_bytes_received",
+}
+
+type namespaceService struct {
+	ctx context.Context
+	cs  cluster.Interface
+}
+
+type NamespaceInterface interface {
+	GetNamespaces(clusterID string, opts ...baseService.OpOption) ([]v1.Namespace, *int64, error)
+	GetNamespace(clusterID string, namespace string, opts ...baseService.OpOption) (*v1.Namespace, error)
+	CreateNamespace(clusterID string, namespacePost coreModels.NamespacePost, opts ...baseService.OpOption) (*v1.Namespace, error)
+	DryRunNamespace(clusterID string, namespacePost coreModels.NamespacePost, opts ...baseService.OpOption) (*v1.Namespace, error)
+	UpdateNamespace(clusterID string, namespace string, namespaceUpdate coreModels.NamespaceUpdate, opts ...baseService.OpOption) (*v1.Namespace, error)
+	DeleteNamespace(clusterID string, namespace string, opts ...baseService.OpOption) error
+	GetNamespaceSummary(clusterID string, namespace string, summaryQuery coreModels.NamespaceSummaryQuery, opts ...baseService.OpOption) (*coreModels.NamespaceSummary, error)
+}
+
+func NewNamespace() (NamespaceInterface, error) {
+	clusterService, err := cluster.NewClusterService()
+	if err != nil {
+		return nil, err
+	}
+	return &namespaceService{
+		ctx: context.Background(),
+		cs:  clusterService,
+	}, nil
+}
+
+func (ns *namespaceService) GetNamespaces(clusterID string, opts ...baseService.OpOption) ([]v1.Namespace, *int64, error) {
+	op := baseService.OpGet(opts...)
+	clientSet, err := ns.cs.GetKubernetesClientSet(clusterID, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+	list, err := clientSet.Kubernetes().CoreV1().Namespaces().List(op.ContextOr(ns.ctx), metav1.ListOptions{})
+	if err != nil {
+		return nil, nil, err
+	}
+	count := util.ConvertToInt64Ptr(len(list.Items))
+	offset, end := baseService.CommonPaginate(list.Items,
+		(op.Pagination.Page-1)*op.Pagination.PageSize,
+		op.Pagination.PageSize)
+	return list.Items[offset:end], count, nil
+}
+
+func (ns *namespaceService) GetNamespace(clusterID string, namespace string,
+	opts ...baseService.OpOption) (*v1.Namespace, error) {
+	op := baseService.OpGet(opts...)
+	clientSet, err := ns.cs.GetKubernetesClientSet(clusterID, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return clientSet.Kubernetes().CoreV1().Namespaces().Get(op.ContextOr(ns.ctx), namespace, metav1.GetOptions{})
+}
+
+func (ns *namespaceService) CreateNamespace(clusterID string,
+	namespacePost coreModels.NamespacePost, opts ...baseService.OpOption) (*v1.Namespace, error) {
+	return ns.createNamespace(clusterID, namespacePost, metav1.CreateOptions{}, opts...)
+}
+
+// DryRunNamespace Validate the namespace, the resource quota and limit range are not validated
+// as they can not be dry-run in a namespace not created yet
+func (ns *namespaceService) DryRunNamespace(clusterID string,
+	namespacePost coreModels.NamespacePost, opts ...baseService.OpOption) (*v1.Namespace, error) {
+	return ns.createNamespace(clusterID, namespacePost, metav1.CreateOptions{
+		DryRun: []string{metav1.DryRunAll},
+	}, opts...)
+}
+
+// createNamespace Create the namespace with its resource quota and limit range,
+// the namespace is deleted again when they can not be created
+func (ns *namespaceService) createNamespace(clusterID string, namespacePost coreModels.NamespacePost,
+	createOptions metav1.CreateOptions, opts ...baseService.OpOption) (*v1.Namespace, error) {
+	op := baseService.OpGet(opts...)
+	ctx := op.ContextOr(ns.ctx)
+	clientSet, err := ns.cs.GetKubernetesClientSet(clusterID, opts...)
+	if err != nil {
+		return nil, err
+	}
+	namespace, err := clientSet.Kubernetes().CoreV1().Namespaces().Create(ctx, &v1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        namespacePost.Name,
+			Labels:      namespacePost.Labels,
+			Annotations: namespacePost.Annotations,
+		},
+	}, createOptions)
+	if err != nil || len(createOptions.DryRun) > 0 {
+		return namespace, err
+	}
+	if err := createNamespaceQuota(ctx, clientSet.Kubernetes().CoreV1(), namespacePost); err != nil {
+		if deleteErr := clientSet.Kubernetes().CoreV1().Namespaces().Delete(ctx, namespace.Name, metav1.DeleteOptions{}); deleteErr != nil {
+			return nil, fmt.Errorf("%v, and delete namespace %s failed: %v", err, namespace.Name, deleteErr)
+		}
+		return nil, err
+	}
+	return namespace, nil
+}
+
+func createNamespaceQuota(ctx context.Context,
+	client typedcorev1.CoreV1Interface, namespacePost coreModels.NamespacePost) error {
+	if namespacePost.ResourceQuota != nil {
+		_, err := client.ResourceQuotas(namespacePost.Name).Create(ctx, &v1.ResourceQuota{
+			ObjectMeta: metav1.ObjectMeta{Name: namespaceQuotaName, Namespace: namespacePost.Name},
+			Spec:       *namespacePost.ResourceQuota,
+		}, metav1.CreateOptions{})
+		if err != nil {
+			return fmt.Errorf("create resource quota: %v", err)
+		}
+	}
+	if namespacePost.LimitRange != nil {
+		_, err := client.LimitRanges(namespacePost.Name).Create(ctx, &v1.LimitRange{
+			ObjectMeta: metav1.ObjectMeta{Name: namespaceQuotaName, Namespace: namespacePost.Name},
+			Spec:       *namespacePost.LimitRange,
+		}, metav1.CreateOptions{})
+		if err != nil {
+			return fmt.Errorf("create limit range: %v", err)
+		}
+	}
+	return nil
+}
+
+// UpdateNamespace Replace the labels and annotations of the namespace, they are kept when not given
+func (ns *namespaceService) UpdateNamespace(clusterID string, namespace string,
+	namespaceUpdate coreModels.NamespaceUpdate, opts ...baseService.OpOption) (*v1.Namespace, error) {
+	op := baseService.OpGet(opts...)
+	ctx := op.ContextOr(ns.ctx)
+	clientSet, err := ns.cs.GetKubernetesClientSet(clusterID, opts...)
+	if err != nil {
+		return nil, err
+	}
+	namespaces := clientSet.Kubernetes().CoreV1().Namespaces()
+	var updated *v1.Namespace
+	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		current, err := namespaces.Get(ctx, namespace, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		if namespaceUpdate.Labels != nil {
+			current.Labels = namespaceUpdate.Labels
+		}
+		if namespaceUpdate.Annotations != nil {
+			current.Annotations = namespaceUpdate.Annotations
+		}
+		updated, err = namespaces.Update(ctx, current, metav1.UpdateOptions{})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+// DeleteNamespace Delete the namespace and everything in it
+func (ns *namespaceService) DeleteNamespace(clusterID string, namespace string, opts ...baseService.OpOption) error {
+	op := baseService.OpGet(opts...)
+	clientSet, err := ns.cs.GetKubernetesClientSet(clusterID, opts...)
+	if err != nil {
+		return err
+	}
+	return clientSet.Kubernetes().CoreV1().Namespaces().Delete(op.ContextOr(ns.ctx), namespace, metav1.DeleteOptions{})
+}
+
+// GetNamespaceSummary Obtain the namespace with the usage of its quotas, its limit ranges and
+// the current values of its namespace_* metrics
+func (ns *namespaceService) GetNamespaceSummary(clusterID string, namespace string,
+	summaryQuery coreModels.NamespaceSummaryQuery, opts ...baseService.OpOption) (*coreModels.NamespaceSummary, error) {
+	metrics := summaryQuery.Metrics
+	if len(metrics) == 0 {
+		metrics = defaultNamespaceMetrics
+	}
+	for _, metric := range metrics {
+		if !strings.HasPrefix(metric, namespaceMetricPrefix) {
+			return nil, fmt.Errorf("metric %s is not a namespace metric", metric)
+		}
+	}
+	op := baseService.OpGet(opts...)
+	ctx := op.ContextOr(ns.ctx)
+	clientSet, err := ns.cs.GetKubernetesClientSet(clusterID, opts...)
+	if err != nil {
+		return nil, err
+	}
+	summary := &coreModels.NamespaceSummary{}
+	summary.Namespace, err = clientSet.Kubernetes().CoreV1().Namespaces().Get(ctx, namespace, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	quotas, err := clientSet.Kubernetes().CoreV1().ResourceQuotas(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	summary.Quotas = NewQuotaUsages(quotas.Items)
+	limitRanges, err := clientSet.Kubernetes().CoreV1().LimitRanges(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	summary.LimitRanges = limitRanges.Items
+	summary.Metrics, err = ns.cs.GetNamespaceMetric(metrics, clusterID, namespace, time.Now())
+	if err != nil {
+		summary.MetricsError = err.Error()
+	}
+	return summary, nil
+}
+
+// NewQuotaUsages the used and hard amounts of the resources of each quota sorted by resource
+func NewQuotaUsages(quotas []v1.ResourceQuota) []coreModels.QuotaUsage {
+	usages := make([]coreModels.QuotaUsage, 0, len(quotas))
+	for _, quota := range quotas {
+		usage := coreModels.QuotaUsage{Name: quota.Name, Resources: make([]coreModels.ResourceUsage, 0, len(quota.Status.Hard))}
+		for resource, hard := range quota.Status.Hard {
+			used := quota.Status.Used[resource]
+			resourceUsage := coreModels.ResourceUsage{
+				Resource: string(resource),
+				Hard:     hard.String(),
+				Used:     used.String(),
+			}
+			if !hard.IsZero() {
+				resourceUsage.Ratio = float64(used.MilliValue()) / float64(hard.MilliValue())
+			}
+			usage.Resources = append(usage.Resources, resourceUsage)
+		}
+		sort.Slice(usage.Resources, func(i, j int) bool {
+			return usage.Resources[i].Resource < usage.Resources[j].Resource
+		})
+		usages = append(usages, usage)
+	}
+	return usages
+}
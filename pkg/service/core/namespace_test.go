@@ -0,0 +1,40 @@
+package core
+
+import (
+	coreModels "muti-kube/models/core"
+	"reflect"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNewQuotaUsages(t *testing.T) {
+	quota := v1.ResourceQuota{
+		ObjectMeta: metav1.ObjectMeta{Name: "default"},
+		Status: v1.ResourceQuotaStatus{
+			Hard: v1.ResourceList{
+				v1.ResourceRequestsCPU:    resource.MustParse("2"),
+				v1.ResourceRequestsMemory: resource.MustParse("4Gi"),
+				v1.ResourcePods:           resource.MustParse("0"),
+			},
+			Used: v1.ResourceList{
+				v1.ResourceRequestsCPU:    resource.MustParse("500m"),
+				v1.ResourceRequestsMemory: resource.MustParse("1Gi"),
+			},
+		},
+	}
+	usages := NewQuotaUsages([]v1.ResourceQuota{quota})
+	expected := []coreModels.QuotaUsage{{
+		Name: "default",
+		Resources: []coreModels.ResourceUsage{
+			{Resource: "pods", Hard: "0", Used: "0", Ratio: 0},
+			{Resource: "requests.cpu", Hard: "2", Used: "500m", Ratio: 0.25},
+			{Resource: "requests.memory", Hard: "4Gi", Used: "1Gi", Ratio: 0.25},
+		},
+	}}
+	if !reflect.DeepEqual(usages, expected) {
+		t.Errorf("unexpected usages %v", usages)
+	}
+}
@@ -2,26 +2,244 @@ package core
 
 import (
 	"context"
+	"fmt"
+	"io"
+	coreModels "muti-kube/models/core"
 	"muti-kube/pkg/client/k8s"
+	baseService "muti-kube/pkg/service"
+	"muti-kube/pkg/service/cluster"
+	"muti-kube/pkg/util"
+	"sort"
 
+	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/client-go/kubernetes"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/tools/remotecommand"
 )
 
+// defaultLogTailLines the lines of logs returned when neither tail lines nor since seconds is given
+const defaultLogTailLines int64 = 1000
+
 type podService struct {
-	client kubernetes.Interface
+	ctx context.Context
+	cs  cluster.Interface
+}
+
+type PodInterface interface {
+	GetPods(clusterID string, namespace string, podQuery coreModels.PodQuery, opts ...baseService.OpOption) ([]v1.Pod, *int64, error)
+	GetPod(clusterID string, namespace string, podID string, opts ...baseService.OpOption) (*v1.Pod, error)
+	DeletePod(clusterID string, namespace string, podID string, podDelete coreModels.PodDelete, opts ...baseService.OpOption) error
+	GetPodLogs(clusterID string, namespace string, podID string, logQuery coreModels.PodLogQuery, opts ...baseService.OpOption) (string, error)
+	GetPodEvents(clusterID string, namespace string, podID string, opts ...baseService.OpOption) ([]v1.Event, error)
+	StreamPodLogs(clusterID string, namespace string, podID string, logQuery coreModels.PodLogQuery, opts ...baseService.OpOption) (io.ReadCloser, error)
+	ExecPod(clusterID string, namespace string, podID string, execQuery coreModels.PodExecQuery, streams remotecommand.StreamOptions, opts ...baseService.OpOption) error
 }
 
-func NewPodService() *podService {
-	client, err := k8s.NewKubernetesClient(&k8s.KubernetesOptions{KubeConfig: "~/.kube/config"})
+func NewPod() (PodInterface, error) {
+	clusterService, err := cluster.NewClusterService()
 	if err != nil {
-		return nil
+		return nil, err
 	}
 	return &podService{
-		client: client.Kubernetes(),
+		ctx: context.Background(),
+		cs:  clusterService,
+	}, nil
+}
+
+// GetPods Obtain the pods of the namespace matching the selectors, and owned by the workload when given
+func (ps *podService) GetPods(clusterID string, namespace string,
+	podQuery coreModels.PodQuery, opts ...baseService.OpOption) ([]v1.Pod, *int64, error) {
+	op := baseService.OpGet(opts...)
+	ctx := op.ContextOr(ps.ctx)
+	clientSet, err := ps.cs.GetKubernetesClientSet(clusterID, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+	list, err := clientSet.Kubernetes().CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: podQuery.LabelSelector,
+		FieldSelector: podQuery.FieldSelector,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	items := list.Items
+	if podQuery.OwnerKind != "" && podQuery.OwnerName != "" {
+		ownerKind, ownerNames, err := ps.podOwners(ctx, clientSet, namespace, podQuery.OwnerKind, podQuery.OwnerName)
+		if err != nil {
+			return nil, nil, err
+		}
+		items = FilterPodsByOwner(items, ownerKind, ownerNames)
+	}
+	count := util.ConvertToInt64Ptr(len(items))
+	if op.Pagination != nil {
+		offset, end := baseService.CommonPaginate(items,
+			(op.Pagination.Page-1)*op.Pagination.PageSize,
+			op.Pagination.PageSize)
+		items = items[offset:end]
+	}
+	return items, count, nil
+}
+
+func (ps *podService) GetPod(clusterID string, namespace string,
+	podID string, opts ...baseService.OpOption) (*v1.Pod, error) {
+	op := baseService.OpGet(opts...)
+	clientSet, err := ps.cs.GetKubernetesClientSet(clusterID, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return clientSet.Kubernetes().CoreV1().Pods(namespace).Get(op.ContextOr(ps.ctx), podID, metav1.GetOptions{})
+}
+
+func (ps *podService) DeletePod(clusterID string, namespace string,
+	podID string, podDelete coreModels.PodDelete, opts ...baseService.OpOption) error {
+	op := baseService.OpGet(opts...)
+	clientSet, err := ps.cs.GetKubernetesClientSet(clusterID, opts...)
+	if err != nil {
+		return err
+	}
+	return clientSet.Kubernetes().CoreV1().Pods(namespace).Delete(op.ContextOr(ps.ctx), podID, metav1.DeleteOptions{
+		GracePeriodSeconds: podDelete.GracePeriodSeconds,
+	})
+}
+
+// GetPodLogs Read the logs of the container of the pod, the last 1000 lines by default
+func (ps *podService) GetPodLogs(clusterID string, namespace string,
+	podID string, logQuery coreModels.PodLogQuery, opts ...baseService.OpOption) (string, error) {
+	if err := validatePodLogQuery(logQuery); err != nil {
+		return "", err
+	}
+	op := baseService.OpGet(opts...)
+	clientSet, err := ps.cs.GetKubernetesClientSet(clusterID, opts...)
+	if err != nil {
+		return "", err
+	}
+	// following is only served by the stream, the response would never end
+	logQuery.Follow = false
+	logs, err := clientSet.Kubernetes().CoreV1().Pods(namespace).GetLogs(podID, NewPodLogOptions(logQuery)).
+		DoRaw(op.ContextOr(ps.ctx))
+	if err != nil {
+		return "", err
+	}
+	return string(logs), nil
+}
+
+// GetPodEvents Obtain the events of the pod, the latest first
+func (ps *podService) GetPodEvents(clusterID string, namespace string,
+	podID string, opts ...baseService.OpOption) ([]v1.Event, error) {
+	op := baseService.OpGet(opts...)
+	clientSet, err := ps.cs.GetKubernetesClientSet(clusterID, opts...)
+	if err != nil {
+		return nil, err
+	}
+	list, err := clientSet.Kubernetes().CoreV1().Events(namespace).List(op.ContextOr(ps.ctx), metav1.ListOptions{
+		FieldSelector: fields.Set{
+			"involvedObject.kind": "Pod",
+			"involvedObject.name": podID,
+		}.AsSelector().String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	events := list.Items
+	sort.SliceStable(events, func(i, j int) bool {
+		return eventTime(&events[i]).After(eventTime(&events[j]).Time)
+	})
+	return events, nil
+}
+
+// podOwners the kind and names of the controllers of the pods of the workload,
+// Deployments and CronJobs control their pods through ReplicaSets and Jobs
+func (ps *podService) podOwners(ctx context.Context, clientSet k8s.Client,
+	namespace string, ownerKind string, ownerName string) (string, map[string]struct{}, error) {
+	names := make(map[string]struct{})
+	switch ownerKind {
+	case "Deployment":
+		replicaSets, err := clientSet.Kubernetes().AppsV1().ReplicaSets(namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return "", nil, err
+		}
+		for i := range replicaSets.Items {
+			if isControlledBy(&replicaSets.Items[i].ObjectMeta, ownerKind, ownerName) {
+				names[replicaSets.Items[i].Name] = struct{}{}
+			}
+		}
+		return "ReplicaSet", names, nil
+	case "CronJob":
+		jobs, err := clientSet.Kubernetes().BatchV1().Jobs(namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return "", nil, err
+		}
+		for i := range jobs.Items {
+			if isControlledBy(&jobs.Items[i].ObjectMeta, ownerKind, ownerName) {
+				names[jobs.Items[i].Name] = struct{}{}
+			}
+		}
+		return "Job", names, nil
+	default:
+		names[ownerName] = struct{}{}
+		return ownerKind, names, nil
+	}
+}
+
+// FilterPodsByOwner the pods controlled by an owner of the kind with one of the names
+func FilterPodsByOwner(pods []v1.Pod, ownerKind string, ownerNames map[string]struct{}) []v1.Pod {
+	filtered := make([]v1.Pod, 0, len(pods))
+	for i := range pods {
+		ref := metav1.GetControllerOf(&pods[i])
+		if ref == nil || ref.Kind != ownerKind {
+			continue
+		}
+		if _, ok := ownerNames[ref.Name]; ok {
+			filtered = append(filtered, pods[i])
+		}
+	}
+	return filtered
+}
+
+// NewPodLogOptions Build the log options of the query, the last 1000 lines are read unless limited otherwise
+func NewPodLogOptions(logQuery coreModels.PodLogQuery) *v1.PodLogOptions {
+	options := &v1.PodLogOptions{
+		Container:    logQuery.Container,
+		TailLines:    logQuery.TailLines,
+		SinceSeconds: logQuery.SinceSeconds,
+		Previous:     logQuery.Previous,
+		Timestamps:   logQuery.Timestamps,
+		LimitBytes:   logQuery.LimitBytes,
+		Follow:       logQuery.Follow,
+	}
+	if options.TailLines == nil && options.SinceSeconds == nil {
+		tailLines := defaultLogTailLines
+		options.TailLines = &tailLines
+	}
+	return options
+}
+
+func isControlledBy(meta *metav1.ObjectMeta, kind string, name string) bool {
+	ref := metav1.GetControllerOfNoCopy(meta)
+	return ref != nil && ref.Kind == kind && ref.Name == name
+}
+
+// eventTime the time the event was last seen
+func eventTime(event *v1.Event) metav1.Time {
+	if !event.LastTimestamp.IsZero() {
+		return event.LastTimestamp
+	}
+	if !event.EventTime.IsZero() {
+		return metav1.NewTime(event.EventTime.Time)
 	}
+	return event.CreationTimestamp
 }
 
-func (ps *podService) GetPodList() {
-	ps.client.CoreV1().Pods("default").List(context.Background(), metav1.ListOptions{})
+// validatePodLogQuery reject negative limits before they reach the api server
+func validatePodLogQuery(logQuery coreModels.PodLogQuery) error {
+	for name, value := range map[string]*int64{
+		"tail_lines":    logQuery.TailLines,
+		"since_seconds": logQuery.SinceSeconds,
+		"limit_bytes":   logQuery.LimitBytes,
+	} {
+		if value != nil && *value < 0 {
+			return fmt.Errorf("%s must not be negative", name)
+		}
+	}
+	return nil
 }
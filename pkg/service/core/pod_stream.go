@@ -0,0 +1,107 @@
+package core
+
+import (
+	"context"
+	"io"
+	coreModels "muti-kube/models/core"
+	baseService "muti-kube/pkg/service"
+	"net/http"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/httpstream"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/remotecommand"
+	"k8s.io/client-go/transport/spdy"
+)
+
+// defaultExecCommand the command run when the exec query has none
+var defaultExecCommand = []string{"/bin/sh"}
+
+// StreamPodLogs Open the log stream of the container, the stream is closed by the caller
+// or when the context of the op is done
+func (ps *podService) StreamPodLogs(clusterID string, namespace string,
+	podID string, logQuery coreModels.PodLogQuery, opts ...baseService.OpOption) (io.ReadCloser, error) {
+	if err := validatePodLogQuery(logQuery); err != nil {
+		return nil, err
+	}
+	op := baseService.OpGet(opts...)
+	clientSet, err := ps.cs.GetKubernetesClientSet(clusterID, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return clientSet.Kubernetes().CoreV1().Pods(namespace).GetLogs(podID, NewPodLogOptions(logQuery)).
+		Stream(op.ContextOr(ps.ctx))
+}
+
+// ExecPod Run the command in the container over SPDY, blocks until the command exits, stdin is closed
+// or the context of the op is done
+func (ps *podService) ExecPod(clusterID string, namespace string, podID string,
+	execQuery coreModels.PodExecQuery, streams remotecommand.StreamOptions, opts ...baseService.OpOption) error {
+	op := baseService.OpGet(opts...)
+	ctx := op.ContextOr(ps.ctx)
+	clientSet, err := ps.cs.GetKubernetesClientSet(clusterID, opts...)
+	if err != nil {
+		return err
+	}
+	req := clientSet.Kubernetes().CoreV1().RESTClient().Post().
+		Resource("pods").
+		Namespace(namespace).
+		Name(podID).
+		SubResource("exec").
+		VersionedParams(NewPodExecOptions(execQuery, streams), scheme.ParameterCodec)
+	transport, upgrader, err := spdy.RoundTripperFor(clientSet.Config())
+	if err != nil {
+		return err
+	}
+	executor, err := remotecommand.NewSPDYExecutorForTransports(transport,
+		&contextUpgrader{Upgrader: upgrader, ctx: ctx}, "POST", req.URL())
+	if err != nil {
+		return err
+	}
+	if err := executor.Stream(streams); err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return err
+	}
+	return nil
+}
+
+// contextUpgrader closes the connections it upgrades once the context is done, the executor of
+// client-go v0.24 streams until the command exits and would keep the session open after the client left
+type contextUpgrader struct {
+	spdy.Upgrader
+	ctx context.Context
+}
+
+func (u *contextUpgrader) NewConnection(resp *http.Response) (httpstream.Connection, error) {
+	conn, err := u.Upgrader.NewConnection(resp)
+	if err != nil {
+		return nil, err
+	}
+	go func() {
+		select {
+		case <-u.ctx.Done():
+			conn.Close()
+		case <-conn.CloseChan():
+		}
+	}()
+	return conn, nil
+}
+
+// NewPodExecOptions Build the exec options of the query, only the streams given are attached
+func NewPodExecOptions(execQuery coreModels.PodExecQuery, streams remotecommand.StreamOptions) *v1.PodExecOptions {
+	command := execQuery.Command
+	if len(command) == 0 {
+		command = defaultExecCommand
+	}
+	return &v1.PodExecOptions{
+		Container: execQuery.Container,
+		Command:   command,
+		Stdin:     streams.Stdin != nil,
+		Stdout:    streams.Stdout != nil,
+		// stderr is merged into stdout by a tty
+		Stderr: streams.Stderr != nil && !execQuery.TTY,
+		TTY:    execQuery.TTY,
+	}
+}
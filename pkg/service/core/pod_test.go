@@ -0,0 +1,85 @@
+package core
+
+import (
+	"context"
+	coreModels "muti-kube/models/core"
+	"net/http"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/httpstream"
+)
+
+func TestFilterPodsByOwner(t *testing.T) {
+	controller := true
+	podOf := func(name string, kind string, owner string) v1.Pod {
+		pod := v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name}}
+		if owner != "" {
+			pod.OwnerReferences = []metav1.OwnerReference{{Kind: kind, Name: owner, Controller: &controller}}
+		}
+		return pod
+	}
+	pods := []v1.Pod{
+		podOf("web-1", "ReplicaSet", "web-5d4f"),
+		podOf("web-2", "ReplicaSet", "web-7c9b"),
+		podOf("api-1", "ReplicaSet", "api-6b8d"),
+		podOf("db-0", "StatefulSet", "web-5d4f"),
+		podOf("static", "", ""),
+	}
+	filtered := FilterPodsByOwner(pods, "ReplicaSet", map[string]struct{}{"web-5d4f": {}, "web-7c9b": {}})
+	if len(filtered) != 2 || filtered[0].Name != "web-1" || filtered[1].Name != "web-2" {
+		t.Errorf("unexpected pods %v", filtered)
+	}
+}
+
+func TestNewPodLogOptions(t *testing.T) {
+	options := NewPodLogOptions(coreModels.PodLogQuery{Container: "app"})
+	if options.TailLines == nil || *options.TailLines != defaultLogTailLines {
+		t.Errorf("tail lines should default to %d", defaultLogTailLines)
+	}
+	sinceSeconds := int64(60)
+	options = NewPodLogOptions(coreModels.PodLogQuery{Container: "app", SinceSeconds: &sinceSeconds, Previous: true})
+	if options.TailLines != nil || !options.Previous {
+		t.Errorf("unexpected options %v", options)
+	}
+}
+
+// closeConnection records the close of the upgraded connection
+type closeConnection struct {
+	httpstream.Connection
+	closed chan bool
+}
+
+func (c *closeConnection) Close() error {
+	close(c.closed)
+	return nil
+}
+
+func (c *closeConnection) CloseChan() <-chan bool {
+	return c.closed
+}
+
+type connectionUpgrader struct {
+	conn *closeConnection
+}
+
+func (u *connectionUpgrader) NewConnection(*http.Response) (httpstream.Connection, error) {
+	return u.conn, nil
+}
+
+func TestContextUpgrader(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	conn := &closeConnection{closed: make(chan bool)}
+	upgrader := &contextUpgrader{Upgrader: &connectionUpgrader{conn: conn}, ctx: ctx}
+	if _, err := upgrader.NewConnection(&http.Response{}); err != nil {
+		t.Fatal(err)
+	}
+	cancel()
+	select {
+	case <-conn.closed:
+	case <-time.After(time.Second):
+		t.Error("the connection is kept open after the context is done")
+	}
+}
@@ -0,0 +1,344 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	coreModels "muti-kube/models/core"
+	"muti-kube/pkg/client/k8s"
+	baseService "muti-kube/pkg/service"
+	"muti-kube/pkg/service/cluster"
+	"muti-kube/pkg/util"
+	"sort"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
+)
+
+// FieldManager the field manager of the objects applied through muti-kube
+const FieldManager = "muti-kube"
+
+type dynamicResourceService struct {
+	ctx context.Context
+	cs  cluster.Interface
+}
+
+// DynamicResourceInterface reads and writes the objects of any resource discovered in the member cluster,
+// including custom resources, the group of the legacy api group is core
+type DynamicResourceInterface interface {
+	GetAPIResources(clusterID string, query coreModels.APIResourceQuery, opts ...baseService.OpOption) (*coreModels.APIResourceList, error)
+	GetResources(clusterID string, gvr schema.GroupVersionResource, namespace string,
+		query coreModels.ResourceQuery, opts ...baseService.OpOption) ([]unstructured.Unstructured, *int64, error)
+	GetResource(clusterID string, gvr schema.GroupVersionResource, namespace string, name string,
+		opts ...baseService.OpOption) (*unstructured.Unstructured, error)
+	CreateResource(clusterID string, gvr schema.GroupVersionResource, namespace string,
+		object *unstructured.Unstructured, opts ...baseService.OpOption) (*unstructured.Unstructured, error)
+	DryRunResource(clusterID string, gvr schema.GroupVersionResource, namespace string,
+		object *unstructured.Unstructured, opts ...baseService.OpOption) (*unstructured.Unstructured, error)
+	ApplyResource(clusterID string, gvr schema.GroupVersionResource, namespace string, name string,
+		object *unstructured.Unstructured, apply coreModels.ResourceApply, opts ...baseService.OpOption) (*unstructured.Unstructured, error)
+	PatchResource(clusterID string, gvr schema.GroupVersionResource, namespace string, name string,
+		patchType types.PatchType, data []byte, opts ...baseService.OpOption) (*unstructured.Unstructured, error)
+	DeleteResource(clusterID string, gvr schema.GroupVersionResource, namespace string, name string,
+		resourceDelete coreModels.ResourceDelete, opts ...baseService.OpOption) error
+}
+
+func NewDynamicResource() (DynamicResourceInterface, error) {
+	clusterService, err := cluster.NewClusterService()
+	if err != nil {
+		return nil, err
+	}
+	return &dynamicResourceService{
+		ctx: context.Background(),
+		cs:  clusterService,
+	}, nil
+}
+
+// GroupVersionResourceOf the resource of the path, the group core is the legacy api group
+func GroupVersionResourceOf(group string, version string, resource string) schema.GroupVersionResource {
+	if group == coreModels.CoreGroup {
+		group = ""
+	}
+	return schema.GroupVersionResource{Group: group, Version: version, Resource: resource}
+}
+
+// GetAPIResources Obtain the resources served by the cluster, the discovery is cached
+// until a resource is not found
+func (ds *dynamicResourceService) GetAPIResources(clusterID string,
+	query coreModels.APIResourceQuery, opts ...baseService.OpOption) (*coreModels.APIResourceList, error) {
+	clientSet, err := ds.cs.GetKubernetesClientSet(clusterID, opts...)
+	if err != nil {
+		return nil, err
+	}
+	groups, lists, err := clientSet.Discovery().ServerGroupsAndResources()
+	failedGroups := make(map[string]string)
+	if err != nil {
+		groupErr := &discovery.ErrGroupDiscoveryFailed{}
+		if !errors.As(err, &groupErr) {
+			return nil, err
+		}
+		for groupVersion, groupVersionErr := range groupErr.Groups {
+			failedGroups[groupVersion.String()] = groupVersionErr.Error()
+		}
+	}
+	return &coreModels.APIResourceList{
+		Resources:    FilterAPIResources(NewAPIResources(groups, lists), query),
+		FailedGroups: failedGroups,
+	}, nil
+}
+
+// NewAPIResources flatten the discovered resources, subresources are left out
+func NewAPIResources(groups []*metav1.APIGroup, lists []*metav1.APIResourceList) []coreModels.APIResource {
+	preferred := make(map[string]string, len(groups))
+	for _, group := range groups {
+		preferred[group.Name] = group.PreferredVersion.Version
+	}
+	resources := make([]coreModels.APIResource, 0)
+	for _, list := range lists {
+		groupVersion, err := schema.ParseGroupVersion(list.GroupVersion)
+		if err != nil {
+			continue
+		}
+		group := groupVersion.Group
+		if group == "" {
+			group = coreModels.CoreGroup
+		}
+		for _, resource := range list.APIResources {
+			if strings.Contains(resource.Name, "/") {
+				continue
+			}
+			resources = append(resources, coreModels.APIResource{
+				Group:      group,
+				Version:    groupVersion.Version,
+				Resource:   resource.Name,
+				Kind:       resource.Kind,
+				Namespaced: resource.Namespaced,
+				Verbs:      resource.Verbs,
+				ShortNames: resource.ShortNames,
+				Categories: resource.Categories,
+				Preferred:  preferred[groupVersion.Group] == groupVersion.Version,
+			})
+		}
+	}
+	sort.SliceStable(resources, func(i, j int) bool {
+		if resources[i].Group != resources[j].Group {
+			return resources[i].Group < resources[j].Group
+		}
+		if resources[i].Version != resources[j].Version {
+			return resources[i].Version < resources[j].Version
+		}
+		return resources[i].Resource < resources[j].Resource
+	})
+	return resources
+}
+
+// FilterAPIResources the resources matching the query
+func FilterAPIResources(resources []coreModels.APIResource, query coreModels.APIResourceQuery) []coreModels.APIResource {
+	filtered := make([]coreModels.APIResource, 0, len(resources))
+	for _, resource := range resources {
+		if query.Group != "" && resource.Group != query.Group {
+			continue
+		}
+		if query.Namespaced != nil && resource.Namespaced != *query.Namespaced {
+			continue
+		}
+		if query.Preferred && !resource.Preferred {
+			continue
+		}
+		filtered = append(filtered, resource)
+	}
+	return filtered
+}
+
+func (ds *dynamicResourceService) GetResources(clusterID string, gvr schema.GroupVersionResource, namespace string,
+	query coreModels.ResourceQuery, opts ...baseService.OpOption) ([]unstructured.Unstructured, *int64, error) {
+	op := baseService.OpGet(opts...)
+	resources, err := ds.resourceClient(clusterID, gvr, namespace, "", "list", opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+	list, err := resources.List(op.ContextOr(ds.ctx), metav1.ListOptions{
+		LabelSelector: query.LabelSelector,
+		FieldSelector: query.FieldSelector,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	count := util.ConvertToInt64Ptr(len(list.Items))
+	offset, end := baseService.CommonPaginate(list.Items,
+		(op.Pagination.Page-1)*op.Pagination.PageSize,
+		op.Pagination.PageSize)
+	return list.Items[offset:end], count, nil
+}
+
+func (ds *dynamicResourceService) GetResource(clusterID string, gvr schema.GroupVersionResource, namespace string,
+	name string, opts ...baseService.OpOption) (*unstructured.Unstructured, error) {
+	op := baseService.OpGet(opts...)
+	resources, err := ds.resourceClient(clusterID, gvr, namespace, name, "get", opts...)
+	if err != nil {
+		return nil, err
+	}
+	return resources.Get(op.ContextOr(ds.ctx), name, metav1.GetOptions{})
+}
+
+func (ds *dynamicResourceService) CreateResource(clusterID string, gvr schema.GroupVersionResource, namespace string,
+	object *unstructured.Unstructured, opts ...baseService.OpOption) (*unstructured.Unstructured, error) {
+	return ds.createResource(clusterID, gvr, namespace, object, metav1.CreateOptions{}, opts...)
+}
+
+func (ds *dynamicResourceService) DryRunResource(clusterID string, gvr schema.GroupVersionResource, namespace string,
+	object *unstructured.Unstructured, opts ...baseService.OpOption) (*unstructured.Unstructured, error) {
+	return ds.createResource(clusterID, gvr, namespace, object, metav1.CreateOptions{
+		DryRun: []string{metav1.DryRunAll},
+	}, opts...)
+}
+
+func (ds *dynamicResourceService) createResource(clusterID string, gvr schema.GroupVersionResource, namespace string,
+	object *unstructured.Unstructured, createOptions metav1.CreateOptions,
+	opts ...baseService.OpOption) (*unstructured.Unstructured, error) {
+	op := baseService.OpGet(opts...)
+	if object.GetNamespace() != "" && object.GetNamespace() != namespace {
+		return nil, fmt.Errorf("the namespace %q of the object does not match the namespace %q of the path",
+			object.GetNamespace(), namespace)
+	}
+	resources, err := ds.resourceClient(clusterID, gvr, namespace, "", "create", opts...)
+	if err != nil {
+		return nil, err
+	}
+	if namespace != "" {
+		object.SetNamespace(namespace)
+	}
+	return resources.Create(op.ContextOr(ds.ctx), object, createOptions)
+}
+
+// ApplyResource Create or update the object with server side apply, only the fields of the object are managed by muti-kube
+func (ds *dynamicResourceService) ApplyResource(clusterID string, gvr schema.GroupVersionResource, namespace string,
+	name string, object *unstructured.Unstructured, apply coreModels.ResourceApply,
+	opts ...baseService.OpOption) (*unstructured.Unstructured, error) {
+	op := baseService.OpGet(opts...)
+	if object.GetName() != "" && object.GetName() != name {
+		return nil, fmt.Errorf("the name %q of the object does not match the name %q of the path", object.GetName(), name)
+	}
+	if object.GetNamespace() != "" && object.GetNamespace() != namespace {
+		return nil, fmt.Errorf("the namespace %q of the object does not match the namespace %q of the path",
+			object.GetNamespace(), namespace)
+	}
+	resources, err := ds.resourceClient(clusterID, gvr, namespace, name, "patch", opts...)
+	if err != nil {
+		return nil, err
+	}
+	object.SetName(name)
+	if namespace != "" {
+		object.SetNamespace(namespace)
+	}
+	// the server rejects applied objects carrying managed fields
+	object.SetManagedFields(nil)
+	data, err := json.Marshal(object)
+	if err != nil {
+		return nil, err
+	}
+	return resources.Patch(op.ContextOr(ds.ctx), name, types.ApplyPatchType, data, metav1.PatchOptions{
+		FieldManager: FieldManager,
+		Force:        &apply.Force,
+	})
+}
+
+// PatchResource Patch the object with a json patch, merge patch or strategic merge patch,
+// strategic merge patches are only supported by the built-in resources
+func (ds *dynamicResourceService) PatchResource(clusterID string, gvr schema.GroupVersionResource, namespace string,
+	name string, patchType types.PatchType, data []byte, opts ...baseService.OpOption) (*unstructured.Unstructured, error) {
+	op := baseService.OpGet(opts...)
+	switch patchType {
+	case types.JSONPatchType, types.MergePatchType, types.StrategicMergePatchType:
+	default:
+		return nil, fmt.Errorf("unsupported patch type %q", patchType)
+	}
+	resources, err := ds.resourceClient(clusterID, gvr, namespace, name, "patch", opts...)
+	if err != nil {
+		return nil, err
+	}
+	return resources.Patch(op.ContextOr(ds.ctx), name, patchType, data, metav1.PatchOptions{
+		FieldManager: FieldManager,
+	})
+}
+
+func (ds *dynamicResourceService) DeleteResource(clusterID string, gvr schema.GroupVersionResource, namespace string,
+	name string, resourceDelete coreModels.ResourceDelete, opts ...baseService.OpOption) error {
+	op := baseService.OpGet(opts...)
+	deleteOptions := metav1.DeleteOptions{}
+	if resourceDelete.PropagationPolicy != "" {
+		policy := metav1.DeletionPropagation(resourceDelete.PropagationPolicy)
+		switch policy {
+		case metav1.DeletePropagationOrphan, metav1.DeletePropagationBackground, metav1.DeletePropagationForeground:
+		default:
+			return fmt.Errorf("unsupported propagation policy %q", policy)
+		}
+		deleteOptions.PropagationPolicy = &policy
+	}
+	resources, err := ds.resourceClient(clusterID, gvr, namespace, name, "delete", opts...)
+	if err != nil {
+		return err
+	}
+	return resources.Delete(op.ContextOr(ds.ctx), name, deleteOptions)
+}
+
+// resourceClient the dynamic client of the resource, the resource must be discovered in the cluster,
+// support the verb and be addressed with a namespace only when it is namespaced
+func (ds *dynamicResourceService) resourceClient(clusterID string, gvr schema.GroupVersionResource,
+	namespace string, name string, verb string, opts ...baseService.OpOption) (dynamic.ResourceInterface, error) {
+	clientSet, err := ds.cs.GetKubernetesClientSet(clusterID, opts...)
+	if err != nil {
+		return nil, err
+	}
+	apiResource, err := discoverResource(clientSet, gvr)
+	if err != nil {
+		return nil, err
+	}
+	if !util.ContainsString(apiResource.Verbs, verb) {
+		return nil, fmt.Errorf("resource %s does not support %s", gvr.String(), verb)
+	}
+	if !apiResource.Namespaced && namespace != "" {
+		return nil, fmt.Errorf("resource %s is not namespaced", gvr.String())
+	}
+	if apiResource.Namespaced && namespace == "" && (name != "" || verb == "create") {
+		return nil, fmt.Errorf("resource %s is namespaced, the namespace is required", gvr.String())
+	}
+	if apiResource.Namespaced && namespace != "" {
+		return clientSet.Dynamic().Resource(gvr).Namespace(namespace), nil
+	}
+	return clientSet.Dynamic().Resource(gvr), nil
+}
+
+// discoverResource find the resource in the cached discovery, the cache is refreshed once
+// when the resource is missing as it may have been installed since
+func discoverResource(clientSet k8s.Client, gvr schema.GroupVersionResource) (*metav1.APIResource, error) {
+	find := func() (*metav1.APIResource, error) {
+		list, err := clientSet.Discovery().ServerResourcesForGroupVersion(gvr.GroupVersion().String())
+		if err != nil {
+			return nil, err
+		}
+		for i := range list.APIResources {
+			if list.APIResources[i].Name == gvr.Resource {
+				return &list.APIResources[i], nil
+			}
+		}
+		return nil, apierrors.NewNotFound(gvr.GroupResource(), "")
+	}
+	apiResource, err := find()
+	if err == nil || !apierrors.IsNotFound(err) {
+		return apiResource, err
+	}
+	clientSet.Discovery().Invalidate()
+	apiResource, err = find()
+	if apierrors.IsNotFound(err) {
+		return nil, fmt.Errorf("the cluster does not serve the resource %s", gvr.String())
+	}
+	return apiResource, err
+}
@@ -0,0 +1,50 @@
+package core
+
+import (
+	coreModels "muti-kube/models/core"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNewAPIResources(t *testing.T) {
+	groups := []*metav1.APIGroup{
+		{Name: "", PreferredVersion: metav1.GroupVersionForDiscovery{Version: "v1"}},
+		{Name: "autoscaling", PreferredVersion: metav1.GroupVersionForDiscovery{Version: "v2"}},
+	}
+	lists := []*metav1.APIResourceList{
+		{GroupVersion: "v1", APIResources: []metav1.APIResource{
+			{Name: "pods", Kind: "Pod", Namespaced: true},
+			{Name: "pods/log", Kind: "Pod", Namespaced: true},
+			{Name: "nodes", Kind: "Node"},
+		}},
+		{GroupVersion: "autoscaling/v1", APIResources: []metav1.APIResource{
+			{Name: "horizontalpodautoscalers", Kind: "HorizontalPodAutoscaler", Namespaced: true},
+		}},
+		{GroupVersion: "autoscaling/v2", APIResources: []metav1.APIResource{
+			{Name: "horizontalpodautoscalers", Kind: "HorizontalPodAutoscaler", Namespaced: true},
+		}},
+	}
+	resources := NewAPIResources(groups, lists)
+	if len(resources) != 4 {
+		t.Fatalf("subresources should be left out, got %v", resources)
+	}
+	if resources[0].Group != "autoscaling" || resources[2].Group != coreModels.CoreGroup {
+		t.Errorf("resources should be sorted by group with the legacy group named core, got %v", resources)
+	}
+
+	namespaced := true
+	filtered := FilterAPIResources(resources, coreModels.APIResourceQuery{Namespaced: &namespaced, Preferred: true})
+	if len(filtered) != 2 || filtered[0].Version != "v2" || filtered[1].Resource != "pods" {
+		t.Errorf("unexpected filtered resources %v", filtered)
+	}
+}
+
+func TestGroupVersionResourceOf(t *testing.T) {
+	if gvr := GroupVersionResourceOf(coreModels.CoreGroup, "v1", "pods"); gvr.Group != "" {
+		t.Errorf("core should be the legacy group, got %v", gvr)
+	}
+	if gvr := GroupVersionResourceOf("apps", "v1", "deployments"); gvr.Group != "apps" {
+		t.Errorf("unexpected group version resource %v", gvr)
+	}
+}
@@ -0,0 +1,305 @@
+package core
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	coreModels "muti-kube/models/core"
+	baseService "muti-kube/pkg/service"
+	"muti-kube/pkg/service/cluster"
+	"muti-kube/pkg/util"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/util/retry"
+)
+
+// lastAppliedAnnotation kubectl apply keeps the whole secret including the data in it
+const lastAppliedAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+
+type secretService struct {
+	ctx context.Context
+	cs  cluster.Interface
+}
+
+// SecretInterface the values of the secrets returned are redacted unless revealed
+type SecretInterface interface {
+	GetSecrets(clusterID string, namespace string, opts ...baseService.OpOption) ([]coreModels.Secret, *int64, error)
+	GetSecret(clusterID string, namespace string, secretID string, opts ...baseService.OpOption) (*coreModels.Secret, error)
+	RevealSecret(clusterID string, namespace string, secretID string, opts ...baseService.OpOption) (*coreModels.Secret, error)
+	CreateSecret(clusterID string, namespace string, secretPost coreModels.SecretPost, opts ...baseService.OpOption) (*coreModels.Secret, error)
+	DryRunSecret(clusterID string, namespace string, secretPost coreModels.SecretPost, opts ...baseService.OpOption) (*coreModels.Secret, error)
+	CreateDockerRegistrySecret(clusterID string, namespace string, secretPost coreModels.DockerRegistrySecretPost, opts ...baseService.OpOption) (*coreModels.Secret, error)
+	CreateTLSSecret(clusterID string, namespace string, secretPost coreModels.TLSSecretPost, opts ...baseService.OpOption) (*coreModels.Secret, error)
+	UpdateSecret(clusterID string, namespace string, secretID string, secretPost coreModels.SecretPost, opts ...baseService.OpOption) (*coreModels.SecretUpdate, error)
+	DeleteSecret(clusterID string, namespace string, secretID string, opts ...baseService.OpOption) error
+}
+
+func NewSecret() (SecretInterface, error) {
+	clusterService, err := cluster.NewClusterService()
+	if err != nil {
+		return nil, err
+	}
+	return &secretService{
+		ctx: context.Background(),
+		cs:  clusterService,
+	}, nil
+}
+
+func (ss *secretService) GetSecrets(clusterID string,
+	namespace string, opts ...baseService.OpOption) ([]coreModels.Secret, *int64, error) {
+	op := baseService.OpGet(opts...)
+	clientSet, err := ss.cs.GetKubernetesClientSet(clusterID, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+	list, err := clientSet.Kubernetes().CoreV1().Secrets(namespace).List(op.ContextOr(ss.ctx), metav1.ListOptions{})
+	if err != nil {
+		return nil, nil, err
+	}
+	count := util.ConvertToInt64Ptr(len(list.Items))
+	offset, end := baseService.CommonPaginate(list.Items,
+		(op.Pagination.Page-1)*op.Pagination.PageSize,
+		op.Pagination.PageSize)
+	secrets := make([]coreModels.Secret, 0, end-offset)
+	for i := range list.Items[offset:end] {
+		secrets = append(secrets, *RedactSecret(&list.Items[offset+i]))
+	}
+	return secrets, count, nil
+}
+
+func (ss *secretService) GetSecret(clusterID string, namespace string,
+	secretID string, opts ...baseService.OpOption) (*coreModels.Secret, error) {
+	secret, err := ss.getSecret(clusterID, namespace, secretID, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return RedactSecret(secret), nil
+}
+
+// RevealSecret Obtain the secret with its values, the caller checks the permission to reveal
+func (ss *secretService) RevealSecret(clusterID string, namespace string,
+	secretID string, opts ...baseService.OpOption) (*coreModels.Secret, error) {
+	secret, err := ss.getSecret(clusterID, namespace, secretID, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &coreModels.Secret{Secret: *secret}, nil
+}
+
+func (ss *secretService) getSecret(clusterID string, namespace string,
+	secretID string, opts ...baseService.OpOption) (*v1.Secret, error) {
+	op := baseService.OpGet(opts...)
+	clientSet, err := ss.cs.GetKubernetesClientSet(clusterID, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return clientSet.Kubernetes().CoreV1().Secrets(namespace).Get(op.ContextOr(ss.ctx), secretID, metav1.GetOptions{})
+}
+
+func (ss *secretService) CreateSecret(clusterID string, namespace string,
+	secretPost coreModels.SecretPost, opts ...baseService.OpOption) (*coreModels.Secret, error) {
+	return ss.createSecret(clusterID, namespace, newSecret(namespace, secretPost), metav1.CreateOptions{}, opts...)
+}
+
+func (ss *secretService) DryRunSecret(clusterID string, namespace string,
+	secretPost coreModels.SecretPost, opts ...baseService.OpOption) (*coreModels.Secret, error) {
+	return ss.createSecret(clusterID, namespace, newSecret(namespace, secretPost), metav1.CreateOptions{
+		DryRun: []string{metav1.DryRunAll},
+	}, opts...)
+}
+
+func (ss *secretService) CreateDockerRegistrySecret(clusterID string, namespace string,
+	secretPost coreModels.DockerRegistrySecretPost, opts ...baseService.OpOption) (*coreModels.Secret, error) {
+	secret, err := NewDockerRegistrySecret(namespace, secretPost)
+	if err != nil {
+		return nil, err
+	}
+	return ss.createSecret(clusterID, namespace, secret, metav1.CreateOptions{}, opts...)
+}
+
+func (ss *secretService) CreateTLSSecret(clusterID string, namespace string,
+	secretPost coreModels.TLSSecretPost, opts ...baseService.OpOption) (*coreModels.Secret, error) {
+	secret, err := NewTLSSecret(namespace, secretPost)
+	if err != nil {
+		return nil, err
+	}
+	return ss.createSecret(clusterID, namespace, secret, metav1.CreateOptions{}, opts...)
+}
+
+func (ss *secretService) createSecret(clusterID string, namespace string, secret *v1.Secret,
+	createOptions metav1.CreateOptions, opts ...baseService.OpOption) (*coreModels.Secret, error) {
+	op := baseService.OpGet(opts...)
+	clientSet, err := ss.cs.GetKubernetesClientSet(clusterID, opts...)
+	if err != nil {
+		return nil, err
+	}
+	created, err := clientSet.Kubernetes().CoreV1().Secrets(namespace).Create(op.ContextOr(ss.ctx), secret, createOptions)
+	if err != nil {
+		return nil, err
+	}
+	return RedactSecret(created), nil
+}
+
+// UpdateSecret Replace the data of the secret and return the changed keys without values,
+// null values keep the current value, the type can not be changed
+func (ss *secretService) UpdateSecret(clusterID string, namespace string, secretID string,
+	secretPost coreModels.SecretPost, opts ...baseService.OpOption) (*coreModels.SecretUpdate, error) {
+	op := baseService.OpGet(opts...)
+	ctx := op.ContextOr(ss.ctx)
+	clientSet, err := ss.cs.GetKubernetesClientSet(clusterID, opts...)
+	if err != nil {
+		return nil, err
+	}
+	secrets := clientSet.Kubernetes().CoreV1().Secrets(namespace)
+	update := &coreModels.SecretUpdate{}
+	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		current, err := secrets.Get(ctx, secretID, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		old := current.Data
+		current.Data = MergeSecretData(current.Data, secretPost.Data, secretPost.StringData)
+		if secretPost.Labels != nil {
+			current.Labels = secretPost.Labels
+		}
+		if secretPost.Annotations != nil {
+			current.Annotations = secretPost.Annotations
+		}
+		updated, err := secrets.Update(ctx, current, metav1.UpdateOptions{})
+		if err != nil {
+			return err
+		}
+		update.Secret = RedactSecret(updated)
+		update.Changes = DiffData(old, updated.Data, false)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return update, nil
+}
+
+func (ss *secretService) DeleteSecret(clusterID string, namespace string,
+	secretID string, opts ...baseService.OpOption) error {
+	op := baseService.OpGet(opts...)
+	clientSet, err := ss.cs.GetKubernetesClientSet(clusterID, opts...)
+	if err != nil {
+		return err
+	}
+	return clientSet.Kubernetes().CoreV1().Secrets(namespace).Delete(op.ContextOr(ss.ctx), secretID, metav1.DeleteOptions{})
+}
+
+// RedactSecret a copy of the secret with the values of data cleared,
+// the last applied configuration of kubectl is dropped as it holds the values as well
+func RedactSecret(secret *v1.Secret) *coreModels.Secret {
+	redacted := secret.DeepCopy()
+	for key := range redacted.Data {
+		redacted.Data[key] = nil
+	}
+	redacted.StringData = nil
+	delete(redacted.Annotations, lastAppliedAnnotation)
+	return &coreModels.Secret{Secret: *redacted, Redacted: true}
+}
+
+// RedactSecretObject a copy of the secret of the dynamic resource api with the values cleared as RedactSecret does
+func RedactSecretObject(object *unstructured.Unstructured) *unstructured.Unstructured {
+	redacted := object.DeepCopy()
+	if data, ok := redacted.Object["data"].(map[string]interface{}); ok {
+		for key := range data {
+			data[key] = nil
+		}
+	}
+	unstructured.RemoveNestedField(redacted.Object, "stringData")
+	if annotations := redacted.GetAnnotations(); annotations != nil {
+		delete(annotations, lastAppliedAnnotation)
+		redacted.SetAnnotations(annotations)
+	}
+	return redacted
+}
+
+// MergeSecretData the data replacing the current one, null values keep the current value
+// and string data overrides data as the api server does
+func MergeSecretData(current map[string][]byte, data map[string][]byte, stringData map[string]string) map[string][]byte {
+	merged := make(map[string][]byte, len(data)+len(stringData))
+	for key, value := range data {
+		if value == nil {
+			if currentValue, ok := current[key]; ok {
+				value = currentValue
+			}
+		}
+		merged[key] = value
+	}
+	for key, value := range stringData {
+		merged[key] = []byte(value)
+	}
+	return merged
+}
+
+func newSecret(namespace string, secretPost coreModels.SecretPost) *v1.Secret {
+	return &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        secretPost.Name,
+			Namespace:   namespace,
+			Labels:      secretPost.Labels,
+			Annotations: secretPost.Annotations,
+		},
+		Immutable:  secretPost.Immutable,
+		Data:       secretPost.Data,
+		StringData: secretPost.StringData,
+		Type:       secretPost.Type,
+	}
+}
+
+// NewDockerRegistrySecret Build the dockerconfigjson secret of the registry credentials
+func NewDockerRegistrySecret(namespace string, secretPost coreModels.DockerRegistrySecretPost) (*v1.Secret, error) {
+	auth := map[string]interface{}{
+		"username": secretPost.Username,
+		"password": secretPost.Password,
+		"auth":     base64.StdEncoding.EncodeToString([]byte(secretPost.Username + ":" + secretPost.Password)),
+	}
+	if secretPost.Email != "" {
+		auth["email"] = secretPost.Email
+	}
+	dockerConfig, err := json.Marshal(map[string]interface{}{
+		"auths": map[string]interface{}{secretPost.Server: auth},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        secretPost.Name,
+			Namespace:   namespace,
+			Labels:      secretPost.Labels,
+			Annotations: secretPost.Annotations,
+		},
+		Type: v1.SecretTypeDockerConfigJson,
+		Data: map[string][]byte{
+			v1.DockerConfigJsonKey: dockerConfig,
+		},
+	}, nil
+}
+
+// NewTLSSecret Build the tls secret, the certificate and key must be a matching PEM encoded pair
+func NewTLSSecret(namespace string, secretPost coreModels.TLSSecretPost) (*v1.Secret, error) {
+	if _, err := tls.X509KeyPair([]byte(secretPost.Cert), []byte(secretPost.Key)); err != nil {
+		return nil, fmt.Errorf("invalid certificate and key: %v", err)
+	}
+	return &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        secretPost.Name,
+			Namespace:   namespace,
+			Labels:      secretPost.Labels,
+			Annotations: secretPost.Annotations,
+		},
+		Type: v1.SecretTypeTLS,
+		Data: map[string][]byte{
+			v1.TLSCertKey:       []byte(secretPost.Cert),
+			v1.TLSPrivateKeyKey: []byte(secretPost.Key),
+		},
+	}, nil
+}
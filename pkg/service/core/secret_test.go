@@ -0,0 +1,90 @@
+package core
+
+import (
+	"encoding/json"
+	coreModels "muti-kube/models/core"
+	"reflect"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestRedactSecret(t *testing.T) {
+	secret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "db",
+			Annotations: map[string]string{lastAppliedAnnotation: `{"data":{"password":"c2VjcmV0"}}`, "team": "dba"},
+		},
+		Data: map[string][]byte{"password": []byte("secret")},
+	}
+	redacted := RedactSecret(secret)
+	if !redacted.Redacted || redacted.Data["password"] != nil {
+		t.Errorf("values should be redacted, got %v", redacted.Data)
+	}
+	if _, ok := redacted.Data["password"]; !ok {
+		t.Error("keys should be kept")
+	}
+	if _, ok := redacted.Annotations[lastAppliedAnnotation]; ok || redacted.Annotations["team"] != "dba" {
+		t.Errorf("unexpected annotations %v", redacted.Annotations)
+	}
+	if string(secret.Data["password"]) != "secret" {
+		t.Error("the secret read should not be modified")
+	}
+	data, _ := json.Marshal(redacted)
+	if !reflect.DeepEqual(decodeData(t, data), map[string]interface{}{"password": nil}) {
+		t.Errorf("unexpected json %s", data)
+	}
+}
+
+func decodeData(t *testing.T, data []byte) map[string]interface{} {
+	secret := struct {
+		Data map[string]interface{} `json:"data"`
+	}{}
+	if err := json.Unmarshal(data, &secret); err != nil {
+		t.Fatal(err)
+	}
+	return secret.Data
+}
+
+func TestMergeSecretData(t *testing.T) {
+	current := map[string][]byte{"user": []byte("admin"), "password": []byte("old"), "token": []byte("t")}
+	merged := MergeSecretData(current,
+		map[string][]byte{"user": nil, "password": []byte("new")},
+		map[string]string{"host": "db"})
+	expected := map[string][]byte{"user": []byte("admin"), "password": []byte("new"), "host": []byte("db")}
+	if !reflect.DeepEqual(merged, expected) {
+		t.Errorf("unexpected data %v", merged)
+	}
+	changes := DiffData(current, merged, false)
+	expectedChanges := []coreModels.DataChange{
+		{Key: "host", Operation: coreModels.DataAdded},
+		{Key: "password", Operation: coreModels.DataChanged},
+		{Key: "token", Operation: coreModels.DataRemoved},
+	}
+	if !reflect.DeepEqual(changes, expectedChanges) {
+		t.Errorf("unexpected changes %v", changes)
+	}
+}
+
+func TestNewDockerRegistrySecret(t *testing.T) {
+	secret, err := NewDockerRegistrySecret("default", coreModels.DockerRegistrySecretPost{
+		Name: "registry", Server: "registry.example.com", Username: "user", Password: "pass",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if secret.Type != v1.SecretTypeDockerConfigJson {
+		t.Errorf("unexpected type %s", secret.Type)
+	}
+	expected := `{"auths":{"registry.example.com":{"auth":"dXNlcjpwYXNz","password":"pass","username":"user"}}}`
+	if string(secret.Data[v1.DockerConfigJsonKey]) != expected {
+		t.Errorf("unexpected docker config %s", secret.Data[v1.DockerConfigJsonKey])
+	}
+}
+
+func TestNewTLSSecret(t *testing.T) {
+	if _, err := NewTLSSecret("default", coreModels.TLSSecretPost{Name: "tls", Cert: "cert", Key: "key"}); err == nil {
+		t.Error("invalid certificate should be rejected")
+	}
+}
@@ -0,0 +1,241 @@
+package core
+
+import (
+	"context"
+	coreModels "muti-kube/models/core"
+	baseService "muti-kube/pkg/service"
+	"muti-kube/pkg/service/cluster"
+	"muti-kube/pkg/util"
+
+	v1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/util/retry"
+)
+
+type serviceService struct {
+	ctx context.Context
+	cs  cluster.Interface
+}
+
+type ServiceInterface interface {
+	GetServices(clusterID string, namespace string, opts ...baseService.OpOption) ([]v1.Service, *int64, error)
+	GetService(clusterID string, namespace string, serviceID string, opts ...baseService.OpOption) (*v1.Service, error)
+	CreateService(clusterID string, namespace string, servicePost coreModels.ServicePost, opts ...baseService.OpOption) (*v1.Service, error)
+	DryRunService(clusterID string, namespace string, servicePost coreModels.ServicePost, opts ...baseService.OpOption) (*v1.Service, error)
+	UpdateService(clusterID string, namespace string, serviceID string, servicePost coreModels.ServicePost, opts ...baseService.OpOption) (*v1.Service, error)
+	DeleteService(clusterID string, namespace string, serviceID string, opts ...baseService.OpOption) error
+	GetServicePods(clusterID string, namespace string, serviceID string, opts ...baseService.OpOption) ([]v1.Pod, error)
+}
+
+func NewService() (ServiceInterface, error) {
+	clusterService, err := cluster.NewClusterService()
+	if err != nil {
+		return nil, err
+	}
+	return &serviceService{
+		ctx: context.Background(),
+		cs:  clusterService,
+	}, nil
+}
+
+func (ss *serviceService) GetServices(clusterID string,
+	namespace string, opts ...baseService.OpOption) ([]v1.Service, *int64, error) {
+	op := baseService.OpGet(opts...)
+	clientSet, err := ss.cs.GetKubernetesClientSet(clusterID, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+	list, err := clientSet.Kubernetes().CoreV1().Services(namespace).List(op.ContextOr(ss.ctx), metav1.ListOptions{})
+	if err != nil {
+		return nil, nil, err
+	}
+	count := util.ConvertToInt64Ptr(len(list.Items))
+	offset, end := baseService.CommonPaginate(list.Items,
+		(op.Pagination.Page-1)*op.Pagination.PageSize,
+		op.Pagination.PageSize)
+	return list.Items[offset:end], count, nil
+}
+
+func (ss *serviceService) GetService(clusterID string, namespace string,
+	serviceID string, opts ...baseService.OpOption) (*v1.Service, error) {
+	op := baseService.OpGet(opts...)
+	clientSet, err := ss.cs.GetKubernetesClientSet(clusterID, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return clientSet.Kubernetes().CoreV1().Services(namespace).Get(op.ContextOr(ss.ctx), serviceID, metav1.GetOptions{})
+}
+
+func (ss *serviceService) CreateService(clusterID string, namespace string,
+	servicePost coreModels.ServicePost, opts ...baseService.OpOption) (*v1.Service, error) {
+	return ss.createService(clusterID, namespace, servicePost, metav1.CreateOptions{}, opts...)
+}
+
+func (ss *serviceService) DryRunService(clusterID string, namespace string,
+	servicePost coreModels.ServicePost, opts ...baseService.OpOption) (*v1.Service, error) {
+	return ss.createService(clusterID, namespace, servicePost, metav1.CreateOptions{
+		DryRun: []string{metav1.DryRunAll},
+	}, opts...)
+}
+
+func (ss *serviceService) createService(clusterID string, namespace string,
+	servicePost coreModels.ServicePost, createOptions metav1.CreateOptions,
+	opts ...baseService.OpOption) (*v1.Service, error) {
+	op := baseService.OpGet(opts...)
+	clientSet, err := ss.cs.GetKubernetesClientSet(clusterID, opts...)
+	if err != nil {
+		return nil, err
+	}
+	service := &v1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        servicePost.Name,
+			Namespace:   namespace,
+			Labels:      servicePost.Labels,
+			Annotations: servicePost.Annotations,
+		},
+		Spec: servicePost.Spec,
+	}
+	return clientSet.Kubernetes().CoreV1().Services(namespace).Create(op.ContextOr(ss.ctx), service, createOptions)
+}
+
+// UpdateService Replace the spec of the service, the allocated cluster ips and node ports are kept
+// when not given, the update is retried on conflicts with the latest version
+func (ss *serviceService) UpdateService(clusterID string, namespace string, serviceID string,
+	servicePost coreModels.ServicePost, opts ...baseService.OpOption) (*v1.Service, error) {
+	op := baseService.OpGet(opts...)
+	ctx := op.ContextOr(ss.ctx)
+	clientSet, err := ss.cs.GetKubernetesClientSet(clusterID, opts...)
+	if err != nil {
+		return nil, err
+	}
+	services := clientSet.Kubernetes().CoreV1().Services(namespace)
+	var service *v1.Service
+	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		current, err := services.Get(ctx, serviceID, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		current.Spec = MergeServiceSpec(current.Spec, servicePost.Spec)
+		if servicePost.Labels != nil {
+			current.Labels = servicePost.Labels
+		}
+		if servicePost.Annotations != nil {
+			current.Annotations = servicePost.Annotations
+		}
+		service, err = services.Update(ctx, current, metav1.UpdateOptions{})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return service, nil
+}
+
+func (ss *serviceService) DeleteService(clusterID string, namespace string,
+	serviceID string, opts ...baseService.OpOption) error {
+	op := baseService.OpGet(opts...)
+	clientSet, err := ss.cs.GetKubernetesClientSet(clusterID, opts...)
+	if err != nil {
+		return err
+	}
+	return clientSet.Kubernetes().CoreV1().Services(namespace).Delete(op.ContextOr(ss.ctx), serviceID, metav1.DeleteOptions{})
+}
+
+// GetServicePods Obtain the pods backing the service, the pods are selected by the selector of the service,
+// services without selector are resolved through the pods referenced by their endpointslices
+func (ss *serviceService) GetServicePods(clusterID string, namespace string,
+	serviceID string, opts ...baseService.OpOption) ([]v1.Pod, error) {
+	op := baseService.OpGet(opts...)
+	ctx := op.ContextOr(ss.ctx)
+	clientSet, err := ss.cs.GetKubernetesClientSet(clusterID, opts...)
+	if err != nil {
+		return nil, err
+	}
+	service, err := clientSet.Kubernetes().CoreV1().Services(namespace).Get(ctx, serviceID, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if len(service.Spec.Selector) > 0 {
+		list, err := clientSet.Kubernetes().CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{
+			LabelSelector: labels.SelectorFromSet(service.Spec.Selector).String(),
+		})
+		if err != nil {
+			return nil, err
+		}
+		return list.Items, nil
+	}
+	slices, err := clientSet.Kubernetes().DiscoveryV1().EndpointSlices(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(labels.Set{discoveryv1.LabelServiceName: serviceID}).String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	list, err := clientSet.Kubernetes().CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	podNames := PodNamesOfEndpointSlices(slices.Items)
+	pods := make([]v1.Pod, 0, len(podNames))
+	for i := range list.Items {
+		if _, ok := podNames[list.Items[i].Name]; ok {
+			pods = append(pods, list.Items[i])
+		}
+	}
+	return pods, nil
+}
+
+// MergeServiceSpec the spec replacing the current one, immutable fields allocated by the cluster
+// are copied from the current spec when the new spec leaves them empty. ExternalName services have
+// no cluster ip, none is copied when the service is changed to one
+func MergeServiceSpec(current v1.ServiceSpec, spec v1.ServiceSpec) v1.ServiceSpec {
+	if spec.Type != v1.ServiceTypeExternalName {
+		if spec.ClusterIP == "" && len(spec.ClusterIPs) == 0 {
+			spec.ClusterIP = current.ClusterIP
+			spec.ClusterIPs = current.ClusterIPs
+		}
+		if spec.IPFamilies == nil {
+			spec.IPFamilies = current.IPFamilies
+		}
+	}
+	if spec.Type != current.Type {
+		return spec
+	}
+	if spec.HealthCheckNodePort == 0 {
+		spec.HealthCheckNodePort = current.HealthCheckNodePort
+	}
+	// keep the node ports, the cluster would allocate new ones otherwise
+	ports := make([]v1.ServicePort, len(spec.Ports))
+	for i, port := range spec.Ports {
+		for _, currentPort := range current.Ports {
+			if port.NodePort == 0 && port.Port == currentPort.Port && protocolOf(port) == protocolOf(currentPort) {
+				port.NodePort = currentPort.NodePort
+			}
+		}
+		ports[i] = port
+	}
+	spec.Ports = ports
+	return spec
+}
+
+// PodNamesOfEndpointSlices the names of the pods the endpoints of the slices refer to
+func PodNamesOfEndpointSlices(slices []discoveryv1.EndpointSlice) map[string]struct{} {
+	names := make(map[string]struct{})
+	for i := range slices {
+		for _, endpoint := range slices[i].Endpoints {
+			if endpoint.TargetRef != nil && endpoint.TargetRef.Kind == "Pod" {
+				names[endpoint.TargetRef.Name] = struct{}{}
+			}
+		}
+	}
+	return names
+}
+
+// protocolOf the protocol of the port, TCP when not given
+func protocolOf(port v1.ServicePort) v1.Protocol {
+	if port.Protocol == "" {
+		return v1.ProtocolTCP
+	}
+	return port.Protocol
+}
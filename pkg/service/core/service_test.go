@@ -0,0 +1,82 @@
+package core
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+)
+
+func TestMergeServiceSpec(t *testing.T) {
+	current := v1.ServiceSpec{
+		Type:       v1.ServiceTypeNodePort,
+		ClusterIP:  "10.96.0.10",
+		ClusterIPs: []string{"10.96.0.10"},
+		IPFamilies: []v1.IPFamily{v1.IPv4Protocol},
+		Ports: []v1.ServicePort{
+			{Port: 80, Protocol: v1.ProtocolTCP, NodePort: 30080},
+			{Port: 53, Protocol: v1.ProtocolUDP, NodePort: 30053},
+		},
+	}
+	tests := []struct {
+		name      string
+		spec      v1.ServiceSpec
+		clusterIP string
+		nodePorts []int32
+	}{
+		{
+			name: "same type keeps the cluster ip and node ports",
+			spec: v1.ServiceSpec{
+				Type:  v1.ServiceTypeNodePort,
+				Ports: []v1.ServicePort{{Port: 80}, {Port: 53, Protocol: v1.ProtocolTCP}},
+			},
+			clusterIP: "10.96.0.10",
+			nodePorts: []int32{30080, 0},
+		},
+		{
+			name:      "cluster ip type drops the node ports",
+			spec:      v1.ServiceSpec{Type: v1.ServiceTypeClusterIP, Ports: []v1.ServicePort{{Port: 80}}},
+			clusterIP: "10.96.0.10",
+			nodePorts: []int32{0},
+		},
+		{
+			name: "external name type has no cluster ip",
+			spec: v1.ServiceSpec{Type: v1.ServiceTypeExternalName, ExternalName: "db.example.com"},
+		},
+	}
+	for _, test := range tests {
+		spec := MergeServiceSpec(current, test.spec)
+		if spec.ClusterIP != test.clusterIP {
+			t.Errorf("%s: unexpected cluster ip %q", test.name, spec.ClusterIP)
+		}
+		if test.clusterIP == "" && (len(spec.ClusterIPs) != 0 || len(spec.IPFamilies) != 0) {
+			t.Errorf("%s: unexpected cluster ips %v, ip families %v", test.name, spec.ClusterIPs, spec.IPFamilies)
+		}
+		if len(spec.Ports) != len(test.nodePorts) {
+			t.Errorf("%s: unexpected ports %v", test.name, spec.Ports)
+			continue
+		}
+		for i, port := range spec.Ports {
+			if port.NodePort != test.nodePorts[i] {
+				t.Errorf("%s: unexpected node ports %v", test.name, spec.Ports)
+			}
+		}
+	}
+}
+
+func TestPodNamesOfEndpointSlices(t *testing.T) {
+	slices := []discoveryv1.EndpointSlice{
+		{Endpoints: []discoveryv1.Endpoint{
+			{TargetRef: &v1.ObjectReference{Kind: "Pod", Name: "web-1"}},
+			{TargetRef: &v1.ObjectReference{Kind: "Node", Name: "node-1"}},
+			{Addresses: []string{"10.0.0.1"}},
+		}},
+		{Endpoints: []discoveryv1.Endpoint{
+			{TargetRef: &v1.ObjectReference{Kind: "Pod", Name: "web-2"}},
+		}},
+	}
+	names := PodNamesOfEndpointSlices(slices)
+	if len(names) != 2 {
+		t.Errorf("unexpected pods %v", names)
+	}
+}
@@ -0,0 +1,163 @@
+package core
+
+import (
+	"context"
+	coreModels "muti-kube/models/core"
+	baseService "muti-kube/pkg/service"
+	"muti-kube/pkg/service/cluster"
+	"muti-kube/pkg/util"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+)
+
+type statefulSetService struct {
+	ctx context.Context
+	cs  cluster.Interface
+}
+
+type StatefulSetInterface interface {
+	GetStatefulSets(clusterID string, namespace string, opts ...baseService.OpOption) ([]appsv1.StatefulSet, *int64, error)
+	GetStatefulSet(clusterID string, namespace string, statefulSetID string, opts ...baseService.OpOption) (*appsv1.StatefulSet, error)
+	CreateStatefulSet(clusterID string, namespace string, statefulSetPost coreModels.StatefulSetPost, opts ...baseService.OpOption) (*appsv1.StatefulSet, error)
+	DryRunStatefulSet(clusterID string, namespace string, statefulSetPost coreModels.StatefulSetPost, opts ...baseService.OpOption) (*appsv1.StatefulSet, error)
+	UpdateStatefulSet(clusterID string, namespace string, statefulSetID string, statefulSetPost coreModels.StatefulSetPost, opts ...baseService.OpOption) (*appsv1.StatefulSet, error)
+	DeleteStatefulSet(clusterID string, namespace string, statefulSetID string, opts ...baseService.OpOption) error
+	ScaleStatefulSet(clusterID string, namespace string, statefulSetID string, replicas int32, opts ...baseService.OpOption) error
+}
+
+func NewStatefulSet() (StatefulSetInterface, error) {
+	clusterService, err := cluster.NewClusterService()
+	if err != nil {
+		return nil, err
+	}
+	return &statefulSetService{
+		ctx: context.Background(),
+		cs:  clusterService,
+	}, nil
+}
+
+func (ss *statefulSetService) GetStatefulSets(clusterID string,
+	namespace string, opts ...baseService.OpOption) ([]appsv1.StatefulSet, *int64, error) {
+	op := baseService.OpGet(opts...)
+	clientSet, err := ss.cs.GetKubernetesClientSet(clusterID, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+	list, err := clientSet.Kubernetes().AppsV1().StatefulSets(namespace).List(op.ContextOr(ss.ctx), metav1.ListOptions{})
+	if err != nil {
+		return nil, nil, err
+	}
+	count := util.ConvertToInt64Ptr(len(list.Items))
+	offset, end := baseService.CommonPaginate(list.Items,
+		(op.Pagination.Page-1)*op.Pagination.PageSize,
+		op.Pagination.PageSize)
+	return list.Items[offset:end], count, nil
+}
+
+func (ss *statefulSetService) GetStatefulSet(clusterID string, namespace string,
+	statefulSetID string, opts ...baseService.OpOption) (*appsv1.StatefulSet, error) {
+	op := baseService.OpGet(opts...)
+	clientSet, err := ss.cs.GetKubernetesClientSet(clusterID, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return clientSet.Kubernetes().AppsV1().StatefulSets(namespace).Get(op.ContextOr(ss.ctx), statefulSetID, metav1.GetOptions{})
+}
+
+func (ss *statefulSetService) CreateStatefulSet(clusterID string, namespace string,
+	statefulSetPost coreModels.StatefulSetPost, opts ...baseService.OpOption) (*appsv1.StatefulSet, error) {
+	return ss.createStatefulSet(clusterID, namespace, statefulSetPost, metav1.CreateOptions{}, opts...)
+}
+
+func (ss *statefulSetService) DryRunStatefulSet(clusterID string, namespace string,
+	statefulSetPost coreModels.StatefulSetPost, opts ...baseService.OpOption) (*appsv1.StatefulSet, error) {
+	return ss.createStatefulSet(clusterID, namespace, statefulSetPost, metav1.CreateOptions{
+		DryRun: []string{metav1.DryRunAll},
+	}, opts...)
+}
+
+func (ss *statefulSetService) createStatefulSet(clusterID string, namespace string,
+	statefulSetPost coreModels.StatefulSetPost, createOptions metav1.CreateOptions,
+	opts ...baseService.OpOption) (*appsv1.StatefulSet, error) {
+	op := baseService.OpGet(opts...)
+	clientSet, err := ss.cs.GetKubernetesClientSet(clusterID, opts...)
+	if err != nil {
+		return nil, err
+	}
+	statefulSet := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        statefulSetPost.Name,
+			Namespace:   namespace,
+			Labels:      statefulSetPost.Labels,
+			Annotations: statefulSetPost.Annotations,
+		},
+		Spec: statefulSetPost.Spec,
+	}
+	return clientSet.Kubernetes().AppsV1().StatefulSets(namespace).Create(op.ContextOr(ss.ctx), statefulSet, createOptions)
+}
+
+// UpdateStatefulSet Replace the spec of the statefulset, the labels and annotations are only
+// replaced when given, the update is retried on conflicts with the latest version
+func (ss *statefulSetService) UpdateStatefulSet(clusterID string, namespace string, statefulSetID string,
+	statefulSetPost coreModels.StatefulSetPost, opts ...baseService.OpOption) (*appsv1.StatefulSet, error) {
+	op := baseService.OpGet(opts...)
+	ctx := op.ContextOr(ss.ctx)
+	clientSet, err := ss.cs.GetKubernetesClientSet(clusterID, opts...)
+	if err != nil {
+		return nil, err
+	}
+	statefulSets := clientSet.Kubernetes().AppsV1().StatefulSets(namespace)
+	var statefulSet *appsv1.StatefulSet
+	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		current, err := statefulSets.Get(ctx, statefulSetID, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		current.Spec = statefulSetPost.Spec
+		if statefulSetPost.Labels != nil {
+			current.Labels = statefulSetPost.Labels
+		}
+		if statefulSetPost.Annotations != nil {
+			current.Annotations = statefulSetPost.Annotations
+		}
+		statefulSet, err = statefulSets.Update(ctx, current, metav1.UpdateOptions{})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return statefulSet, nil
+}
+
+func (ss *statefulSetService) DeleteStatefulSet(clusterID string, namespace string,
+	statefulSetID string, opts ...baseService.OpOption) error {
+	op := baseService.OpGet(opts...)
+	clientSet, err := ss.cs.GetKubernetesClientSet(clusterID, opts...)
+	if err != nil {
+		return err
+	}
+	return clientSet.Kubernetes().AppsV1().StatefulSets(namespace).Delete(op.ContextOr(ss.ctx), statefulSetID, metav1.DeleteOptions{})
+}
+
+// ScaleStatefulSet Set the replicas of the statefulset through the scale subresource
+func (ss *statefulSetService) ScaleStatefulSet(clusterID string, namespace string,
+	statefulSetID string, replicas int32, opts ...baseService.OpOption) error {
+	op := baseService.OpGet(opts...)
+	ctx := op.ContextOr(ss.ctx)
+	clientSet, err := ss.cs.GetKubernetesClientSet(clusterID, opts...)
+	if err != nil {
+		return err
+	}
+	statefulSets := clientSet.Kubernetes().AppsV1().StatefulSets(namespace)
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		scale, err := statefulSets.GetScale(ctx, statefulSetID, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		scale.Spec.Replicas = replicas
+		_, err = statefulSets.UpdateScale(ctx, statefulSetID, scale, metav1.UpdateOptions{})
+		return err
+	})
+}
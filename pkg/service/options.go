@@ -1,17 +1,41 @@
 package service
 
-import "muti-kube/models/common"
+import (
+	"context"
+	"muti-kube/models/auth"
+	"muti-kube/models/common"
+)
 
 type OpOption func(*Op)
 
 type Op struct {
 	Pagination *common.Pagination
+	Context    context.Context
+	User       *auth.User
 }
 
 func WithPagination(pagination *common.Pagination) OpOption {
 	return func(op *Op) { op.Pagination = pagination }
 }
 
+// WithContext the requests to the member cluster are bound to the context
+func WithContext(ctx context.Context) OpOption {
+	return func(op *Op) { op.Context = ctx }
+}
+
+// WithUser the requests to the member cluster are made on behalf of the user when impersonation is enabled
+func WithUser(user *auth.User) OpOption {
+	return func(op *Op) { op.User = user }
+}
+
+// ContextOr the context of the op, or the given default when none is set
+func (op *Op) ContextOr(ctx context.Context) context.Context {
+	if op.Context != nil {
+		return op.Context
+	}
+	return ctx
+}
+
 func (op *Op) applyOpts(opts []OpOption) {
 	for _, opt := range opts {
 		opt(op)
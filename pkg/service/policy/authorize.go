@@ -0,0 +1,149 @@
+package policy
+
+import (
+	"encoding/json"
+	"fmt"
+	"muti-kube/models/auth"
+	"muti-kube/pkg/api/cluster/v1alpha1"
+	"muti-kube/pkg/authentication"
+	"muti-kube/pkg/authorization"
+	baseService "muti-kube/pkg/service"
+	"sort"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// propagationVerbs the verbs on the deployments of a cluster and namespace needed to propagate to them
+var propagationVerbs = []string{authorization.VerbCreate, authorization.VerbUpdate}
+
+// NewAuthorizer the authorizer of the api users, nil when no authenticator is configured
+// and the api is served without authorization
+func NewAuthorizer(bs baseService.BaseInterface) (authorization.Authorizer, error) {
+	options, err := authentication.NewAuthenticationOptionsFromConfig()
+	if err != nil {
+		return nil, err
+	}
+	if !options.Enabled() {
+		return nil, nil
+	}
+	return authorization.NewRBACAuthorizer(bs.GetRoleLister(), bs.GetRoleBindingLister()), nil
+}
+
+// SetPolicyUser record the user writing the spec of the policy, the annotation sent by the client is replaced
+func SetPolicyUser(object metav1.Object, user *auth.User) error {
+	annotations := make(map[string]string, len(object.GetAnnotations())+1)
+	for key, value := range object.GetAnnotations() {
+		annotations[key] = value
+	}
+	delete(annotations, v1alpha1.PolicyUserAnnotation)
+	if user != nil {
+		data, err := json.Marshal(&auth.User{Name: user.Name, Groups: user.Groups})
+		if err != nil {
+			return err
+		}
+		annotations[v1alpha1.PolicyUserAnnotation] = string(data)
+	}
+	object.SetAnnotations(annotations)
+	return nil
+}
+
+// PolicyUser the user recorded on the policy, nil for the policies written on the host cluster directly
+// or while the api was served without authentication
+func PolicyUser(object metav1.Object) (*auth.User, error) {
+	data, ok := object.GetAnnotations()[v1alpha1.PolicyUserAnnotation]
+	if !ok {
+		return nil, nil
+	}
+	user := &auth.User{}
+	if err := json.Unmarshal([]byte(data), user); err != nil {
+		return nil, fmt.Errorf("annotation %s: %v", v1alpha1.PolicyUserAnnotation, err)
+	}
+	return user, nil
+}
+
+// AuthorizeDeployments check the user may create and update the deployments of the namespace in every cluster,
+// a policy only writes the deployments its user could write through the deployment api
+func AuthorizeDeployments(authorizer authorization.Authorizer, user *auth.User, namespace string,
+	clusters []string) error {
+	if authorizer == nil {
+		return nil
+	}
+	for _, cluster := range clusters {
+		for _, verb := range propagationVerbs {
+			allowed, reason, err := authorizer.Authorize(authorization.Attributes{
+				User:      user,
+				Verb:      verb,
+				Resource:  authorization.ResourceDeployments,
+				Cluster:   cluster,
+				Namespace: namespace,
+			})
+			if err != nil {
+				return err
+			}
+			if !allowed {
+				return fmt.Errorf("forbidden: %s", reason)
+			}
+		}
+	}
+	return nil
+}
+
+// AuthorizePropagation check the user recorded on the policy may still write its deployment in the cluster,
+// the clusters selected by labels may change after the policy was authorized
+func AuthorizePropagation(authorizer authorization.Authorizer, policy *v1alpha1.PropagationPolicy,
+	cluster string) error {
+	user, err := PolicyUser(policy)
+	if err != nil || user == nil {
+		return err
+	}
+	if err := AuthorizeDeployments(authorizer, user, policy.Namespace, []string{cluster}); err != nil {
+		return fmt.Errorf("propagation policy %s: %w", policy.Name, err)
+	}
+	return nil
+}
+
+// AuthorizeOverrides check the users recorded on the override policies changing the deployment of the policy
+// in the cluster may still write it, the override policies apply to the propagation policies created later too
+func AuthorizeOverrides(authorizer authorization.Authorizer, policy *v1alpha1.PropagationPolicy,
+	overrides []*v1alpha1.OverridePolicy, cluster *v1alpha1.Cluster) error {
+	if authorizer == nil {
+		return nil
+	}
+	for _, override := range OverridesFor(policy, overrides) {
+		user, err := PolicyUser(override)
+		if err != nil {
+			return fmt.Errorf("override policy %s: %w", override.Name, err)
+		}
+		if user == nil {
+			continue
+		}
+		targeted, err := overrideTargets(override, []*v1alpha1.Cluster{cluster})
+		if err != nil {
+			return fmt.Errorf("override policy %s: %w", override.Name, err)
+		}
+		if err := AuthorizeDeployments(authorizer, user, policy.Namespace, targeted); err != nil {
+			return fmt.Errorf("override policy %s: %w", override.Name, err)
+		}
+	}
+	return nil
+}
+
+// overrideTargets the names of the clusters targeted by any rule of the override policy in order
+func overrideTargets(override *v1alpha1.OverridePolicy, clusters []*v1alpha1.Cluster) ([]string, error) {
+	targeted := make(map[string]struct{})
+	for _, rule := range override.Spec.OverrideRules {
+		names, err := MatchClusters(rule.TargetCluster, clusters)
+		if err != nil {
+			return nil, err
+		}
+		for _, name := range names {
+			targeted[name] = struct{}{}
+		}
+	}
+	names := make([]string, 0, len(targeted))
+	for name := range targeted {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
@@ -0,0 +1,90 @@
+package policy
+
+import (
+	"muti-kube/models/auth"
+	"muti-kube/pkg/api/cluster/v1alpha1"
+	"muti-kube/pkg/authorization"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// clusterAuthorizer allows every request on the clusters it holds
+type clusterAuthorizer map[string]bool
+
+func (a clusterAuthorizer) Authorize(attributes authorization.Attributes) (bool, string, error) {
+	return attributes.User != nil && a[attributes.Cluster], "cluster " + attributes.Cluster + " denied", nil
+}
+
+func TestPolicyUser(t *testing.T) {
+	policy := &v1alpha1.PropagationPolicy{ObjectMeta: metav1.ObjectMeta{
+		Name:        "nginx",
+		Annotations: map[string]string{v1alpha1.PolicyUserAnnotation: `{"name":"admin"}`, "team": "a"},
+	}}
+	if err := SetPolicyUser(policy, &auth.User{Name: "alice", Groups: []string{"dev"}}); err != nil {
+		t.Fatal(err)
+	}
+	user, err := PolicyUser(policy)
+	if err != nil || user == nil || user.Name != "alice" || len(user.Groups) != 1 {
+		t.Errorf("unexpected user %+v, %v", user, err)
+	}
+	if policy.Annotations["team"] != "a" {
+		t.Error("the other annotations are dropped")
+	}
+	if err := SetPolicyUser(policy, nil); err != nil {
+		t.Fatal(err)
+	}
+	if user, _ := PolicyUser(policy); user != nil {
+		t.Errorf("the annotation sent by the client is kept: %+v", user)
+	}
+}
+
+func TestAuthorizePropagation(t *testing.T) {
+	authorizer := clusterAuthorizer{"cluster-a": true}
+	policy := &v1alpha1.PropagationPolicy{ObjectMeta: metav1.ObjectMeta{Name: "nginx", Namespace: "default"}}
+	if err := AuthorizePropagation(authorizer, policy, "cluster-b"); err != nil {
+		t.Errorf("policies without a user are trusted: %v", err)
+	}
+	if err := SetPolicyUser(policy, &auth.User{Name: "alice"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := AuthorizePropagation(authorizer, policy, "cluster-a"); err != nil {
+		t.Error(err)
+	}
+	if err := AuthorizePropagation(authorizer, policy, "cluster-b"); err == nil {
+		t.Error("the deployment is propagated to a cluster the user can not write")
+	}
+	if err := AuthorizePropagation(nil, policy, "cluster-b"); err != nil {
+		t.Errorf("everything is allowed without authentication: %v", err)
+	}
+}
+
+func TestAuthorizeOverrides(t *testing.T) {
+	authorizer := clusterAuthorizer{"cluster-a": true}
+	policy := &v1alpha1.PropagationPolicy{ObjectMeta: metav1.ObjectMeta{Name: "nginx", Namespace: "default"}}
+	clusterA := &v1alpha1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "cluster-a"}}
+	clusterB := &v1alpha1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "cluster-b"}}
+	override := &v1alpha1.OverridePolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "region", Namespace: "default"},
+		Spec: v1alpha1.OverridePolicySpec{OverrideRules: []v1alpha1.OverrideRule{
+			{TargetCluster: v1alpha1.ClusterAffinity{ClusterNames: []string{"cluster-b"}}},
+		}},
+	}
+	overrides := []*v1alpha1.OverridePolicy{override}
+	if err := AuthorizeOverrides(authorizer, policy, overrides, clusterB); err != nil {
+		t.Errorf("overrides without a user are trusted: %v", err)
+	}
+	if err := SetPolicyUser(override, &auth.User{Name: "alice"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := AuthorizeOverrides(authorizer, policy, overrides, clusterA); err != nil {
+		t.Errorf("the override does not change the deployment of cluster-a: %v", err)
+	}
+	if err := AuthorizeOverrides(authorizer, policy, overrides, clusterB); err == nil {
+		t.Error("the override changes the deployment of a cluster its user can not write")
+	}
+	override.Spec.PropagationPolicies = []string{"other"}
+	if err := AuthorizeOverrides(authorizer, policy, overrides, clusterB); err != nil {
+		t.Errorf("the override does not apply to the policy: %v", err)
+	}
+}
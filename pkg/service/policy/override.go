@@ -0,0 +1,393 @@
+package policy
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"muti-kube/models/auth"
+	policyModels "muti-kube/models/policy"
+	"muti-kube/pkg/api/cluster/v1alpha1"
+	"muti-kube/pkg/authorization"
+	baseService "muti-kube/pkg/service"
+	"muti-kube/pkg/util"
+	"sort"
+	"strconv"
+	"strings"
+
+	jsonpatch "github.com/evanphx/json-patch"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/util/retry"
+)
+
+type overrideService struct {
+	ctx context.Context
+	bs  baseService.BaseInterface
+	// authorizer checks the user may write the deployments the rules change, nil without authentication
+	authorizer authorization.Authorizer
+}
+
+// OverridePolicyInterface manages the override policies stored in the host cluster, the overrides are
+// applied by the propagation controller to the deployments of each member cluster
+type OverridePolicyInterface interface {
+	GetOverridePolicies(namespace string, opts ...baseService.OpOption) ([]v1alpha1.OverridePolicy, *int64, error)
+	GetOverridePolicy(namespace string, name string, opts ...baseService.OpOption) (*v1alpha1.OverridePolicy, error)
+	CreateOverridePolicy(namespace string, policyPost policyModels.OverridePolicyPost,
+		opts ...baseService.OpOption) (*v1alpha1.OverridePolicy, error)
+	DryRunOverridePolicy(namespace string, policyPost policyModels.OverridePolicyPost,
+		opts ...baseService.OpOption) (*v1alpha1.OverridePolicy, error)
+	UpdateOverridePolicy(namespace string, name string, policyUpdate policyModels.OverridePolicyUpdate,
+		opts ...baseService.OpOption) (*v1alpha1.OverridePolicy, error)
+	DeleteOverridePolicy(namespace string, name string, opts ...baseService.OpOption) error
+}
+
+func NewOverridePolicy() (OverridePolicyInterface, error) {
+	base, err := baseService.NewBase()
+	if err != nil {
+		return nil, err
+	}
+	authorizer, err := NewAuthorizer(base)
+	if err != nil {
+		return nil, err
+	}
+	return &overrideService{
+		ctx:        context.Background(),
+		bs:         base,
+		authorizer: authorizer,
+	}, nil
+}
+
+func (ors *overrideService) GetOverridePolicies(namespace string,
+	opts ...baseService.OpOption) ([]v1alpha1.OverridePolicy, *int64, error) {
+	op := baseService.OpGet(opts...)
+	list, err := ors.bs.GetCrdClient().OverridePolicies(namespace).List(op.ContextOr(ors.ctx), metav1.ListOptions{})
+	if err != nil {
+		return nil, nil, err
+	}
+	count := util.ConvertToInt64Ptr(len(list.Items))
+	offset, end := baseService.CommonPaginate(list.Items,
+		(op.Pagination.Page-1)*op.Pagination.PageSize,
+		op.Pagination.PageSize)
+	return list.Items[offset:end], count, nil
+}
+
+func (ors *overrideService) GetOverridePolicy(namespace string, name string,
+	opts ...baseService.OpOption) (*v1alpha1.OverridePolicy, error) {
+	op := baseService.OpGet(opts...)
+	return ors.bs.GetCrdClient().OverridePolicies(namespace).Get(op.ContextOr(ors.ctx), name, metav1.GetOptions{})
+}
+
+func (ors *overrideService) CreateOverridePolicy(namespace string, policyPost policyModels.OverridePolicyPost,
+	opts ...baseService.OpOption) (*v1alpha1.OverridePolicy, error) {
+	return ors.createOverridePolicy(namespace, policyPost, metav1.CreateOptions{}, opts...)
+}
+
+func (ors *overrideService) DryRunOverridePolicy(namespace string, policyPost policyModels.OverridePolicyPost,
+	opts ...baseService.OpOption) (*v1alpha1.OverridePolicy, error) {
+	return ors.createOverridePolicy(namespace, policyPost, metav1.CreateOptions{
+		DryRun: []string{metav1.DryRunAll},
+	}, opts...)
+}
+
+func (ors *overrideService) createOverridePolicy(namespace string, policyPost policyModels.OverridePolicyPost,
+	createOptions metav1.CreateOptions, opts ...baseService.OpOption) (*v1alpha1.OverridePolicy, error) {
+	op := baseService.OpGet(opts...)
+	if err := ValidateOverridePolicySpec(&policyPost.Spec); err != nil {
+		return nil, err
+	}
+	if err := ors.authorizeRules(namespace, &policyPost.Spec, op.User); err != nil {
+		return nil, err
+	}
+	policy := &v1alpha1.OverridePolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        policyPost.Name,
+			Namespace:   namespace,
+			Labels:      policyPost.Labels,
+			Annotations: policyPost.Annotations,
+		},
+		Spec: policyPost.Spec,
+	}
+	if err := SetPolicyUser(policy, op.User); err != nil {
+		return nil, err
+	}
+	return ors.bs.GetCrdClient().OverridePolicies(namespace).Create(op.ContextOr(ors.ctx), policy, createOptions)
+}
+
+// authorizeRules the user must be allowed to create and update the deployments of the namespace in every
+// cluster a rule changes the deployment of a propagation policy in, as the rules rewrite them without limits
+func (ors *overrideService) authorizeRules(namespace string, spec *v1alpha1.OverridePolicySpec, user *auth.User) error {
+	if ors.authorizer == nil {
+		return nil
+	}
+	clusters, err := ors.bs.GetClusterLister().List(labels.Everything())
+	if err != nil {
+		return err
+	}
+	policies, err := ors.bs.GetPropagationPolicyInformer().Lister().PropagationPolicies(namespace).List(labels.Everything())
+	if err != nil {
+		return err
+	}
+	override := &v1alpha1.OverridePolicy{Spec: *spec}
+	affected := make(map[string]struct{})
+	for _, policy := range policies {
+		if len(spec.PropagationPolicies) > 0 && !util.ContainsString(spec.PropagationPolicies, policy.Name) {
+			continue
+		}
+		selected, err := SelectClusters(policy.Spec.Placement.ClusterAffinity, clusters)
+		if err != nil {
+			return err
+		}
+		targeted, err := overrideTargets(override, selected)
+		if err != nil {
+			return err
+		}
+		for _, name := range targeted {
+			affected[name] = struct{}{}
+		}
+	}
+	names := make([]string, 0, len(affected))
+	for name := range affected {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return AuthorizeDeployments(ors.authorizer, user, namespace, names)
+}
+
+// UpdateOverridePolicy Replace the spec of the policy, the update is retried on conflicts with the latest version
+func (ors *overrideService) UpdateOverridePolicy(namespace string, name string,
+	policyUpdate policyModels.OverridePolicyUpdate, opts ...baseService.OpOption) (*v1alpha1.OverridePolicy, error) {
+	op := baseService.OpGet(opts...)
+	ctx := op.ContextOr(ors.ctx)
+	if err := ValidateOverridePolicySpec(&policyUpdate.Spec); err != nil {
+		return nil, err
+	}
+	if err := ors.authorizeRules(namespace, &policyUpdate.Spec, op.User); err != nil {
+		return nil, err
+	}
+	policies := ors.bs.GetCrdClient().OverridePolicies(namespace)
+	var policy *v1alpha1.OverridePolicy
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		current, err := policies.Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		current.Spec = policyUpdate.Spec
+		if policyUpdate.Labels != nil {
+			current.Labels = policyUpdate.Labels
+		}
+		if policyUpdate.Annotations != nil {
+			current.Annotations = policyUpdate.Annotations
+		}
+		if err := SetPolicyUser(current, op.User); err != nil {
+			return err
+		}
+		policy, err = policies.Update(ctx, current, metav1.UpdateOptions{})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+func (ors *overrideService) DeleteOverridePolicy(namespace string, name string, opts ...baseService.OpOption) error {
+	op := baseService.OpGet(opts...)
+	return ors.bs.GetCrdClient().OverridePolicies(namespace).Delete(op.ContextOr(ors.ctx), name, metav1.DeleteOptions{})
+}
+
+// ValidateOverridePolicySpec every rule must target clusters, and its field paths and json patch must be well formed
+func ValidateOverridePolicySpec(spec *v1alpha1.OverridePolicySpec) error {
+	for i, rule := range spec.OverrideRules {
+		affinity := rule.TargetCluster
+		if AffinityEmpty(affinity) {
+			return fmt.Errorf("overrideRules[%d].targetCluster selects no cluster, clusterNames, clusterSets or labelSelector is required", i)
+		}
+		if _, err := metav1.LabelSelectorAsSelector(affinity.LabelSelector); err != nil {
+			return fmt.Errorf("overrideRules[%d].targetCluster.labelSelector: %w", i, err)
+		}
+		for j, override := range rule.Overriders.FieldOverrides {
+			if _, err := fieldPath(override.Path); err != nil {
+				return fmt.Errorf("overrideRules[%d].overriders.fieldOverrides[%d]: %w", i, j, err)
+			}
+			if len(override.Value.Raw) == 0 {
+				return fmt.Errorf("overrideRules[%d].overriders.fieldOverrides[%d]: value is required", i, j)
+			}
+		}
+		for j, operation := range rule.Overriders.JSONPatch {
+			if err := validateJSONPatchOperation(operation); err != nil {
+				return fmt.Errorf("overrideRules[%d].overriders.jsonPatch[%d]: %w", i, j, err)
+			}
+		}
+	}
+	return nil
+}
+
+func validateJSONPatchOperation(operation v1alpha1.JSONPatchOperation) error {
+	if !strings.HasPrefix(operation.Path, "/") {
+		return fmt.Errorf("path %q must start with /", operation.Path)
+	}
+	switch operation.Op {
+	case "add", "replace", "test":
+		if operation.Value == nil {
+			return fmt.Errorf("value is required by %s", operation.Op)
+		}
+	case "move", "copy":
+		if operation.From == "" {
+			return fmt.Errorf("from is required by %s", operation.Op)
+		}
+	case "remove":
+	default:
+		return fmt.Errorf("unsupported op %q", operation.Op)
+	}
+	return nil
+}
+
+// OverridesFor the override policies applying to the propagation policy, in the order of their names
+func OverridesFor(policy *v1alpha1.PropagationPolicy, overrides []*v1alpha1.OverridePolicy) []*v1alpha1.OverridePolicy {
+	matched := make([]*v1alpha1.OverridePolicy, 0)
+	for _, override := range overrides {
+		if override.Namespace != policy.Namespace {
+			continue
+		}
+		if len(override.Spec.PropagationPolicies) > 0 &&
+			!util.ContainsString(override.Spec.PropagationPolicies, policy.Name) {
+			continue
+		}
+		matched = append(matched, override)
+	}
+	sort.Slice(matched, func(i, j int) bool { return matched[i].Name < matched[j].Name })
+	return matched
+}
+
+// RenderDeployment the deployment of the policy for the cluster, with the replicas of the schedule when given
+// and the rules of the overrides targeting the cluster applied in order. The overrides can not change the name,
+// namespace and policy labels
+func RenderDeployment(policy *v1alpha1.PropagationPolicy, overrides []*v1alpha1.OverridePolicy,
+	cluster *v1alpha1.Cluster, schedule map[string]int32) (*appsv1.Deployment, error) {
+	deployment := NewDeployment(policy)
+	if schedule != nil {
+		replicas := schedule[cluster.Name]
+		deployment.Spec.Replicas = &replicas
+	}
+	data, err := json.Marshal(deployment)
+	if err != nil {
+		return nil, err
+	}
+	for _, override := range OverridesFor(policy, overrides) {
+		for i, rule := range override.Spec.OverrideRules {
+			selected, err := MatchClusters(rule.TargetCluster, []*v1alpha1.Cluster{cluster})
+			if err != nil {
+				return nil, fmt.Errorf("override policy %s rule %d: %w", override.Name, i, err)
+			}
+			if len(selected) == 0 {
+				continue
+			}
+			if data, err = applyOverriders(data, rule.Overriders); err != nil {
+				return nil, fmt.Errorf("override policy %s rule %d: %w", override.Name, i, err)
+			}
+		}
+	}
+	rendered := &appsv1.Deployment{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(rendered); err != nil {
+		return nil, fmt.Errorf("overridden deployment: %w", err)
+	}
+	rendered.TypeMeta = deployment.TypeMeta
+	rendered.Name = deployment.Name
+	rendered.Namespace = deployment.Namespace
+	if rendered.Labels == nil {
+		rendered.Labels = make(map[string]string, 2)
+	}
+	rendered.Labels[v1alpha1.PropagationPolicyNamespaceLabel] = policy.Namespace
+	rendered.Labels[v1alpha1.PropagationPolicyNameLabel] = policy.Name
+	return rendered, nil
+}
+
+func applyOverriders(data []byte, overriders v1alpha1.Overriders) ([]byte, error) {
+	if len(overriders.FieldOverrides) > 0 {
+		object := make(map[string]interface{})
+		if err := json.Unmarshal(data, &object); err != nil {
+			return nil, err
+		}
+		for _, override := range overriders.FieldOverrides {
+			var value interface{}
+			if err := json.Unmarshal(override.Value.Raw, &value); err != nil {
+				return nil, fmt.Errorf("value of %s: %w", override.Path, err)
+			}
+			if err := SetField(object, override.Path, value); err != nil {
+				return nil, err
+			}
+		}
+		var err error
+		if data, err = json.Marshal(object); err != nil {
+			return nil, err
+		}
+	}
+	if len(overriders.JSONPatch) > 0 {
+		raw, err := json.Marshal(overriders.JSONPatch)
+		if err != nil {
+			return nil, err
+		}
+		patch, err := jsonpatch.DecodePatch(raw)
+		if err != nil {
+			return nil, err
+		}
+		if data, err = patch.Apply(data); err != nil {
+			return nil, fmt.Errorf("json patch: %w", err)
+		}
+	}
+	return data, nil
+}
+
+func fieldPath(path string) ([]string, error) {
+	segments := strings.Split(path, ".")
+	for _, segment := range segments {
+		if segment == "" {
+			return nil, fmt.Errorf("invalid field path %q", path)
+		}
+	}
+	return segments, nil
+}
+
+// SetField set the field of the dotted path of the object to the value, list items are addressed by index
+// and missing objects on the path are created
+func SetField(object map[string]interface{}, path string, value interface{}) error {
+	segments, err := fieldPath(path)
+	if err != nil {
+		return err
+	}
+	var current interface{} = object
+	for i, segment := range segments {
+		last := i == len(segments)-1
+		switch node := current.(type) {
+		case map[string]interface{}:
+			if last {
+				node[segment] = value
+				return nil
+			}
+			next, ok := node[segment]
+			if !ok || next == nil {
+				next = make(map[string]interface{})
+				node[segment] = next
+			}
+			current = next
+		case []interface{}:
+			index, err := strconv.Atoi(segment)
+			if err != nil || index < 0 || index >= len(node) {
+				return fmt.Errorf("field path %q: no item %s in the list of %d items", path, segment, len(node))
+			}
+			if last {
+				node[index] = value
+				return nil
+			}
+			current = node[index]
+		default:
+			return fmt.Errorf("field path %q: %s is not an object or a list", path,
+				strings.Join(segments[:i], "."))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,158 @@
+package policy
+
+import (
+	"muti-kube/pkg/api/cluster/v1alpha1"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func rawJSON(value string) apiextensionsv1.JSON {
+	return apiextensionsv1.JSON{Raw: []byte(value)}
+}
+
+func TestRenderDeployment(t *testing.T) {
+	replicas := int32(2)
+	policy := &v1alpha1.PropagationPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "nginx", Namespace: "default"},
+		Spec: v1alpha1.PropagationPolicySpec{
+			Template: v1alpha1.DeploymentTemplate{
+				Spec: appsv1.DeploymentSpec{
+					Replicas: &replicas,
+					Template: v1.PodTemplateSpec{
+						Spec: v1.PodSpec{Containers: []v1.Container{{Name: "nginx", Image: "nginx:1.21"}}},
+					},
+				},
+			},
+		},
+	}
+	env := rawJSON(`[{"name":"REGION","value":"eu-central"}]`)
+	overrides := []*v1alpha1.OverridePolicy{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "b-region", Namespace: "default"},
+			Spec: v1alpha1.OverridePolicySpec{
+				OverrideRules: []v1alpha1.OverrideRule{{
+					TargetCluster: v1alpha1.ClusterAffinity{ClusterNames: []string{"frankfurt"}},
+					Overriders: v1alpha1.Overriders{
+						JSONPatch: []v1alpha1.JSONPatchOperation{
+							{Op: "add", Path: "/spec/template/spec/containers/0/env", Value: &env},
+						},
+					},
+				}},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "a-registry", Namespace: "default"},
+			Spec: v1alpha1.OverridePolicySpec{
+				PropagationPolicies: []string{"nginx"},
+				OverrideRules: []v1alpha1.OverrideRule{{
+					TargetCluster: v1alpha1.ClusterAffinity{
+						LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"region": "eu"}},
+					},
+					Overriders: v1alpha1.Overriders{
+						FieldOverrides: []v1alpha1.FieldOverride{
+							{Path: "spec.replicas", Value: rawJSON("3")},
+							{Path: "spec.template.spec.containers.0.image", Value: rawJSON(`"registry.eu.example.com/nginx:1.21"`)},
+							{Path: "metadata.name", Value: rawJSON(`"renamed"`)},
+						},
+					},
+				}},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "default"},
+			Spec: v1alpha1.OverridePolicySpec{
+				PropagationPolicies: []string{"redis"},
+				OverrideRules: []v1alpha1.OverrideRule{{
+					TargetCluster: v1alpha1.ClusterAffinity{ClusterNames: []string{"frankfurt"}},
+					Overriders: v1alpha1.Overriders{
+						FieldOverrides: []v1alpha1.FieldOverride{{Path: "spec.replicas", Value: rawJSON("0")}},
+					},
+				}},
+			},
+		},
+	}
+	frankfurt := &v1alpha1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "frankfurt", Labels: map[string]string{"region": "eu"}}}
+	deployment, err := RenderDeployment(policy, overrides, frankfurt, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	container := deployment.Spec.Template.Spec.Containers[0]
+	if *deployment.Spec.Replicas != 3 || container.Image != "registry.eu.example.com/nginx:1.21" ||
+		len(container.Env) != 1 || container.Env[0].Value != "eu-central" {
+		t.Errorf("unexpected deployment %+v", deployment.Spec)
+	}
+	if deployment.Name != "nginx" || !IsPropagatedBy(deployment, policy) {
+		t.Errorf("the overrides changed the name or labels of the deployment %s %v", deployment.Name, deployment.Labels)
+	}
+
+	beijing := &v1alpha1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "beijing", Labels: map[string]string{"region": "cn"}}}
+	deployment, err = RenderDeployment(policy, overrides, beijing, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *deployment.Spec.Replicas != 2 || deployment.Spec.Template.Spec.Containers[0].Image != "nginx:1.21" {
+		t.Errorf("overrides of other clusters applied %+v", deployment.Spec)
+	}
+
+	overrides[1].Spec.OverrideRules[0].Overriders.FieldOverrides = []v1alpha1.FieldOverride{
+		{Path: "spec.replica", Value: rawJSON("3")},
+	}
+	if _, err := RenderDeployment(policy, overrides, frankfurt, nil); err == nil {
+		t.Error("an override of an unknown field is accepted")
+	}
+}
+
+func TestSetField(t *testing.T) {
+	object := map[string]interface{}{
+		"spec": map[string]interface{}{"containers": []interface{}{map[string]interface{}{"name": "nginx"}}},
+	}
+	if err := SetField(object, "spec.containers.0.image", "nginx:1.21"); err != nil {
+		t.Error(err)
+	}
+	if err := SetField(object, "metadata.labels.app", "nginx"); err != nil {
+		t.Error(err)
+	}
+	for _, path := range []string{"spec.containers.1.image", "spec.containers.name", "spec.containers.0.name.first", "spec..name"} {
+		if err := SetField(object, path, "value"); err == nil {
+			t.Errorf("path %s is accepted", path)
+		}
+	}
+}
+
+func TestValidateOverridePolicySpec(t *testing.T) {
+	value := rawJSON("3")
+	cases := []struct {
+		rule  v1alpha1.OverrideRule
+		valid bool
+	}{
+		{v1alpha1.OverrideRule{}, false},
+		{v1alpha1.OverrideRule{
+			TargetCluster: v1alpha1.ClusterAffinity{ClusterNames: []string{"beijing"}},
+			Overriders: v1alpha1.Overriders{
+				JSONPatch: []v1alpha1.JSONPatchOperation{{Op: "replace", Path: "/spec/replicas", Value: &value}},
+			},
+		}, true},
+		{v1alpha1.OverrideRule{
+			TargetCluster: v1alpha1.ClusterAffinity{ClusterNames: []string{"beijing"}},
+			Overriders: v1alpha1.Overriders{
+				JSONPatch: []v1alpha1.JSONPatchOperation{{Op: "replace", Path: "/spec/replicas"}},
+			},
+		}, false},
+		{v1alpha1.OverrideRule{
+			TargetCluster: v1alpha1.ClusterAffinity{ClusterNames: []string{"beijing"}},
+			Overriders: v1alpha1.Overriders{
+				FieldOverrides: []v1alpha1.FieldOverride{{Path: "spec.replicas"}},
+			},
+		}, false},
+	}
+	for i, c := range cases {
+		err := ValidateOverridePolicySpec(&v1alpha1.OverridePolicySpec{OverrideRules: []v1alpha1.OverrideRule{c.rule}})
+		if (err == nil) != c.valid {
+			t.Errorf("case %d: unexpected result %v", i, err)
+		}
+	}
+}
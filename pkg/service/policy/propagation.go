@@ -0,0 +1,350 @@
+package policy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"muti-kube/models/auth"
+	policyModels "muti-kube/models/policy"
+	"muti-kube/pkg/api/cluster/v1alpha1"
+	"muti-kube/pkg/authorization"
+	baseService "muti-kube/pkg/service"
+	"muti-kube/pkg/util"
+	"sort"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/util/retry"
+)
+
+type propagationService struct {
+	ctx context.Context
+	bs  baseService.BaseInterface
+	// authorizer checks the user may write the deployments of the selected clusters, nil without authentication
+	authorizer authorization.Authorizer
+}
+
+// PropagationPolicyInterface manages the propagation policies stored in the host cluster,
+// the deployments of the policies are propagated by the propagation controller
+type PropagationPolicyInterface interface {
+	GetPropagationPolicies(namespace string, opts ...baseService.OpOption) ([]v1alpha1.PropagationPolicy, *int64, error)
+	GetPropagationPolicy(namespace string, name string, opts ...baseService.OpOption) (*v1alpha1.PropagationPolicy, error)
+	CreatePropagationPolicy(namespace string, policyPost policyModels.PropagationPolicyPost,
+		opts ...baseService.OpOption) (*v1alpha1.PropagationPolicy, error)
+	DryRunPropagationPolicy(namespace string, policyPost policyModels.PropagationPolicyPost,
+		opts ...baseService.OpOption) (*v1alpha1.PropagationPolicy, error)
+	UpdatePropagationPolicy(namespace string, name string, policyUpdate policyModels.PropagationPolicyUpdate,
+		opts ...baseService.OpOption) (*v1alpha1.PropagationPolicy, error)
+	DeletePropagationPolicy(namespace string, name string, opts ...baseService.OpOption) error
+	PreviewPropagationPolicy(namespace string, name string, cluster string,
+		opts ...baseService.OpOption) (*appsv1.Deployment, error)
+}
+
+func NewPropagationPolicy() (PropagationPolicyInterface, error) {
+	base, err := baseService.NewBase()
+	if err != nil {
+		return nil, err
+	}
+	authorizer, err := NewAuthorizer(base)
+	if err != nil {
+		return nil, err
+	}
+	return &propagationService{
+		ctx:        context.Background(),
+		bs:         base,
+		authorizer: authorizer,
+	}, nil
+}
+
+func (ps *propagationService) GetPropagationPolicies(namespace string,
+	opts ...baseService.OpOption) ([]v1alpha1.PropagationPolicy, *int64, error) {
+	op := baseService.OpGet(opts...)
+	list, err := ps.bs.GetCrdClient().PropagationPolicies(namespace).List(op.ContextOr(ps.ctx), metav1.ListOptions{})
+	if err != nil {
+		return nil, nil, err
+	}
+	count := util.ConvertToInt64Ptr(len(list.Items))
+	offset, end := baseService.CommonPaginate(list.Items,
+		(op.Pagination.Page-1)*op.Pagination.PageSize,
+		op.Pagination.PageSize)
+	return list.Items[offset:end], count, nil
+}
+
+func (ps *propagationService) GetPropagationPolicy(namespace string, name string,
+	opts ...baseService.OpOption) (*v1alpha1.PropagationPolicy, error) {
+	op := baseService.OpGet(opts...)
+	return ps.bs.GetCrdClient().PropagationPolicies(namespace).Get(op.ContextOr(ps.ctx), name, metav1.GetOptions{})
+}
+
+func (ps *propagationService) CreatePropagationPolicy(namespace string, policyPost policyModels.PropagationPolicyPost,
+	opts ...baseService.OpOption) (*v1alpha1.PropagationPolicy, error) {
+	return ps.createPropagationPolicy(namespace, policyPost, metav1.CreateOptions{}, opts...)
+}
+
+func (ps *propagationService) DryRunPropagationPolicy(namespace string, policyPost policyModels.PropagationPolicyPost,
+	opts ...baseService.OpOption) (*v1alpha1.PropagationPolicy, error) {
+	return ps.createPropagationPolicy(namespace, policyPost, metav1.CreateOptions{
+		DryRun: []string{metav1.DryRunAll},
+	}, opts...)
+}
+
+func (ps *propagationService) createPropagationPolicy(namespace string, policyPost policyModels.PropagationPolicyPost,
+	createOptions metav1.CreateOptions, opts ...baseService.OpOption) (*v1alpha1.PropagationPolicy, error) {
+	op := baseService.OpGet(opts...)
+	if err := ValidatePropagationPolicySpec(&policyPost.Spec); err != nil {
+		return nil, err
+	}
+	if err := ps.authorizePlacement(namespace, &policyPost.Spec, op.User); err != nil {
+		return nil, err
+	}
+	policy := &v1alpha1.PropagationPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        policyPost.Name,
+			Namespace:   namespace,
+			Labels:      policyPost.Labels,
+			Annotations: policyPost.Annotations,
+			Finalizers:  []string{v1alpha1.PropagationPolicyFinalizer},
+		},
+		Spec: policyPost.Spec,
+	}
+	if err := SetPolicyUser(policy, op.User); err != nil {
+		return nil, err
+	}
+	return ps.bs.GetCrdClient().PropagationPolicies(namespace).Create(op.ContextOr(ps.ctx), policy, createOptions)
+}
+
+// authorizePlacement the user must be allowed to create and update the deployments of the namespace
+// in every cluster selected by the placement, as the deployments are written on behalf of the user
+func (ps *propagationService) authorizePlacement(namespace string, spec *v1alpha1.PropagationPolicySpec,
+	user *auth.User) error {
+	if ps.authorizer == nil {
+		return nil
+	}
+	clusters, err := ps.bs.GetClusterLister().List(labels.Everything())
+	if err != nil {
+		return err
+	}
+	selected, err := MatchClusters(spec.Placement.ClusterAffinity, clusters)
+	if err != nil {
+		return err
+	}
+	return AuthorizeDeployments(ps.authorizer, user, namespace, selected)
+}
+
+// UpdatePropagationPolicy Replace the spec of the policy, the update is retried on conflicts with the latest version.
+// The deployments of the policy are written on behalf of the updating user from then on
+func (ps *propagationService) UpdatePropagationPolicy(namespace string, name string,
+	policyUpdate policyModels.PropagationPolicyUpdate, opts ...baseService.OpOption) (*v1alpha1.PropagationPolicy, error) {
+	op := baseService.OpGet(opts...)
+	ctx := op.ContextOr(ps.ctx)
+	if err := ValidatePropagationPolicySpec(&policyUpdate.Spec); err != nil {
+		return nil, err
+	}
+	if err := ps.authorizePlacement(namespace, &policyUpdate.Spec, op.User); err != nil {
+		return nil, err
+	}
+	policies := ps.bs.GetCrdClient().PropagationPolicies(namespace)
+	var policy *v1alpha1.PropagationPolicy
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		current, err := policies.Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		current.Spec = policyUpdate.Spec
+		if policyUpdate.Labels != nil {
+			current.Labels = policyUpdate.Labels
+		}
+		if policyUpdate.Annotations != nil {
+			current.Annotations = policyUpdate.Annotations
+		}
+		if err := SetPolicyUser(current, op.User); err != nil {
+			return err
+		}
+		policy, err = policies.Update(ctx, current, metav1.UpdateOptions{})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+// DeletePropagationPolicy Delete the policy, its deployments are removed from the member clusters
+// by the propagation controller before the policy is gone
+func (ps *propagationService) DeletePropagationPolicy(namespace string, name string, opts ...baseService.OpOption) error {
+	op := baseService.OpGet(opts...)
+	return ps.bs.GetCrdClient().PropagationPolicies(namespace).Delete(op.ContextOr(ps.ctx), name, metav1.DeleteOptions{})
+}
+
+// PreviewPropagationPolicy Render the deployment of the policy for the cluster with the override policies
+// of the namespace applied, as the propagation controller creates or updates it in the cluster
+func (ps *propagationService) PreviewPropagationPolicy(namespace string, name string, cluster string,
+	opts ...baseService.OpOption) (*appsv1.Deployment, error) {
+	op := baseService.OpGet(opts...)
+	ctx := op.ContextOr(ps.ctx)
+	policy, err := ps.bs.GetCrdClient().PropagationPolicies(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	memberCluster, err := ps.bs.GetClusterLister().Get(cluster)
+	if err != nil {
+		return nil, err
+	}
+	clusters, err := ps.bs.GetClusterLister().List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	selected, err := SelectClusters(policy.Spec.Placement.ClusterAffinity, clusters)
+	if err != nil {
+		return nil, err
+	}
+	isSelected := false
+	for _, selectedCluster := range selected {
+		isSelected = isSelected || selectedCluster.Name == cluster
+	}
+	if !isSelected {
+		return nil, fmt.Errorf("cluster %s is not selected by the placement of the policy", cluster)
+	}
+	schedule, err := ScheduleReplicas(policy, selected)
+	if err != nil {
+		return nil, err
+	}
+	list, err := ps.bs.GetCrdClient().OverridePolicies(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	overrides := make([]*v1alpha1.OverridePolicy, 0, len(list.Items))
+	for i := range list.Items {
+		overrides = append(overrides, &list.Items[i])
+	}
+	return RenderDeployment(policy, overrides, memberCluster, schedule.ReplicasOf())
+}
+
+// ValidatePropagationPolicySpec the placement must select clusters and the template must have a selector,
+// the rest of the template is validated by the member clusters
+func ValidatePropagationPolicySpec(spec *v1alpha1.PropagationPolicySpec) error {
+	affinity := spec.Placement.ClusterAffinity
+	if AffinityEmpty(affinity) {
+		return errors.New("placement.clusterAffinity selects no cluster, clusterNames, clusterSets or labelSelector is required")
+	}
+	if _, err := metav1.LabelSelectorAsSelector(affinity.LabelSelector); err != nil {
+		return fmt.Errorf("placement.clusterAffinity.labelSelector: %w", err)
+	}
+	if spec.Template.Spec.Selector == nil {
+		return errors.New("template.spec.selector is required")
+	}
+	return validateReplicaScheduling(spec.Placement.ReplicaScheduling)
+}
+
+func validateReplicaScheduling(scheduling *v1alpha1.ReplicaScheduling) error {
+	if scheduling == nil {
+		return nil
+	}
+	switch scheduling.Type {
+	case v1alpha1.ReplicaDivisionStatic:
+		if len(scheduling.StaticWeights) == 0 {
+			return errors.New("placement.replicaScheduling.staticWeights is required by the Static type")
+		}
+	case v1alpha1.ReplicaDivisionDynamic:
+	default:
+		return fmt.Errorf("placement.replicaScheduling.type must be %s or %s",
+			v1alpha1.ReplicaDivisionStatic, v1alpha1.ReplicaDivisionDynamic)
+	}
+	for i, staticWeight := range scheduling.StaticWeights {
+		if staticWeight.Weight < 0 || staticWeight.Weight > math.MaxInt32 {
+			return fmt.Errorf("placement.replicaScheduling.staticWeights[%d].weight must be between 0 and %d",
+				i, math.MaxInt32)
+		}
+		if _, err := metav1.LabelSelectorAsSelector(staticWeight.TargetCluster.LabelSelector); err != nil {
+			return fmt.Errorf("placement.replicaScheduling.staticWeights[%d].targetCluster.labelSelector: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// AffinityEmpty whether the affinity gives none of its fields, such an affinity selects no cluster
+func AffinityEmpty(affinity v1alpha1.ClusterAffinity) bool {
+	return len(affinity.ClusterNames) == 0 && len(affinity.ClusterSets) == 0 && affinity.LabelSelector == nil
+}
+
+// MatchClusters the names of the clusters selected by the affinity in order, an empty affinity selects no cluster
+func MatchClusters(affinity v1alpha1.ClusterAffinity, clusters []*v1alpha1.Cluster) ([]string, error) {
+	names := make([]string, 0)
+	if AffinityEmpty(affinity) {
+		return names, nil
+	}
+	selector := labels.Everything()
+	if affinity.LabelSelector != nil {
+		var err error
+		if selector, err = metav1.LabelSelectorAsSelector(affinity.LabelSelector); err != nil {
+			return nil, err
+		}
+	}
+	for _, cluster := range clusters {
+		if len(affinity.ClusterNames) > 0 && !util.ContainsString(affinity.ClusterNames, cluster.Name) {
+			continue
+		}
+		if len(affinity.ClusterSets) > 0 && !util.ContainsString(affinity.ClusterSets, cluster.Labels[v1alpha1.ClusterSetLabel]) {
+			continue
+		}
+		if !selector.Matches(labels.Set(cluster.Labels)) {
+			continue
+		}
+		names = append(names, cluster.Name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// SelectClusters the clusters selected by the affinity in the order of their names
+func SelectClusters(affinity v1alpha1.ClusterAffinity, clusters []*v1alpha1.Cluster) ([]*v1alpha1.Cluster, error) {
+	names, err := MatchClusters(affinity, clusters)
+	if err != nil {
+		return nil, err
+	}
+	byName := make(map[string]*v1alpha1.Cluster, len(clusters))
+	for _, cluster := range clusters {
+		byName[cluster.Name] = cluster
+	}
+	selected := make([]*v1alpha1.Cluster, 0, len(names))
+	for _, name := range names {
+		selected = append(selected, byName[name])
+	}
+	return selected, nil
+}
+
+// NewDeployment the deployment of the policy propagated to the member clusters, labelled with the policy
+func NewDeployment(policy *v1alpha1.PropagationPolicy) *appsv1.Deployment {
+	template := policy.Spec.Template.DeepCopy()
+	name := template.Metadata.Name
+	if name == "" {
+		name = policy.Name
+	}
+	deploymentLabels := template.Metadata.Labels
+	if deploymentLabels == nil {
+		deploymentLabels = make(map[string]string, 2)
+	}
+	deploymentLabels[v1alpha1.PropagationPolicyNamespaceLabel] = policy.Namespace
+	deploymentLabels[v1alpha1.PropagationPolicyNameLabel] = policy.Name
+	return &appsv1.Deployment{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: appsv1.SchemeGroupVersion.String(),
+			Kind:       "Deployment",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   policy.Namespace,
+			Labels:      deploymentLabels,
+			Annotations: template.Metadata.Annotations,
+		},
+		Spec: template.Spec,
+	}
+}
+
+// IsPropagatedBy whether the deployment of a member cluster was created by the policy
+func IsPropagatedBy(deployment *appsv1.Deployment, policy *v1alpha1.PropagationPolicy) bool {
+	return deployment.Labels[v1alpha1.PropagationPolicyNamespaceLabel] == policy.Namespace &&
+		deployment.Labels[v1alpha1.PropagationPolicyNameLabel] == policy.Name
+}
@@ -0,0 +1,81 @@
+package policy
+
+import (
+	"muti-kube/pkg/api/cluster/v1alpha1"
+	"reflect"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestMatchClusters(t *testing.T) {
+	clusters := []*v1alpha1.Cluster{
+		{ObjectMeta: metav1.ObjectMeta{Name: "cluster-b", Labels: map[string]string{"env": "prod"}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "cluster-a", Labels: map[string]string{
+			"env": "prod", v1alpha1.ClusterSetLabel: "hangzhou"}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "cluster-c", Labels: map[string]string{
+			"env": "test", v1alpha1.ClusterSetLabel: "hangzhou"}}},
+	}
+	prod := &metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}}
+	cases := []struct {
+		affinity v1alpha1.ClusterAffinity
+		expected []string
+	}{
+		{v1alpha1.ClusterAffinity{}, []string{}},
+		{v1alpha1.ClusterAffinity{ClusterNames: []string{"cluster-c", "cluster-x"}}, []string{"cluster-c"}},
+		{v1alpha1.ClusterAffinity{LabelSelector: prod}, []string{"cluster-a", "cluster-b"}},
+		{v1alpha1.ClusterAffinity{ClusterNames: []string{"cluster-b", "cluster-c"}, LabelSelector: prod}, []string{"cluster-b"}},
+		{v1alpha1.ClusterAffinity{ClusterSets: []string{"hangzhou"}}, []string{"cluster-a", "cluster-c"}},
+		{v1alpha1.ClusterAffinity{ClusterSets: []string{"hangzhou"}, LabelSelector: prod}, []string{"cluster-a"}},
+	}
+	for _, c := range cases {
+		names, err := MatchClusters(c.affinity, clusters)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(names, c.expected) {
+			t.Errorf("affinity %+v selects %v, expected %v", c.affinity, names, c.expected)
+		}
+	}
+}
+
+func TestNewDeployment(t *testing.T) {
+	policy := &v1alpha1.PropagationPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "nginx", Namespace: "default"},
+		Spec: v1alpha1.PropagationPolicySpec{
+			Template: v1alpha1.DeploymentTemplate{
+				Metadata: v1alpha1.TemplateMeta{Labels: map[string]string{"app": "nginx"}},
+			},
+		},
+	}
+	deployment := NewDeployment(policy)
+	if deployment.Name != "nginx" || deployment.Namespace != "default" {
+		t.Errorf("unexpected deployment %s/%s", deployment.Namespace, deployment.Name)
+	}
+	if !IsPropagatedBy(deployment, policy) || deployment.Labels["app"] != "nginx" {
+		t.Errorf("unexpected labels %v", deployment.Labels)
+	}
+	if _, ok := policy.Spec.Template.Metadata.Labels[v1alpha1.PropagationPolicyNameLabel]; ok {
+		t.Error("the template of the policy was modified")
+	}
+}
+
+func TestValidatePropagationPolicySpec(t *testing.T) {
+	spec := v1alpha1.PropagationPolicySpec{
+		Template: v1alpha1.DeploymentTemplate{
+			Spec: appsv1.DeploymentSpec{Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "nginx"}}},
+		},
+	}
+	if err := ValidatePropagationPolicySpec(&spec); err == nil {
+		t.Error("a placement selecting no cluster is accepted")
+	}
+	spec.Placement.ClusterAffinity.ClusterNames = []string{"cluster-a"}
+	if err := ValidatePropagationPolicySpec(&spec); err != nil {
+		t.Error(err)
+	}
+	spec.Template.Spec.Selector = nil
+	if err := ValidatePropagationPolicySpec(&spec); err == nil {
+		t.Error("a template without selector is accepted")
+	}
+}
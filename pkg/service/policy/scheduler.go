@@ -0,0 +1,275 @@
+package policy
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/bits"
+	"muti-kube/pkg/api/cluster/v1alpha1"
+	"sort"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// ClusterReady whether the last probe of the cluster succeeded
+func ClusterReady(cluster *v1alpha1.Cluster) bool {
+	return meta.IsStatusConditionTrue(cluster.Status.Conditions, v1alpha1.ClusterReady)
+}
+
+// ClusterFailedOver whether the cluster is tainted by the failover controller, or by hand, with a NoExecute taint
+func ClusterFailedOver(cluster *v1alpha1.Cluster) bool {
+	for _, taint := range cluster.Spec.Taints {
+		if taint.Effect == v1.TaintEffectNoExecute {
+			return true
+		}
+	}
+	return false
+}
+
+// ClusterSchedulable only the ready clusters not failed over are scheduled replicas
+func ClusterSchedulable(cluster *v1alpha1.Cluster) bool {
+	return ClusterReady(cluster) && !ClusterFailedOver(cluster)
+}
+
+// ReplicaSchedule the replicas of every selected cluster, the unavailable clusters are the clusters left out
+// as they were not ready or failed over
+type ReplicaSchedule struct {
+	Replicas            map[string]int32
+	UnavailableClusters []string
+}
+
+// ReplicasOf the replicas of the clusters, nil when no replicas are scheduled
+func (rs *ReplicaSchedule) ReplicasOf() map[string]int32 {
+	if rs == nil {
+		return nil
+	}
+	return rs.Replicas
+}
+
+// ScheduleReplicas divide the replicas of the template across the selected clusters by the replica scheduling
+// of the placement, nil when the placement schedules no replicas. The previous schedule of the status is kept
+// while it still fits the policy and no cluster holding replicas failed over, otherwise the replicas would move
+// with the dynamic weights at every sync, and the clusters not ready yet within the grace period would lose them
+func ScheduleReplicas(policy *v1alpha1.PropagationPolicy, selected []*v1alpha1.Cluster) (*ReplicaSchedule, error) {
+	scheduling := policy.Spec.Placement.ReplicaScheduling
+	if scheduling == nil {
+		return nil, nil
+	}
+	total := int32(1)
+	if policy.Spec.Template.Spec.Replicas != nil {
+		total = *policy.Spec.Template.Spec.Replicas
+	}
+	if total < 0 {
+		return nil, fmt.Errorf("invalid replicas %d", total)
+	}
+	if previous, ok := previousSchedule(policy, selected, total); ok {
+		return previous, nil
+	}
+	weights := make(map[string]int64, len(selected))
+	switch scheduling.Type {
+	case v1alpha1.ReplicaDivisionStatic:
+		for _, cluster := range selected {
+			weight, err := staticWeight(scheduling.StaticWeights, cluster)
+			if err != nil {
+				return nil, err
+			}
+			weights[cluster.Name] = weight
+		}
+	case v1alpha1.ReplicaDivisionDynamic:
+		requests := podRequests(&policy.Spec.Template.Spec.Template.Spec)
+		for _, cluster := range selected {
+			weights[cluster.Name] = AvailableReplicas(cluster, requests)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported replica scheduling type %q", scheduling.Type)
+	}
+	unavailable := make([]string, 0)
+	for _, cluster := range selected {
+		if !ClusterSchedulable(cluster) {
+			weights[cluster.Name] = 0
+			unavailable = append(unavailable, cluster.Name)
+		}
+	}
+	replicas, err := DivideReplicas(total, weights)
+	if err != nil {
+		return nil, err
+	}
+	return &ReplicaSchedule{Replicas: replicas, UnavailableClusters: unavailable}, nil
+}
+
+func staticWeight(staticWeights []v1alpha1.StaticClusterWeight, cluster *v1alpha1.Cluster) (int64, error) {
+	for _, staticWeight := range staticWeights {
+		matched, err := MatchClusters(staticWeight.TargetCluster, []*v1alpha1.Cluster{cluster})
+		if err != nil {
+			return 0, err
+		}
+		if len(matched) > 0 {
+			return staticWeight.Weight, nil
+		}
+	}
+	return 0, nil
+}
+
+// previousSchedule the schedule recorded in the status, when it was made for the current generation, covers
+// every selected cluster, schedules nothing to the clusters failed over and adds up to the replicas.
+// With migrate back, the schedule is dropped once an unavailable cluster is schedulable again
+func previousSchedule(policy *v1alpha1.PropagationPolicy, selected []*v1alpha1.Cluster,
+	total int32) (*ReplicaSchedule, bool) {
+	if policy.Status.ObservedGeneration != policy.Generation {
+		return nil, false
+	}
+	recorded := make(map[string]int32, len(policy.Status.Clusters))
+	for _, status := range policy.Status.Clusters {
+		if status.ScheduledReplicas != nil {
+			recorded[status.Cluster] = *status.ScheduledReplicas
+		}
+	}
+	unavailable := make(map[string]struct{}, len(policy.Status.UnavailableClusters))
+	for _, cluster := range policy.Status.UnavailableClusters {
+		unavailable[cluster] = struct{}{}
+	}
+	migrateBack := policy.Spec.Placement.ReplicaScheduling.MigrateBack
+	schedule := &ReplicaSchedule{
+		Replicas:            make(map[string]int32, len(selected)),
+		UnavailableClusters: make([]string, 0),
+	}
+	sum := int32(0)
+	for _, cluster := range selected {
+		replicas, ok := recorded[cluster.Name]
+		if !ok || (replicas > 0 && ClusterFailedOver(cluster)) {
+			return nil, false
+		}
+		if _, ok := unavailable[cluster.Name]; ok {
+			if migrateBack && ClusterSchedulable(cluster) {
+				return nil, false
+			}
+			schedule.UnavailableClusters = append(schedule.UnavailableClusters, cluster.Name)
+		}
+		schedule.Replicas[cluster.Name] = replicas
+		sum += replicas
+	}
+	return schedule, sum == total
+}
+
+// podRequests the cpu and memory requested by the containers of the pod
+func podRequests(spec *v1.PodSpec) v1.ResourceList {
+	requests := v1.ResourceList{}
+	for _, container := range spec.Containers {
+		for _, name := range []v1.ResourceName{v1.ResourceCPU, v1.ResourceMemory} {
+			if quantity, ok := container.Resources.Requests[name]; ok {
+				sum := requests[name]
+				sum.Add(quantity)
+				requests[name] = sum
+			}
+		}
+	}
+	return requests
+}
+
+// AvailableReplicas the number of pods with the requests the cluster can still run, by the free pod slots and
+// the allocatable cpu and memory not requested yet. A cluster without resources in its status runs none,
+// and a cluster not limited by any of them weighs 1
+func AvailableReplicas(cluster *v1alpha1.Cluster, requests v1.ResourceList) int64 {
+	resources := cluster.Status.Resources
+	if resources == nil {
+		return 0
+	}
+	available := int64(math.MaxInt64)
+	if allocatable, ok := resources.Allocatable[v1.ResourcePods]; ok {
+		used := resources.Usage[v1.ResourcePods]
+		available = allocatable.Value() - used.Value()
+	}
+	for name, request := range requests {
+		allocatable, ok := resources.Allocatable[name]
+		if !ok || quantityValue(name, request) == 0 {
+			continue
+		}
+		requested := resources.Requests[name]
+		replicas := (quantityValue(name, allocatable) - quantityValue(name, requested)) / quantityValue(name, request)
+		if replicas < available {
+			available = replicas
+		}
+	}
+	switch {
+	case available == math.MaxInt64:
+		return 1
+	case available < 0:
+		return 0
+	case available > math.MaxInt32:
+		return math.MaxInt32
+	}
+	return available
+}
+
+// quantityValue cpu in millicores, the milli value of the other resources may overflow
+func quantityValue(name v1.ResourceName, quantity resource.Quantity) int64 {
+	if name == v1.ResourceCPU {
+		return quantity.MilliValue()
+	}
+	return quantity.Value()
+}
+
+// DivideReplicas divide the replicas in proportion to the weights capped to math.MaxInt32, the replicas left by rounding down go to the
+// clusters with the largest remainders, then the largest weights, then in the order of the names
+func DivideReplicas(total int32, weights map[string]int64) (map[string]int32, error) {
+	type share struct {
+		cluster   string
+		weight    int64
+		remainder uint64
+	}
+	normalized := make(map[string]int64, len(weights))
+	sum := int64(0)
+	for cluster, weight := range weights {
+		switch {
+		case weight < 0:
+			weight = 0
+		case weight > math.MaxInt32:
+			weight = math.MaxInt32
+		}
+		normalized[cluster] = weight
+		sum += weight
+	}
+	schedule := make(map[string]int32, len(weights))
+	if sum == 0 {
+		if total == 0 {
+			for cluster := range weights {
+				schedule[cluster] = 0
+			}
+			return schedule, nil
+		}
+		return nil, errors.New("no ready cluster with a weight to schedule the replicas to")
+	}
+	shares := make([]share, 0, len(weights))
+	assigned := int32(0)
+	for cluster, weight := range normalized {
+		// total * weight / sum is at most total, the 128 bits product can not overflow the quotient
+		hi, lo := bits.Mul64(uint64(total), uint64(weight))
+		replicas, remainder := bits.Div64(hi, lo, uint64(sum))
+		schedule[cluster] = int32(replicas)
+		assigned += int32(replicas)
+		shares = append(shares, share{
+			cluster:   cluster,
+			weight:    weight,
+			remainder: remainder,
+		})
+	}
+	sort.Slice(shares, func(i, j int) bool {
+		if shares[i].remainder != shares[j].remainder {
+			return shares[i].remainder > shares[j].remainder
+		}
+		if shares[i].weight != shares[j].weight {
+			return shares[i].weight > shares[j].weight
+		}
+		return shares[i].cluster < shares[j].cluster
+	})
+	for i := 0; assigned < total; i++ {
+		if shares[i%len(shares)].weight == 0 {
+			continue
+		}
+		schedule[shares[i%len(shares)].cluster]++
+		assigned++
+	}
+	return schedule, nil
+}
@@ -0,0 +1,153 @@
+package policy
+
+import (
+	"muti-kube/pkg/api/cluster/v1alpha1"
+	"reflect"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestDivideReplicas(t *testing.T) {
+	cases := []struct {
+		total    int32
+		weights  map[string]int64
+		expected map[string]int32
+	}{
+		{30, map[string]int64{"a": 1, "b": 1, "c": 1}, map[string]int32{"a": 10, "b": 10, "c": 10}},
+		{10, map[string]int64{"a": 1, "b": 1, "c": 1}, map[string]int32{"a": 4, "b": 3, "c": 3}},
+		{10, map[string]int64{"a": 1, "b": 2, "c": 0}, map[string]int32{"a": 3, "b": 7, "c": 0}},
+		{5, map[string]int64{"a": 1 << 40, "b": 1 << 40}, map[string]int32{"a": 3, "b": 2}},
+		{0, map[string]int64{"a": 0}, map[string]int32{"a": 0}},
+	}
+	for _, c := range cases {
+		schedule, err := DivideReplicas(c.total, c.weights)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(schedule, c.expected) {
+			t.Errorf("divide %d by %v: %v, expected %v", c.total, c.weights, schedule, c.expected)
+		}
+	}
+	if _, err := DivideReplicas(3, map[string]int64{"a": 0}); err == nil {
+		t.Error("replicas are scheduled without weights")
+	}
+}
+
+func newScheduledCluster(name string, ready bool, cpu string, requestedCPU string) *v1alpha1.Cluster {
+	status := metav1.ConditionFalse
+	if ready {
+		status = metav1.ConditionTrue
+	}
+	return &v1alpha1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Status: v1alpha1.ClusterStatus{
+			Conditions: []metav1.Condition{{Type: v1alpha1.ClusterReady, Status: status}},
+			Resources: &v1alpha1.ClusterResources{
+				ResourceSummary: v1alpha1.ResourceSummary{
+					Allocatable: v1.ResourceList{v1.ResourceCPU: resource.MustParse(cpu), v1.ResourcePods: resource.MustParse("110")},
+					Requests:    v1.ResourceList{v1.ResourceCPU: resource.MustParse(requestedCPU)},
+					Usage:       v1.ResourceList{v1.ResourcePods: resource.MustParse("10")},
+				},
+			},
+		},
+	}
+}
+
+func TestScheduleReplicas(t *testing.T) {
+	replicas := int32(30)
+	policy := &v1alpha1.PropagationPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "nginx", Namespace: "default", Generation: 1},
+		Spec: v1alpha1.PropagationPolicySpec{
+			Template: v1alpha1.DeploymentTemplate{
+				Spec: appsv1.DeploymentSpec{
+					Replicas: &replicas,
+					Template: v1.PodTemplateSpec{
+						Spec: v1.PodSpec{Containers: []v1.Container{{
+							Name: "nginx",
+							Resources: v1.ResourceRequirements{
+								Requests: v1.ResourceList{v1.ResourceCPU: resource.MustParse("500m")},
+							},
+						}}},
+					},
+				},
+			},
+			Placement: v1alpha1.Placement{
+				ReplicaScheduling: &v1alpha1.ReplicaScheduling{Type: v1alpha1.ReplicaDivisionDynamic},
+			},
+		},
+	}
+	// 40 and 20 replicas of 500m fit in the free cpu of beijing and frankfurt
+	clusters := []*v1alpha1.Cluster{
+		newScheduledCluster("beijing", true, "32", "12"),
+		newScheduledCluster("frankfurt", true, "16", "6"),
+		newScheduledCluster("shanghai", false, "64", "0"),
+	}
+	schedule, err := ScheduleReplicas(policy, clusters)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := &ReplicaSchedule{
+		Replicas:            map[string]int32{"beijing": 20, "frankfurt": 10, "shanghai": 0},
+		UnavailableClusters: []string{"shanghai"},
+	}
+	if !reflect.DeepEqual(schedule, expected) {
+		t.Errorf("dynamic schedule %v, expected %v", schedule, expected)
+	}
+
+	// the recorded schedule is kept while no cluster holding replicas failed over
+	recordSchedule(policy, map[string]int32{"beijing": 15, "frankfurt": 15, "shanghai": 0}, []string{"shanghai"})
+	clusters[1] = newScheduledCluster("frankfurt", false, "16", "6")
+	if schedule, _ = ScheduleReplicas(policy, clusters); schedule.Replicas["frankfurt"] != 15 {
+		t.Errorf("recorded schedule is not kept %v", schedule)
+	}
+	clusters[1].Spec.Taints = []v1.Taint{{Key: v1alpha1.ClusterNotReadyTaint, Effect: v1.TaintEffectNoExecute}}
+	expected = &ReplicaSchedule{
+		Replicas:            map[string]int32{"beijing": 30, "frankfurt": 0, "shanghai": 0},
+		UnavailableClusters: []string{"frankfurt", "shanghai"},
+	}
+	if schedule, _ = ScheduleReplicas(policy, clusters); !reflect.DeepEqual(schedule, expected) {
+		t.Errorf("replicas of the failed over cluster are not moved %v", schedule)
+	}
+
+	// the replicas move back to the recovered cluster only with migrate back
+	recordSchedule(policy, schedule.Replicas, schedule.UnavailableClusters)
+	clusters[1] = newScheduledCluster("frankfurt", true, "16", "6")
+	if schedule, _ = ScheduleReplicas(policy, clusters); schedule.Replicas["frankfurt"] != 0 {
+		t.Errorf("replicas moved back without migrate back %v", schedule)
+	}
+	policy.Spec.Placement.ReplicaScheduling.MigrateBack = true
+	if schedule, _ = ScheduleReplicas(policy, clusters); schedule.Replicas["frankfurt"] != 10 {
+		t.Errorf("replicas not moved back %v", schedule)
+	}
+
+	policy.Generation = 2
+	policy.Spec.Placement.ReplicaScheduling = &v1alpha1.ReplicaScheduling{
+		Type: v1alpha1.ReplicaDivisionStatic,
+		StaticWeights: []v1alpha1.StaticClusterWeight{
+			{TargetCluster: v1alpha1.ClusterAffinity{ClusterNames: []string{"beijing"}}, Weight: 1},
+			{TargetCluster: v1alpha1.ClusterAffinity{ClusterNames: []string{"frankfurt", "shanghai"}}, Weight: 2},
+		},
+	}
+	expected = &ReplicaSchedule{
+		Replicas:            map[string]int32{"beijing": 10, "frankfurt": 20, "shanghai": 0},
+		UnavailableClusters: []string{"shanghai"},
+	}
+	if schedule, _ = ScheduleReplicas(policy, clusters); !reflect.DeepEqual(schedule, expected) {
+		t.Errorf("static schedule %v, expected %v", schedule, expected)
+	}
+}
+
+func recordSchedule(policy *v1alpha1.PropagationPolicy, replicas map[string]int32, unavailable []string) {
+	policy.Status.ObservedGeneration = policy.Generation
+	policy.Status.Clusters = nil
+	for _, name := range []string{"beijing", "frankfurt", "shanghai"} {
+		scheduled := replicas[name]
+		policy.Status.Clusters = append(policy.Status.Clusters,
+			v1alpha1.ClusterPropagationStatus{Cluster: name, ScheduledReplicas: &scheduled})
+	}
+	policy.Status.UnavailableClusters = unavailable
+}
@@ -4,3 +4,13 @@ func ConvertToInt64Ptr(x int) *int64 {
 	xInt64 := int64(x)
 	return &xInt64
 }
+
+// ContainsString whether the value is one of the values
+func ContainsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,117 @@
+package encryption
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io"
+
+	"github.com/spf13/viper"
+)
+
+// EnvelopeAlgorithm name of the envelope encryption recorded next to encrypted data
+const EnvelopeAlgorithm = "envelope-aes-gcm"
+
+// dataKeySize size of the random data key generated for every encryption
+const dataKeySize = 32
+
+type Encryptor interface {
+	Encrypt(plaintext []byte) ([]byte, error)
+	Decrypt(ciphertext []byte) ([]byte, error)
+}
+
+// envelope data encrypted with a random data key, the data key itself is encrypted with the local key
+type envelope struct {
+	DataKey []byte `json:"dek"`
+	Data    []byte `json:"data"`
+}
+
+type envelopeEncryptor struct {
+	keyEncryption cipher.AEAD
+}
+
+// NewEnvelopeEncryptor envelope encryption with AES-GCM, key is the 16, 24 or 32 bytes local key encrypting the data keys
+func NewEnvelopeEncryptor(key []byte) (Encryptor, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	return &envelopeEncryptor{keyEncryption: aead}, nil
+}
+
+// NewEncryptorFromConfig the encryptor of the base64 key in settings.encryption.key, nil when no key is configured
+func NewEncryptorFromConfig() (Encryptor, error) {
+	encodedKey := viper.GetString("settings.encryption.key")
+	if encodedKey == "" {
+		return nil, nil
+	}
+	key, err := base64.StdEncoding.DecodeString(encodedKey)
+	if err != nil {
+		return nil, err
+	}
+	return NewEnvelopeEncryptor(key)
+}
+
+func (e *envelopeEncryptor) Encrypt(plaintext []byte) ([]byte, error) {
+	dataKey := make([]byte, dataKeySize)
+	if _, err := io.ReadFull(rand.Reader, dataKey); err != nil {
+		return nil, err
+	}
+	dataEncryption, err := newAEAD(dataKey)
+	if err != nil {
+		return nil, err
+	}
+	data, err := seal(dataEncryption, plaintext)
+	if err != nil {
+		return nil, err
+	}
+	wrappedKey, err := seal(e.keyEncryption, dataKey)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(&envelope{DataKey: wrappedKey, Data: data})
+}
+
+func (e *envelopeEncryptor) Decrypt(ciphertext []byte) ([]byte, error) {
+	var env envelope
+	if err := json.Unmarshal(ciphertext, &env); err != nil {
+		return nil, err
+	}
+	dataKey, err := open(e.keyEncryption, env.DataKey)
+	if err != nil {
+		return nil, err
+	}
+	dataEncryption, err := newAEAD(dataKey)
+	if err != nil {
+		return nil, err
+	}
+	return open(dataEncryption, env.Data)
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// seal encrypt the plaintext, the random nonce is prepended to the result
+func seal(aead cipher.AEAD, plaintext []byte) ([]byte, error) {
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+	return aead.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+func open(aead cipher.AEAD, ciphertext []byte) ([]byte, error) {
+	if len(ciphertext) < aead.NonceSize() {
+		return nil, errors.New("ciphertext too short")
+	}
+	nonce, data := ciphertext[:aead.NonceSize()], ciphertext[aead.NonceSize():]
+	return aead.Open(nil, nonce, data, nil)
+}
@@ -0,0 +1,36 @@
+package encryption
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestEnvelopeEncryptor(t *testing.T) {
+	encryptor, err := NewEnvelopeEncryptor(bytes.Repeat([]byte("k"), 32))
+	if err != nil {
+		t.Fatal(err)
+	}
+	plaintext := []byte("apiVersion: v1\nkind: Config\n")
+	ciphertext, err := encryptor.Encrypt(plaintext)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(ciphertext, plaintext) {
+		t.Error("ciphertext contains the plaintext")
+	}
+	decrypted, err := encryptor.Decrypt(ciphertext)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(decrypted, plaintext) {
+		t.Errorf("decrypted = %q, want %q", decrypted, plaintext)
+	}
+
+	other, err := NewEnvelopeEncryptor(bytes.Repeat([]byte("o"), 32))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = other.Decrypt(ciphertext); err == nil {
+		t.Error("decrypt with another key should fail")
+	}
+}
@@ -0,0 +1,158 @@
+package terminal
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+
+	"github.com/gorilla/websocket"
+	"k8s.io/client-go/tools/remotecommand"
+)
+
+// Protocol the websocket subprotocol of the session, clients must offer it
+const Protocol = "muti-kube.terminal"
+
+// operations of the messages exchanged with the client
+const (
+	OpStdin  = "stdin"
+	OpStdout = "stdout"
+	OpStderr = "stderr"
+	OpResize = "resize"
+	OpError  = "error"
+)
+
+// Message a json text frame of the websocket,
+// data carries the stdin, stdout, stderr or error text and rows, cols the terminal size of resize
+type Message struct {
+	Op   string `json:"op"`
+	Data string `json:"data,omitempty"`
+	Rows uint16 `json:"rows,omitempty"`
+	Cols uint16 `json:"cols,omitempty"`
+}
+
+// Session bridges a websocket to the streams of remotecommand, it reads stdin and resize messages
+// from the client and writes stdout and stderr messages back
+type Session struct {
+	conn *websocket.Conn
+	// serializes the writes, gorilla supports only one concurrent writer
+	writeLock sync.Mutex
+	sizeChan  chan remotecommand.TerminalSize
+	// pending stdin not yet consumed by Read
+	stdin     []byte
+	closeOnce sync.Once
+	done      chan struct{}
+}
+
+func NewSession(conn *websocket.Conn) *Session {
+	return &Session{
+		conn:     conn,
+		sizeChan: make(chan remotecommand.TerminalSize, 1),
+		done:     make(chan struct{}),
+	}
+}
+
+// Read the stdin of the client, resize messages are queued for Next and malformed messages skipped,
+// io.EOF is returned once the client goes away
+func (s *Session) Read(p []byte) (int, error) {
+	for len(s.stdin) == 0 {
+		_, data, err := s.conn.ReadMessage()
+		if err != nil {
+			s.Close()
+			return 0, io.EOF
+		}
+		message := Message{}
+		if err := json.Unmarshal(data, &message); err != nil {
+			continue
+		}
+		switch message.Op {
+		case OpStdin:
+			s.stdin = []byte(message.Data)
+		case OpResize:
+			s.resize(remotecommand.TerminalSize{Width: message.Cols, Height: message.Rows})
+		}
+	}
+	n := copy(p, s.stdin)
+	s.stdin = s.stdin[n:]
+	return n, nil
+}
+
+// Write p to the client as stdout
+func (s *Session) Write(p []byte) (int, error) {
+	if err := s.WriteMessage(&Message{Op: OpStdout, Data: string(p)}); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// Stderr the writer sending p to the client as stderr
+func (s *Session) Stderr() io.Writer {
+	return writerFunc(func(p []byte) (int, error) {
+		if err := s.WriteMessage(&Message{Op: OpStderr, Data: string(p)}); err != nil {
+			return 0, err
+		}
+		return len(p), nil
+	})
+}
+
+func (s *Session) WriteMessage(message *Message) error {
+	data, err := json.Marshal(message)
+	if err != nil {
+		return err
+	}
+	s.writeLock.Lock()
+	defer s.writeLock.Unlock()
+	return s.conn.WriteMessage(websocket.TextMessage, data)
+}
+
+// Fail send the error to the client and close the session
+func (s *Session) Fail(err error) {
+	_ = s.WriteMessage(&Message{Op: OpError, Data: err.Error()})
+	s.Close()
+}
+
+// Next the next size of the terminal, nil once the session is closed
+func (s *Session) Next() *remotecommand.TerminalSize {
+	select {
+	case size := <-s.sizeChan:
+		return &size
+	default:
+	}
+	select {
+	case size := <-s.sizeChan:
+		return &size
+	case <-s.done:
+		return nil
+	}
+}
+
+// Done closed when the session is closed
+func (s *Session) Done() <-chan struct{} {
+	return s.done
+}
+
+// Close the websocket with a normal closure
+func (s *Session) Close() {
+	s.closeOnce.Do(func() {
+		close(s.done)
+		s.writeLock.Lock()
+		_ = s.conn.WriteMessage(websocket.CloseMessage,
+			websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+		s.writeLock.Unlock()
+		_ = s.conn.Close()
+	})
+}
+
+// resize keep only the latest size when the executor has not consumed the previous one
+func (s *Session) resize(size remotecommand.TerminalSize) {
+	select {
+	case <-s.sizeChan:
+	default:
+	}
+	s.sizeChan <- size
+}
+
+type writerFunc func(p []byte) (int, error)
+
+func (f writerFunc) Write(p []byte) (int, error) {
+	return f(p)
+}
@@ -0,0 +1,78 @@
+package terminal
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/websocket"
+	"github.com/spf13/viper"
+)
+
+func TestSession(t *testing.T) {
+	upgrader := websocket.Upgrader{}
+	stdin := make(chan string, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		session := NewSession(conn)
+		data, _ := io.ReadAll(session)
+		stdin <- string(data)
+		size := session.Next()
+		if size == nil || size.Width != 120 || size.Height != 40 {
+			t.Errorf("unexpected size %v", size)
+		}
+		if session.Next() != nil {
+			t.Error("no size should be left once the session is closed")
+		}
+	}))
+	defer server.Close()
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, message := range []interface{}{
+		Message{Op: OpStdin, Data: "ls\n"},
+		Message{Op: OpResize, Rows: 40, Cols: 120},
+		"not a message",
+		Message{Op: OpStdin, Data: "exit\n"},
+	} {
+		if err := conn.WriteJSON(message); err != nil {
+			t.Fatal(err)
+		}
+	}
+	_ = conn.Close()
+	if data := <-stdin; data != "ls\nexit\n" {
+		t.Errorf("unexpected stdin %q", data)
+	}
+}
+
+func TestCheckOrigin(t *testing.T) {
+	viper.Set("settings.terminal.allowedorigins", []string{"https://console.example.com/"})
+	defer viper.Set("settings.terminal.allowedorigins", nil)
+	tests := []struct {
+		origin  string
+		allowed bool
+	}{
+		{"", true},
+		{"https://muti-kube.example.com", true},
+		{"https://console.example.com", true},
+		{"https://evil.example.com", false},
+		{"http://muti-kube.example.com.evil.com", false},
+	}
+	for _, test := range tests {
+		r := httptest.NewRequest(http.MethodGet, "https://muti-kube.example.com/ws", nil)
+		if test.origin != "" {
+			r.Header.Set("Origin", test.origin)
+		}
+		if allowed := CheckOrigin(r); allowed != test.allowed {
+			t.Errorf("origin %q: allowed %v, expected %v", test.origin, allowed, test.allowed)
+		}
+	}
+}
@@ -0,0 +1,13 @@
+package auth
+
+import (
+	"muti-kube/apis/auth"
+	"muti-kube/pkg/authorization"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterAuthRouter(v1alpha1 *gin.RouterGroup, authorizer authorization.Authorizer) {
+	authApi := auth.NewAuth(authorizer)
+	v1alpha1.GET("/auth/can-i", authApi.CanI)
+}
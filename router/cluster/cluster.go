@@ -2,19 +2,48 @@ package cluster
 
 import (
 	"muti-kube/apis/cluster"
+	"muti-kube/middleware"
+	"muti-kube/pkg/authorization"
 	"muti-kube/pkg/util/logger"
 
 	"github.com/gin-gonic/gin"
 )
 
-func RegisterClusterRouter(v1alpha1 *gin.RouterGroup) {
+func RegisterClusterRouter(v1alpha1 *gin.RouterGroup, authorizer authorization.Authorizer) {
 	clusterApi, err := cluster.NewCluster()
 	if err != nil {
 		logger.Error(err)
 		return
 	}
-	v1alpha1.GET("/clusters", clusterApi.GetClusters)
-	v1alpha1.GET("/clusters/:clusterID", clusterApi.GetCluster)
-	v1alpha1.POST("/clusters", clusterApi.CreateCluster)
-	v1alpha1.GET("/clusters/:clusterID/nodes/:nodeName/metrics", clusterApi.GetNodeMetrics)
+	authorize := func(verb string, resource string) gin.HandlerFunc {
+		return middleware.Authorization(authorizer, verb, resource)
+	}
+	v1alpha1.GET("/clusters",
+		authorize(authorization.VerbList, authorization.ResourceClusters), clusterApi.GetClusters)
+	v1alpha1.GET("/clusters/:clusterID",
+		authorize(authorization.VerbGet, authorization.ResourceClusters), clusterApi.GetCluster)
+	v1alpha1.POST("/clusters",
+		authorize(authorization.VerbCreate, authorization.ResourceClusters), clusterApi.CreateCluster)
+	v1alpha1.PUT("/clusters/:clusterID",
+		authorize(authorization.VerbUpdate, authorization.ResourceClusters), clusterApi.UpdateCluster)
+	v1alpha1.PATCH("/clusters/:clusterID",
+		authorize(authorization.VerbPatch, authorization.ResourceClusters), clusterApi.PatchCluster)
+	v1alpha1.DELETE("/clusters/:clusterID",
+		authorize(authorization.VerbDelete, authorization.ResourceClusters), clusterApi.DeleteCluster)
+	v1alpha1.GET("/clusters/:clusterID/nodes",
+		authorize(authorization.VerbList, authorization.ResourceNodes), clusterApi.GetNodes)
+	v1alpha1.GET("/clusters/:clusterID/nodes/:nodeName",
+		authorize(authorization.VerbGet, authorization.ResourceNodes), clusterApi.GetNode)
+	v1alpha1.POST("/clusters/:clusterID/nodes/:nodeName",
+		authorize(authorization.VerbPatch, authorization.ResourceNodes), clusterApi.NodeAction)
+	v1alpha1.PUT("/clusters/:clusterID/nodes/:nodeName/labels",
+		authorize(authorization.VerbPatch, authorization.ResourceNodes), clusterApi.UpdateNodeLabels)
+	v1alpha1.PUT("/clusters/:clusterID/nodes/:nodeName/taints",
+		authorize(authorization.VerbUpdate, authorization.ResourceNodes), clusterApi.UpdateNodeTaints)
+	v1alpha1.POST("/clusters/:clusterID/nodes/:nodeName/drain",
+		authorize(authorization.VerbCreate, authorization.ResourceNodeDrain), clusterApi.DrainNode)
+	v1alpha1.GET("/clusters/:clusterID/nodes/:nodeName/drain",
+		authorize(authorization.VerbGet, authorization.ResourceNodeDrain), clusterApi.GetNodeDrain)
+	v1alpha1.GET("/clusters/:clusterID/nodes/:nodeName/metrics",
+		authorize(authorization.VerbGet, authorization.ResourceNodeMetrics), clusterApi.GetNodeMetrics)
 }
@@ -0,0 +1,31 @@
+package cluster
+
+import (
+	"muti-kube/apis/cluster"
+	"muti-kube/middleware"
+	"muti-kube/pkg/authorization"
+	"muti-kube/pkg/util/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterClusterSetRouter(v1alpha1 *gin.RouterGroup, authorizer authorization.Authorizer) {
+	clusterSetApi, err := cluster.NewClusterSet()
+	if err != nil {
+		logger.Error(err)
+		return
+	}
+	authorize := func(verb string) gin.HandlerFunc {
+		return middleware.Authorization(authorizer, verb, authorization.ResourceClusterSets)
+	}
+	v1alpha1.GET("/clustersets",
+		authorize(authorization.VerbList), clusterSetApi.GetClusterSets)
+	v1alpha1.POST("/clustersets",
+		authorize(authorization.VerbCreate), clusterSetApi.CreateClusterSet)
+	v1alpha1.GET("/clustersets/:name",
+		authorize(authorization.VerbGet), clusterSetApi.GetClusterSet)
+	v1alpha1.PUT("/clustersets/:name",
+		authorize(authorization.VerbUpdate), clusterSetApi.UpdateClusterSet)
+	v1alpha1.DELETE("/clustersets/:name",
+		authorize(authorization.VerbDelete), clusterSetApi.DeleteClusterSet)
+}
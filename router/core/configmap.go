@@ -0,0 +1,31 @@
+package core
+
+import (
+	coreService "muti-kube/apis/core"
+	"muti-kube/middleware"
+	"muti-kube/pkg/authorization"
+	"muti-kube/pkg/util/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterConfigMapRouter(v1alpha1 *gin.RouterGroup, authorizer authorization.Authorizer) {
+	configMapApi, err := coreService.NewConfigMap()
+	if err != nil {
+		logger.Error(err)
+		return
+	}
+	authorize := func(verb string) gin.HandlerFunc {
+		return middleware.Authorization(authorizer, verb, authorization.ResourceConfigMaps)
+	}
+	v1alpha1.GET("/clusters/:clusterID/namespaces/:namespace/configmaps",
+		authorize(authorization.VerbList), configMapApi.GetConfigMaps)
+	v1alpha1.POST("/clusters/:clusterID/namespaces/:namespace/configmaps",
+		authorize(authorization.VerbCreate), configMapApi.ConfigMapAction)
+	v1alpha1.GET("/clusters/:clusterID/namespaces/:namespace/configmaps/:configMapID",
+		authorize(authorization.VerbGet), configMapApi.GetConfigMap)
+	v1alpha1.PUT("/clusters/:clusterID/namespaces/:namespace/configmaps/:configMapID",
+		authorize(authorization.VerbUpdate), configMapApi.UpdateConfigMap)
+	v1alpha1.DELETE("/clusters/:clusterID/namespaces/:namespace/configmaps/:configMapID",
+		authorize(authorization.VerbDelete), configMapApi.DeleteConfigMap)
+}
@@ -0,0 +1,37 @@
+package core
+
+import (
+	"muti-kube/apis"
+	coreService "muti-kube/apis/core"
+	"muti-kube/middleware"
+	"muti-kube/pkg/authorization"
+	"muti-kube/pkg/util/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterCronJobRouter(v1alpha1 *gin.RouterGroup, authorizer authorization.Authorizer) {
+	cronJobApi, err := coreService.NewCronJob()
+	if err != nil {
+		logger.Error(err)
+		return
+	}
+	authorize := func(verb string) gin.HandlerFunc {
+		return middleware.Authorization(authorizer, verb, authorization.ResourceCronJobs)
+	}
+	v1alpha1.GET("/clusters/:clusterID/namespaces/:namespace/cronjobs",
+		authorize(authorization.VerbList), cronJobApi.GetCronJobs)
+	v1alpha1.POST("/clusters/:clusterID/namespaces/:namespace/cronjobs",
+		authorize(authorization.VerbCreate), cronJobApi.CronJobAction)
+	v1alpha1.GET("/clusters/:clusterID/namespaces/:namespace/cronjobs/:cronJobID",
+		authorize(authorization.VerbGet), cronJobApi.GetCronJob)
+	v1alpha1.PUT("/clusters/:clusterID/namespaces/:namespace/cronjobs/:cronJobID",
+		authorize(authorization.VerbUpdate), cronJobApi.UpdateCronJob)
+	// the trigger action creates a job of the cronjob
+	authorizeTrigger := middleware.RequestAuthorization(authorizer, coreService.IsAction(apis.TriggerAction),
+		authorization.VerbCreate, authorization.ResourceJobs)
+	v1alpha1.POST("/clusters/:clusterID/namespaces/:namespace/cronjobs/:cronJobID",
+		authorize(authorization.VerbUpdate), authorizeTrigger, cronJobApi.CronJobItemAction)
+	v1alpha1.DELETE("/clusters/:clusterID/namespaces/:namespace/cronjobs/:cronJobID",
+		authorize(authorization.VerbDelete), cronJobApi.DeleteCronJob)
+}
@@ -0,0 +1,31 @@
+package core
+
+import (
+	coreService "muti-kube/apis/core"
+	"muti-kube/middleware"
+	"muti-kube/pkg/authorization"
+	"muti-kube/pkg/util/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterDaemonSetRouter(v1alpha1 *gin.RouterGroup, authorizer authorization.Authorizer) {
+	daemonSetApi, err := coreService.NewDaemonSet()
+	if err != nil {
+		logger.Error(err)
+		return
+	}
+	authorize := func(verb string) gin.HandlerFunc {
+		return middleware.Authorization(authorizer, verb, authorization.ResourceDaemonSets)
+	}
+	v1alpha1.GET("/clusters/:clusterID/namespaces/:namespace/daemonsets",
+		authorize(authorization.VerbList), daemonSetApi.GetDaemonSets)
+	v1alpha1.POST("/clusters/:clusterID/namespaces/:namespace/daemonsets",
+		authorize(authorization.VerbCreate), daemonSetApi.DaemonSetAction)
+	v1alpha1.GET("/clusters/:clusterID/namespaces/:namespace/daemonsets/:daemonSetID",
+		authorize(authorization.VerbGet), daemonSetApi.GetDaemonSet)
+	v1alpha1.PUT("/clusters/:clusterID/namespaces/:namespace/daemonsets/:daemonSetID",
+		authorize(authorization.VerbUpdate), daemonSetApi.UpdateDaemonSet)
+	v1alpha1.DELETE("/clusters/:clusterID/namespaces/:namespace/daemonsets/:daemonSetID",
+		authorize(authorization.VerbDelete), daemonSetApi.DeleteDaemonSet)
+}
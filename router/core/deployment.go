@@ -2,18 +2,38 @@ package core
 
 import (
 	coreService "muti-kube/apis/core"
+	"muti-kube/middleware"
+	"muti-kube/pkg/authorization"
 	"muti-kube/pkg/util/logger"
 
 	"github.com/gin-gonic/gin"
 )
 
-func RegisterDeploymentRouter(v1alpha1 *gin.RouterGroup) {
+func RegisterDeploymentRouter(v1alpha1 *gin.RouterGroup, authorizer authorization.Authorizer) {
 	deploymentApi, err := coreService.NewDeployment()
 	if err != nil {
 		logger.Error(err)
 		return
 	}
-	v1alpha1.GET("/clusters/:clusterID/namespaces/:namespace/deployments", deploymentApi.GetDeployments)
-	v1alpha1.POST("/clusters/:clusterID/namespaces/:namespace/deployments", deploymentApi.DeploymentAction)
-	v1alpha1.DELETE("/clusters/:clusterID/namespaces/:namespace/deployments/:deploymentID",deploymentApi.DeleteDeployment)
+	authorize := func(verb string) gin.HandlerFunc {
+		return middleware.Authorization(authorizer, verb, authorization.ResourceDeployments)
+	}
+	v1alpha1.GET("/clusters/:clusterID/namespaces/:namespace/deployments",
+		authorize(authorization.VerbList), deploymentApi.GetDeployments)
+	v1alpha1.POST("/clusters/:clusterID/namespaces/:namespace/deployments",
+		middleware.DynamicAuthorization(authorizer, coreService.DeploymentActionAttributes), deploymentApi.DeploymentAction)
+	v1alpha1.GET("/clusters/:clusterID/namespaces/:namespace/deployments/:deploymentID",
+		authorize(authorization.VerbGet), deploymentApi.GetDeployment)
+	v1alpha1.PUT("/clusters/:clusterID/namespaces/:namespace/deployments/:deploymentID",
+		authorize(authorization.VerbUpdate), deploymentApi.UpdateDeployment)
+	v1alpha1.PATCH("/clusters/:clusterID/namespaces/:namespace/deployments/:deploymentID",
+		authorize(authorization.VerbPatch), deploymentApi.PatchDeployment)
+	v1alpha1.POST("/clusters/:clusterID/namespaces/:namespace/deployments/:deploymentID",
+		authorize(authorization.VerbUpdate), deploymentApi.DeploymentItemAction)
+	v1alpha1.DELETE("/clusters/:clusterID/namespaces/:namespace/deployments/:deploymentID",
+		authorize(authorization.VerbDelete), deploymentApi.DeleteDeployment)
+	v1alpha1.GET("/clusters/:clusterID/namespaces/:namespace/deployments/:deploymentID/status",
+		authorize(authorization.VerbGet), deploymentApi.GetDeploymentRolloutStatus)
+	v1alpha1.GET("/clusters/:clusterID/namespaces/:namespace/deployments/:deploymentID/revisions",
+		authorize(authorization.VerbGet), deploymentApi.GetDeploymentRevisions)
 }
@@ -0,0 +1,25 @@
+package core
+
+import (
+	coreService "muti-kube/apis/core"
+	"muti-kube/middleware"
+	"muti-kube/pkg/authorization"
+	"muti-kube/pkg/util/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterEndpointSliceRouter(v1alpha1 *gin.RouterGroup, authorizer authorization.Authorizer) {
+	endpointSliceApi, err := coreService.NewEndpointSlice()
+	if err != nil {
+		logger.Error(err)
+		return
+	}
+	authorize := func(verb string) gin.HandlerFunc {
+		return middleware.Authorization(authorizer, verb, authorization.ResourceEndpointSlices)
+	}
+	v1alpha1.GET("/clusters/:clusterID/namespaces/:namespace/endpointslices",
+		authorize(authorization.VerbList), endpointSliceApi.GetEndpointSlices)
+	v1alpha1.GET("/clusters/:clusterID/namespaces/:namespace/endpointslices/:endpointSliceID",
+		authorize(authorization.VerbGet), endpointSliceApi.GetEndpointSlice)
+}
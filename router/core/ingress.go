@@ -0,0 +1,31 @@
+package core
+
+import (
+	coreService "muti-kube/apis/core"
+	"muti-kube/middleware"
+	"muti-kube/pkg/authorization"
+	"muti-kube/pkg/util/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterIngressRouter(v1alpha1 *gin.RouterGroup, authorizer authorization.Authorizer) {
+	ingressApi, err := coreService.NewIngress()
+	if err != nil {
+		logger.Error(err)
+		return
+	}
+	authorize := func(verb string) gin.HandlerFunc {
+		return middleware.Authorization(authorizer, verb, authorization.ResourceIngresses)
+	}
+	v1alpha1.GET("/clusters/:clusterID/namespaces/:namespace/ingresses",
+		authorize(authorization.VerbList), ingressApi.GetIngresses)
+	v1alpha1.POST("/clusters/:clusterID/namespaces/:namespace/ingresses",
+		authorize(authorization.VerbCreate), ingressApi.IngressAction)
+	v1alpha1.GET("/clusters/:clusterID/namespaces/:namespace/ingresses/:ingressID",
+		authorize(authorization.VerbGet), ingressApi.GetIngress)
+	v1alpha1.PUT("/clusters/:clusterID/namespaces/:namespace/ingresses/:ingressID",
+		authorize(authorization.VerbUpdate), ingressApi.UpdateIngress)
+	v1alpha1.DELETE("/clusters/:clusterID/namespaces/:namespace/ingresses/:ingressID",
+		authorize(authorization.VerbDelete), ingressApi.DeleteIngress)
+}
@@ -0,0 +1,29 @@
+package core
+
+import (
+	coreService "muti-kube/apis/core"
+	"muti-kube/middleware"
+	"muti-kube/pkg/authorization"
+	"muti-kube/pkg/util/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterJobRouter(v1alpha1 *gin.RouterGroup, authorizer authorization.Authorizer) {
+	jobApi, err := coreService.NewJob()
+	if err != nil {
+		logger.Error(err)
+		return
+	}
+	authorize := func(verb string) gin.HandlerFunc {
+		return middleware.Authorization(authorizer, verb, authorization.ResourceJobs)
+	}
+	v1alpha1.GET("/clusters/:clusterID/namespaces/:namespace/jobs",
+		authorize(authorization.VerbList), jobApi.GetJobs)
+	v1alpha1.POST("/clusters/:clusterID/namespaces/:namespace/jobs",
+		authorize(authorization.VerbCreate), jobApi.JobAction)
+	v1alpha1.GET("/clusters/:clusterID/namespaces/:namespace/jobs/:jobID",
+		authorize(authorization.VerbGet), jobApi.GetJob)
+	v1alpha1.DELETE("/clusters/:clusterID/namespaces/:namespace/jobs/:jobID",
+		authorize(authorization.VerbDelete), jobApi.DeleteJob)
+}
@@ -0,0 +1,33 @@
+package core
+
+import (
+	coreService "muti-kube/apis/core"
+	"muti-kube/middleware"
+	"muti-kube/pkg/authorization"
+	"muti-kube/pkg/util/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterNamespaceRouter(v1alpha1 *gin.RouterGroup, authorizer authorization.Authorizer) {
+	namespaceApi, err := coreService.NewNamespace()
+	if err != nil {
+		logger.Error(err)
+		return
+	}
+	authorize := func(verb string) gin.HandlerFunc {
+		return middleware.Authorization(authorizer, verb, authorization.ResourceNamespaces)
+	}
+	v1alpha1.GET("/clusters/:clusterID/namespaces",
+		authorize(authorization.VerbList), namespaceApi.GetNamespaces)
+	v1alpha1.POST("/clusters/:clusterID/namespaces",
+		authorize(authorization.VerbCreate), namespaceApi.NamespaceAction)
+	v1alpha1.GET("/clusters/:clusterID/namespaces/:namespace",
+		authorize(authorization.VerbGet), namespaceApi.GetNamespace)
+	v1alpha1.PUT("/clusters/:clusterID/namespaces/:namespace",
+		authorize(authorization.VerbUpdate), namespaceApi.UpdateNamespace)
+	v1alpha1.DELETE("/clusters/:clusterID/namespaces/:namespace",
+		authorize(authorization.VerbDelete), namespaceApi.DeleteNamespace)
+	v1alpha1.GET("/clusters/:clusterID/namespaces/:namespace/summary",
+		authorize(authorization.VerbGet), namespaceApi.GetNamespaceSummary)
+}
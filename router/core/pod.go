@@ -1 +1,35 @@
 package core
+
+import (
+	coreService "muti-kube/apis/core"
+	"muti-kube/middleware"
+	"muti-kube/pkg/authorization"
+	"muti-kube/pkg/util/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterPodRouter(v1alpha1 *gin.RouterGroup, authorizer authorization.Authorizer) {
+	podApi, err := coreService.NewPod()
+	if err != nil {
+		logger.Error(err)
+		return
+	}
+	authorize := func(verb string) gin.HandlerFunc {
+		return middleware.Authorization(authorizer, verb, authorization.ResourcePods)
+	}
+	v1alpha1.GET("/clusters/:clusterID/namespaces/:namespace/pods",
+		authorize(authorization.VerbList), podApi.GetPods)
+	v1alpha1.GET("/clusters/:clusterID/namespaces/:namespace/pods/:podID",
+		authorize(authorization.VerbGet), podApi.GetPod)
+	v1alpha1.DELETE("/clusters/:clusterID/namespaces/:namespace/pods/:podID",
+		authorize(authorization.VerbDelete), podApi.DeletePod)
+	v1alpha1.GET("/clusters/:clusterID/namespaces/:namespace/pods/:podID/logs",
+		middleware.Authorization(authorizer, authorization.VerbGet, authorization.ResourcePodLogs), podApi.GetPodLogs)
+	v1alpha1.GET("/clusters/:clusterID/namespaces/:namespace/pods/:podID/logs/stream",
+		middleware.Authorization(authorizer, authorization.VerbGet, authorization.ResourcePodLogs), podApi.StreamPodLogs)
+	v1alpha1.GET("/clusters/:clusterID/namespaces/:namespace/pods/:podID/exec",
+		middleware.Authorization(authorizer, authorization.VerbCreate, authorization.ResourcePodExec), podApi.ExecPod)
+	v1alpha1.GET("/clusters/:clusterID/namespaces/:namespace/pods/:podID/events",
+		authorize(authorization.VerbGet), podApi.GetPodEvents)
+}
@@ -0,0 +1,36 @@
+package core
+
+import (
+	coreService "muti-kube/apis/core"
+	"muti-kube/middleware"
+	"muti-kube/pkg/authorization"
+	"muti-kube/pkg/util/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterDynamicResourceRouter(v1alpha1 *gin.RouterGroup, authorizer authorization.Authorizer) {
+	resourceApi, err := coreService.NewDynamicResource()
+	if err != nil {
+		logger.Error(err)
+		return
+	}
+	authorize := middleware.DynamicAuthorization(authorizer, coreService.ResourceAttributes)
+	v1alpha1.GET("/clusters/:clusterID/resources",
+		middleware.Authorization(authorizer, authorization.VerbGet, authorization.ResourceClusters), resourceApi.GetAPIResources)
+	v1alpha1.GET("/clusters/:clusterID/resources/:group/:version/:resource",
+		authorize, resourceApi.GetResource)
+	v1alpha1.POST("/clusters/:clusterID/resources/:group/:version/:resource",
+		authorize, resourceApi.ResourceAction)
+	// the namespace and name of the path are parsed by the handlers as the scope of the resource is discovered
+	v1alpha1.GET("/clusters/:clusterID/resources/:group/:version/:resource/*path",
+		authorize, resourceApi.GetResource)
+	v1alpha1.POST("/clusters/:clusterID/resources/:group/:version/:resource/*path",
+		authorize, resourceApi.ResourceAction)
+	v1alpha1.PUT("/clusters/:clusterID/resources/:group/:version/:resource/*path",
+		authorize, resourceApi.ApplyResource)
+	v1alpha1.PATCH("/clusters/:clusterID/resources/:group/:version/:resource/*path",
+		authorize, resourceApi.PatchResource)
+	v1alpha1.DELETE("/clusters/:clusterID/resources/:group/:version/:resource/*path",
+		authorize, resourceApi.DeleteResource)
+}
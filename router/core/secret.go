@@ -0,0 +1,33 @@
+package core
+
+import (
+	coreService "muti-kube/apis/core"
+	"muti-kube/middleware"
+	"muti-kube/pkg/authorization"
+	"muti-kube/pkg/util/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterSecretRouter(v1alpha1 *gin.RouterGroup, authorizer authorization.Authorizer) {
+	secretApi, err := coreService.NewSecret()
+	if err != nil {
+		logger.Error(err)
+		return
+	}
+	authorize := func(verb string) gin.HandlerFunc {
+		return middleware.Authorization(authorizer, verb, authorization.ResourceSecrets)
+	}
+	v1alpha1.GET("/clusters/:clusterID/namespaces/:namespace/secrets",
+		authorize(authorization.VerbList), secretApi.GetSecrets)
+	v1alpha1.POST("/clusters/:clusterID/namespaces/:namespace/secrets",
+		authorize(authorization.VerbCreate), secretApi.SecretAction)
+	v1alpha1.GET("/clusters/:clusterID/namespaces/:namespace/secrets/:secretID",
+		authorize(authorization.VerbGet), secretApi.GetSecret)
+	v1alpha1.GET("/clusters/:clusterID/namespaces/:namespace/secrets/:secretID/reveal",
+		middleware.Authorization(authorizer, authorization.VerbGet, authorization.ResourceSecretReveal), secretApi.RevealSecret)
+	v1alpha1.PUT("/clusters/:clusterID/namespaces/:namespace/secrets/:secretID",
+		authorize(authorization.VerbUpdate), secretApi.UpdateSecret)
+	v1alpha1.DELETE("/clusters/:clusterID/namespaces/:namespace/secrets/:secretID",
+		authorize(authorization.VerbDelete), secretApi.DeleteSecret)
+}
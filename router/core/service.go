@@ -0,0 +1,33 @@
+package core
+
+import (
+	coreService "muti-kube/apis/core"
+	"muti-kube/middleware"
+	"muti-kube/pkg/authorization"
+	"muti-kube/pkg/util/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterServiceRouter(v1alpha1 *gin.RouterGroup, authorizer authorization.Authorizer) {
+	serviceApi, err := coreService.NewService()
+	if err != nil {
+		logger.Error(err)
+		return
+	}
+	authorize := func(verb string) gin.HandlerFunc {
+		return middleware.Authorization(authorizer, verb, authorization.ResourceServices)
+	}
+	v1alpha1.GET("/clusters/:clusterID/namespaces/:namespace/services",
+		authorize(authorization.VerbList), serviceApi.GetServices)
+	v1alpha1.POST("/clusters/:clusterID/namespaces/:namespace/services",
+		authorize(authorization.VerbCreate), serviceApi.ServiceAction)
+	v1alpha1.GET("/clusters/:clusterID/namespaces/:namespace/services/:serviceID",
+		authorize(authorization.VerbGet), serviceApi.GetService)
+	v1alpha1.PUT("/clusters/:clusterID/namespaces/:namespace/services/:serviceID",
+		authorize(authorization.VerbUpdate), serviceApi.UpdateService)
+	v1alpha1.DELETE("/clusters/:clusterID/namespaces/:namespace/services/:serviceID",
+		authorize(authorization.VerbDelete), serviceApi.DeleteService)
+	v1alpha1.GET("/clusters/:clusterID/namespaces/:namespace/services/:serviceID/pods",
+		middleware.Authorization(authorizer, authorization.VerbList, authorization.ResourcePods), serviceApi.GetServicePods)
+}
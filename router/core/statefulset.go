@@ -0,0 +1,33 @@
+package core
+
+import (
+	coreService "muti-kube/apis/core"
+	"muti-kube/middleware"
+	"muti-kube/pkg/authorization"
+	"muti-kube/pkg/util/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterStatefulSetRouter(v1alpha1 *gin.RouterGroup, authorizer authorization.Authorizer) {
+	statefulSetApi, err := coreService.NewStatefulSet()
+	if err != nil {
+		logger.Error(err)
+		return
+	}
+	authorize := func(verb string) gin.HandlerFunc {
+		return middleware.Authorization(authorizer, verb, authorization.ResourceStatefulSets)
+	}
+	v1alpha1.GET("/clusters/:clusterID/namespaces/:namespace/statefulsets",
+		authorize(authorization.VerbList), statefulSetApi.GetStatefulSets)
+	v1alpha1.POST("/clusters/:clusterID/namespaces/:namespace/statefulsets",
+		authorize(authorization.VerbCreate), statefulSetApi.StatefulSetAction)
+	v1alpha1.GET("/clusters/:clusterID/namespaces/:namespace/statefulsets/:statefulSetID",
+		authorize(authorization.VerbGet), statefulSetApi.GetStatefulSet)
+	v1alpha1.PUT("/clusters/:clusterID/namespaces/:namespace/statefulsets/:statefulSetID",
+		authorize(authorization.VerbUpdate), statefulSetApi.UpdateStatefulSet)
+	v1alpha1.POST("/clusters/:clusterID/namespaces/:namespace/statefulsets/:statefulSetID",
+		authorize(authorization.VerbUpdate), statefulSetApi.StatefulSetItemAction)
+	v1alpha1.DELETE("/clusters/:clusterID/namespaces/:namespace/statefulsets/:statefulSetID",
+		authorize(authorization.VerbDelete), statefulSetApi.DeleteStatefulSet)
+}
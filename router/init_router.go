@@ -2,8 +2,15 @@ package router
 
 import (
 	"fmt"
+	"muti-kube/middleware"
+	"muti-kube/pkg/authentication"
+	"muti-kube/pkg/authorization"
+	"muti-kube/pkg/service"
+	"muti-kube/pkg/util/logger"
+	"muti-kube/router/auth"
 	"muti-kube/router/cluster"
 	"muti-kube/router/core"
+	"muti-kube/router/policy"
 
 	"github.com/gin-gonic/gin"
 )
@@ -14,11 +21,59 @@ func InitRouter() *gin.Engine {
 
 func baseRouterV1() *gin.Engine {
 	r := gin.New()
-	addRouter(r.Group(fmt.Sprintf("/api/%s/%s", VERSION, SERVERNAME)))
+	v1alpha1 := r.Group(fmt.Sprintf("/api/%s/%s", VERSION, SERVERNAME))
+	var authorizer authorization.Authorizer
+	if authenticator := newAuthenticator(); authenticator != nil {
+		v1alpha1.Use(middleware.Authentication(authenticator))
+		authorizer = newAuthorizer()
+	} else {
+		logger.Warn("no authenticator configured, the api is served without authentication")
+	}
+	addRouter(v1alpha1, authorizer)
 	return r
 }
 
-func addRouter(v1alpha1 *gin.RouterGroup) {
-	cluster.RegisterClusterRouter(v1alpha1)
-	core.RegisterDeploymentRouter(v1alpha1)
+func newAuthorizer() authorization.Authorizer {
+	bs, err := service.NewBase()
+	if err != nil {
+		logger.Fatal(err)
+	}
+	return authorization.NewRBACAuthorizer(bs.GetRoleLister(), bs.GetRoleBindingLister())
+}
+
+func newAuthenticator() authentication.Authenticator {
+	options, err := authentication.NewAuthenticationOptionsFromConfig()
+	if err != nil {
+		logger.Fatal(err)
+	}
+	bs, err := service.NewBase()
+	if err != nil {
+		logger.Fatal(err)
+	}
+	authenticator, err := options.NewAuthenticator(bs.GetHostClient())
+	if err != nil {
+		logger.Fatal(err)
+	}
+	return authenticator
+}
+
+func addRouter(v1alpha1 *gin.RouterGroup, authorizer authorization.Authorizer) {
+	auth.RegisterAuthRouter(v1alpha1, authorizer)
+	cluster.RegisterClusterRouter(v1alpha1, authorizer)
+	cluster.RegisterClusterSetRouter(v1alpha1, authorizer)
+	core.RegisterDeploymentRouter(v1alpha1, authorizer)
+	core.RegisterStatefulSetRouter(v1alpha1, authorizer)
+	core.RegisterDaemonSetRouter(v1alpha1, authorizer)
+	core.RegisterJobRouter(v1alpha1, authorizer)
+	core.RegisterCronJobRouter(v1alpha1, authorizer)
+	core.RegisterPodRouter(v1alpha1, authorizer)
+	core.RegisterServiceRouter(v1alpha1, authorizer)
+	core.RegisterIngressRouter(v1alpha1, authorizer)
+	core.RegisterEndpointSliceRouter(v1alpha1, authorizer)
+	core.RegisterConfigMapRouter(v1alpha1, authorizer)
+	core.RegisterSecretRouter(v1alpha1, authorizer)
+	core.RegisterNamespaceRouter(v1alpha1, authorizer)
+	core.RegisterDynamicResourceRouter(v1alpha1, authorizer)
+	policy.RegisterPropagationPolicyRouter(v1alpha1, authorizer)
+	policy.RegisterOverridePolicyRouter(v1alpha1, authorizer)
 }
@@ -0,0 +1,31 @@
+package policy
+
+import (
+	policyApi "muti-kube/apis/policy"
+	"muti-kube/middleware"
+	"muti-kube/pkg/authorization"
+	"muti-kube/pkg/util/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterOverridePolicyRouter(v1alpha1 *gin.RouterGroup, authorizer authorization.Authorizer) {
+	overrideApi, err := policyApi.NewOverridePolicy()
+	if err != nil {
+		logger.Error(err)
+		return
+	}
+	authorize := func(verb string) gin.HandlerFunc {
+		return middleware.Authorization(authorizer, verb, authorization.ResourceOverridePolicies)
+	}
+	v1alpha1.GET("/namespaces/:namespace/overridepolicies",
+		authorize(authorization.VerbList), overrideApi.GetOverridePolicies)
+	v1alpha1.POST("/namespaces/:namespace/overridepolicies",
+		authorize(authorization.VerbCreate), overrideApi.OverridePolicyAction)
+	v1alpha1.GET("/namespaces/:namespace/overridepolicies/:name",
+		authorize(authorization.VerbGet), overrideApi.GetOverridePolicy)
+	v1alpha1.PUT("/namespaces/:namespace/overridepolicies/:name",
+		authorize(authorization.VerbUpdate), overrideApi.UpdateOverridePolicy)
+	v1alpha1.DELETE("/namespaces/:namespace/overridepolicies/:name",
+		authorize(authorization.VerbDelete), overrideApi.DeleteOverridePolicy)
+}
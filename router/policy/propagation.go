@@ -0,0 +1,33 @@
+package policy
+
+import (
+	policyApi "muti-kube/apis/policy"
+	"muti-kube/middleware"
+	"muti-kube/pkg/authorization"
+	"muti-kube/pkg/util/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterPropagationPolicyRouter(v1alpha1 *gin.RouterGroup, authorizer authorization.Authorizer) {
+	propagationApi, err := policyApi.NewPropagationPolicy()
+	if err != nil {
+		logger.Error(err)
+		return
+	}
+	authorize := func(verb string) gin.HandlerFunc {
+		return middleware.Authorization(authorizer, verb, authorization.ResourcePropagationPolicies)
+	}
+	v1alpha1.GET("/namespaces/:namespace/propagationpolicies",
+		authorize(authorization.VerbList), propagationApi.GetPropagationPolicies)
+	v1alpha1.POST("/namespaces/:namespace/propagationpolicies",
+		authorize(authorization.VerbCreate), propagationApi.PropagationPolicyAction)
+	v1alpha1.GET("/namespaces/:namespace/propagationpolicies/:name",
+		authorize(authorization.VerbGet), propagationApi.GetPropagationPolicy)
+	v1alpha1.PUT("/namespaces/:namespace/propagationpolicies/:name",
+		authorize(authorization.VerbUpdate), propagationApi.UpdatePropagationPolicy)
+	v1alpha1.DELETE("/namespaces/:namespace/propagationpolicies/:name",
+		authorize(authorization.VerbDelete), propagationApi.DeletePropagationPolicy)
+	v1alpha1.GET("/namespaces/:namespace/propagationpolicies/:name/preview",
+		authorize(authorization.VerbGet), propagationApi.PreviewPropagationPolicy)
+}